@@ -10,8 +10,10 @@ require (
 	github.com/google/go-cmp v0.5.7
 	github.com/google/uuid v1.2.0 // indirect
 	github.com/hashicorp/go-getter v1.5.11
+	github.com/hashicorp/go-hclog v1.1.0
 	github.com/hashicorp/go-memdb v1.3.2
 	github.com/hashicorp/go-multierror v1.1.1
+	github.com/hashicorp/go-plugin v1.4.3
 	github.com/hashicorp/go-uuid v1.0.2
 	github.com/hashicorp/go-version v1.4.0
 	github.com/hashicorp/hc-install v0.3.1
@@ -21,6 +23,7 @@ require (
 	github.com/hashicorp/terraform-json v0.13.0
 	github.com/hashicorp/terraform-registry-address v0.0.0-20210816115301-cb2034eba045
 	github.com/hashicorp/terraform-schema v0.0.0-20220111104703-762daa2d811e
+	github.com/hashicorp/yamux v0.0.0-20181012175058-2f1d1f20f75d // indirect
 	github.com/kylelemons/godebug v1.1.0 // indirect
 	github.com/mh-cbon/go-fmt-fail v0.0.0-20160815164508-67765b3fbcb5
 	github.com/mitchellh/cli v1.1.2
@@ -36,4 +39,6 @@ require (
 	github.com/zclconf/go-cty v1.10.0
 	github.com/zclconf/go-cty-debug v0.0.0-20191215020915-b22d67c1ba0b
 	golang.org/x/tools v0.1.9
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 )
@@ -21,6 +21,7 @@ func (k *contextKey) String() string {
 
 var (
 	ctxDs                   = &contextKey{"document storage"}
+	ctxFs                   = &contextKey{"filesystem"}
 	ctxTfExecPath           = &contextKey{"terraform executable path"}
 	ctxTfExecLogPath        = &contextKey{"terraform executor log path"}
 	ctxTfExecTimeout        = &contextKey{"terraform execution timeout"}
@@ -30,10 +31,12 @@ var (
 	ctxModuleWalker         = &contextKey{"module walker"}
 	ctxRootDir              = &contextKey{"root directory"}
 	ctxCommandPrefix        = &contextKey{"command prefix"}
+	ctxFolderCmdPrefixes    = &contextKey{"workspace folder command prefixes"}
 	ctxDiagsNotifier        = &contextKey{"diagnostics notifier"}
 	ctxLsVersion            = &contextKey{"language server version"}
 	ctxProgressToken        = &contextKey{"progress token"}
 	ctxExperimentalFeatures = &contextKey{"experimental features"}
+	ctxVariablesFileName    = &contextKey{"variables file name"}
 )
 
 func missingContextErr(ctxKey *contextKey) *MissingContextErr {
@@ -53,6 +56,19 @@ func DocumentStorage(ctx context.Context) (filesystem.DocumentStorage, error) {
 	return fs, nil
 }
 
+func WithFilesystem(ctx context.Context, fs filesystem.Filesystem) context.Context {
+	return context.WithValue(ctx, ctxFs, fs)
+}
+
+func Filesystem(ctx context.Context) (filesystem.Filesystem, error) {
+	fs, ok := ctx.Value(ctxFs).(filesystem.Filesystem)
+	if !ok {
+		return nil, missingContextErr(ctxFs)
+	}
+
+	return fs, nil
+}
+
 func WithTerraformExecLogPath(ctx context.Context, path string) context.Context {
 	return context.WithValue(ctx, ctxTfExecLogPath, path)
 }
@@ -160,6 +176,17 @@ func CommandPrefix(ctx context.Context) (string, bool) {
 	return *commandPrefix, true
 }
 
+func WithFolderCommandPrefixes(ctx context.Context, prefixes []string) context.Context {
+	return context.WithValue(ctx, ctxFolderCmdPrefixes, prefixes)
+}
+
+// FolderCommandPrefixes returns command prefixes configured
+// for individual workspace folders
+func FolderCommandPrefixes(ctx context.Context) []string {
+	prefixes, _ := ctx.Value(ctxFolderCmdPrefixes).([]string)
+	return prefixes
+}
+
 func WithModuleWalker(ctx context.Context, w *module.Walker) context.Context {
 	return context.WithValue(ctx, ctxModuleWalker, w)
 }
@@ -230,3 +257,12 @@ func ExperimentalFeatures(ctx context.Context) (settings.ExperimentalFeatures, e
 	}
 	return *expFeatures, nil
 }
+
+func WithVariablesFileName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxVariablesFileName, name)
+}
+
+func VariablesFileName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(ctxVariablesFileName).(string)
+	return name, ok
+}
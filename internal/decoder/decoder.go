@@ -11,6 +11,7 @@ import (
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
 
@@ -20,7 +21,7 @@ func NewDecoder(ctx context.Context, pathReader decoder.PathReader) *decoder.Dec
 	return d
 }
 
-func modulePathContext(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader) (*decoder.PathContext, error) {
+func modulePathContext(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader, mergeOverrides bool) (*decoder.PathContext, error) {
 	schema, err := schemaForModule(mod, schemaReader, modReader)
 	if err != nil {
 		return nil, err
@@ -46,7 +47,11 @@ func modulePathContext(mod *state.Module, schemaReader state.SchemaReader, modRe
 		}
 	}
 
-	for name, f := range mod.ParsedModuleFiles {
+	files := mod.ParsedModuleFiles
+	if mergeOverrides {
+		files, _ = parser.MergeOverrideFiles(files)
+	}
+	for name, f := range files {
 		pathCtx.Files[name.String()] = f
 	}
 
@@ -0,0 +1,105 @@
+package decoder
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+)
+
+type HighlightKind uint
+
+const (
+	// ReadHighlight represents a reference origin
+	ReadHighlight HighlightKind = iota
+	// WriteHighlight represents a definition of a reference target
+	WriteHighlight
+)
+
+// Highlight represents a range related to the reference
+// (origin or target) at a given position
+type Highlight struct {
+	Range hcl.Range
+	Kind  HighlightKind
+}
+
+type targetInPath struct {
+	Target reference.Target
+	Path   lang.Path
+}
+
+// ReferenceHighlights returns definitions of targets referenced
+// (or declared) at the position, along with all origins referring
+// to these targets, as long as they are within the same file.
+//
+// Origins are matched the same way as in the reference count code lens.
+func ReferenceHighlights(pathReader decoder.PathReader, path lang.Path, file string, pos hcl.Pos) []Highlight {
+	highlights := make([]Highlight, 0)
+
+	pathCtx, err := pathReader.PathContext(path)
+	if err != nil {
+		return highlights
+	}
+
+	targets := make([]targetInPath, 0)
+	if origins, ok := pathCtx.ReferenceOrigins.AtPos(file, pos); ok {
+		for _, origin := range origins {
+			targetCtx := pathCtx
+			targetPath := path
+
+			if pathOrigin, ok := origin.(reference.PathOrigin); ok {
+				ctx, err := pathReader.PathContext(pathOrigin.TargetPath)
+				if err != nil {
+					continue
+				}
+				targetCtx = ctx
+				targetPath = pathOrigin.TargetPath
+			}
+
+			matchingTargets, ok := targetCtx.ReferenceTargets.Match(origin.Address(), origin.OriginConstraints())
+			if !ok {
+				continue
+			}
+			for _, target := range matchingTargets {
+				targets = append(targets, targetInPath{target, targetPath})
+			}
+		}
+	} else if matchingTargets, ok := pathCtx.ReferenceTargets.InnermostAtPos(file, pos); ok {
+		for _, target := range matchingTargets {
+			targets = append(targets, targetInPath{target, path})
+		}
+	}
+
+	seen := make(map[Highlight]bool, 0)
+	addHighlight := func(h Highlight) {
+		if !seen[h] {
+			seen[h] = true
+			highlights = append(highlights, h)
+		}
+	}
+
+	for _, t := range targets {
+		if t.Path.Equals(path) && t.Target.RangePtr != nil && t.Target.RangePtr.Filename == file {
+			rng := *t.Target.RangePtr
+			if t.Target.DefRangePtr != nil {
+				rng = *t.Target.DefRangePtr
+			}
+			addHighlight(Highlight{Range: rng, Kind: WriteHighlight})
+		}
+
+		for _, origin := range pathCtx.ReferenceOrigins.Match(path, t.Target, t.Path) {
+			if origin.OriginRange().Filename != file {
+				continue
+			}
+			addHighlight(Highlight{Range: origin.OriginRange(), Kind: ReadHighlight})
+		}
+	}
+
+	sort.SliceStable(highlights, func(i, j int) bool {
+		return highlights[i].Range.Start.Byte < highlights[j].Range.Start.Byte
+	})
+
+	return highlights
+}
@@ -0,0 +1,302 @@
+package decoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+type InlayHintKind uint
+
+const (
+	// ValueHint represents effective value of a reference
+	ValueHint InlayHintKind = iota
+	// TypeHint represents type of a reference
+	TypeHint
+)
+
+// InlayHint represents a label displayed after a reference
+type InlayHint struct {
+	Pos     hcl.Pos
+	Label   string
+	Tooltip string
+	Kind    InlayHintKind
+}
+
+// maxHintValueLength is the maximum length of a value
+// in a hint label, before it is truncated
+const maxHintValueLength = 40
+
+// InlayHints returns hints for references to variables and local values
+// within the given range of a module file.
+//
+// Variable references are annotated with the effective value, which is
+// the value from activeVarsFile (if any), autoloaded variable files,
+// or the default value, in order of precedence. References to local
+// values are annotated with their type, where it can be inferred.
+func InlayHints(mod *state.Module, rng hcl.Range, activeVarsFile string) []InlayHint {
+	hints := make([]InlayHint, 0)
+
+	values := effectiveVariableValues(mod, ast.VarsFilename(activeVarsFile))
+	types := newTypeInferrer(mod.RefTargets, functions.Functions(mod.TerraformVersion))
+
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || localOrigin.Range.Filename != rng.Filename ||
+			!rangeOverlaps(localOrigin.Range, rng) || len(localOrigin.Addr) < 2 {
+			continue
+		}
+		root, ok := localOrigin.Addr[0].(lang.RootStep)
+		if !ok {
+			continue
+		}
+		name, ok := localOrigin.Addr[1].(lang.AttrStep)
+		if !ok {
+			continue
+		}
+
+		switch root.Name {
+		case "var":
+			hint, ok := variableValueHint(mod, values, name.Name, localOrigin.Addr[2:])
+			if !ok {
+				continue
+			}
+			hint.Pos = localOrigin.Range.End
+			hints = append(hints, hint)
+		case "local":
+			typ := types.localType(mod, localOrigin.Addr)
+			if typ == cty.DynamicPseudoType {
+				continue
+			}
+			hints = append(hints, InlayHint{
+				Pos:   localOrigin.Range.End,
+				Label: ": " + typ.FriendlyNameForConstraint(),
+				Kind:  TypeHint,
+			})
+		}
+	}
+
+	sort.SliceStable(hints, func(i, j int) bool {
+		return hints[i].Pos.Byte < hints[j].Pos.Byte
+	})
+
+	return hints
+}
+
+// variableValue represents effective value of a variable
+// along with its source
+type variableValue struct {
+	Value  cty.Value
+	Source string
+}
+
+// effectiveVariableValues returns values of variables assigned
+// in variable files, keyed by variable name, in the order
+// of precedence which Terraform applies
+func effectiveVariableValues(mod *state.Module, activeVarsFile ast.VarsFilename) map[string]variableValue {
+	values := make(map[string]variableValue, 0)
+
+	files := make([]ast.VarsFilename, 0)
+	for name := range mod.ParsedVarsFiles {
+		if !name.IsAutoloaded() && name != activeVarsFile {
+			continue
+		}
+		files = append(files, name)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return varsFilePrecedence(files[i], activeVarsFile) < varsFilePrecedence(files[j], activeVarsFile)
+	})
+
+	for _, name := range files {
+		f, ok := mod.ParsedVarsFiles[name]
+		if !ok {
+			continue
+		}
+		attrs, _ := f.Body.JustAttributes()
+		for _, attr := range attrs {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				continue
+			}
+			values[attr.Name] = variableValue{
+				Value:  val,
+				Source: name.String(),
+			}
+		}
+	}
+
+	return values
+}
+
+// varsFilePrecedence returns precedence of the variable file,
+// where files with higher precedence override values of others
+func varsFilePrecedence(name, activeVarsFile ast.VarsFilename) string {
+	switch {
+	case name == activeVarsFile:
+		return "3"
+	case name == "terraform.tfvars":
+		return "0"
+	case name == "terraform.tfvars.json":
+		return "1"
+	}
+	// *.auto.tfvars are loaded in lexical order
+	return "2" + name.String()
+}
+
+func variableValueHint(mod *state.Module, values map[string]variableValue, name string, steps lang.Address) (InlayHint, bool) {
+	variable, ok := mod.Meta.Variables[name]
+	if !ok {
+		return InlayHint{}, false
+	}
+
+	if variable.IsSensitive {
+		return InlayHint{
+			Label:   "= (sensitive value)",
+			Tooltip: fmt.Sprintf("var.%s is marked as sensitive", name),
+			Kind:    ValueHint,
+		}, true
+	}
+
+	var val cty.Value
+	var tooltip string
+	if v, ok := values[name]; ok {
+		val = v.Value
+		tooltip = fmt.Sprintf("Value of var.%s from %s", name, v.Source)
+	} else if variable.DefaultValue != cty.NilVal {
+		val = variable.DefaultValue
+		tooltip = fmt.Sprintf("Default value of var.%s", name)
+	} else {
+		return InlayHint{}, false
+	}
+
+	if variable.Type != cty.NilType {
+		if converted, err := convert.Convert(val, variable.Type); err == nil {
+			val = converted
+		}
+	}
+
+	val, ok = valueAtSteps(val, steps)
+	if !ok {
+		return InlayHint{}, false
+	}
+
+	return InlayHint{
+		Label:   "= " + formatHintValue(val),
+		Tooltip: tooltip,
+		Kind:    ValueHint,
+	}, true
+}
+
+// valueAtSteps returns the value nested within val
+// at the given (attribute or index) steps
+func valueAtSteps(val cty.Value, steps lang.Address) (cty.Value, bool) {
+	for _, step := range steps {
+		if val.IsNull() || !val.IsKnown() {
+			return cty.NilVal, false
+		}
+		switch s := step.(type) {
+		case lang.AttrStep:
+			typ := val.Type()
+			switch {
+			case typ.IsObjectType() && typ.HasAttribute(s.Name):
+				val = val.GetAttr(s.Name)
+			case typ.IsMapType() && val.HasIndex(cty.StringVal(s.Name)).True():
+				val = val.Index(cty.StringVal(s.Name))
+			default:
+				return cty.NilVal, false
+			}
+		case lang.IndexStep:
+			if !val.CanIterateElements() || !val.HasIndex(s.Key).True() {
+				return cty.NilVal, false
+			}
+			val = val.Index(s.Key)
+		default:
+			return cty.NilVal, false
+		}
+	}
+	return val, true
+}
+
+// formatHintValue returns the value formatted
+// on a single line, truncated if it is too long
+func formatHintValue(val cty.Value) string {
+	src := string(hclwrite.TokensForValue(val).Bytes())
+
+	// collapse multi-line values (objects and maps)
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	src = strings.Join(lines, " ")
+	src = strings.ReplaceAll(src, "{ }", "{}")
+
+	runes := []rune(src)
+	if len(runes) > maxHintValueLength {
+		return string(runes[:maxHintValueLength-1]) + "…"
+	}
+	return src
+}
+
+// localType returns type of the local value at the given address,
+// inferred from its expression if the type of the target is not known
+func (ti *typeInferrer) localType(mod *state.Module, addr lang.Address) cty.Type {
+	typ := ti.addressType(addr)
+	if typ != cty.DynamicPseudoType || len(addr) != 2 {
+		return typ
+	}
+
+	for _, target := range ti.targets {
+		if !target.Addr.Equals(addr) || target.RangePtr == nil {
+			continue
+		}
+		f, ok := mod.ParsedModuleFiles[ast.ModFilename(target.RangePtr.Filename)]
+		if !ok {
+			continue
+		}
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		attr, ok := attributeAtRange(body, *target.RangePtr)
+		if !ok {
+			continue
+		}
+		return ti.inferType(attr.Expr)
+	}
+
+	return cty.DynamicPseudoType
+}
+
+// attributeAtRange returns the attribute within the body
+// (or any nested blocks) declared at the given range
+func attributeAtRange(body *hclsyntax.Body, rng hcl.Range) (*hclsyntax.Attribute, bool) {
+	for _, attr := range body.Attributes {
+		if attr.SrcRange.Start.Byte == rng.Start.Byte && attr.SrcRange.End.Byte == rng.End.Byte {
+			return attr, true
+		}
+	}
+	for _, block := range body.Blocks {
+		if !rangeOverlaps(block.Range(), rng) {
+			continue
+		}
+		if attr, ok := attributeAtRange(block.Body, rng); ok {
+			return attr, true
+		}
+	}
+	return nil, false
+}
+
+func rangeOverlaps(a, b hcl.Range) bool {
+	return a.Start.Byte <= b.End.Byte && b.Start.Byte <= a.End.Byte
+}
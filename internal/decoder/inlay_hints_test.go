@@ -0,0 +1,76 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFormatHintValue(t *testing.T) {
+	testCases := []struct {
+		val      cty.Value
+		expected string
+	}{
+		{cty.StringVal("t2.micro"), `"t2.micro"`},
+		{cty.NumberIntVal(42), `42`},
+		{cty.NullVal(cty.String), `null`},
+		{cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}), `["a", "b"]`},
+		{cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("web"),
+			"size": cty.NumberIntVal(2),
+		}), `{ name = "web" size = 2 }`},
+		{cty.MapValEmpty(cty.String), `{}`},
+		{cty.StringVal("a very long value which does not fit into the hint"), `"a very long value which does not fit i…`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			formatted := formatHintValue(tc.val)
+			if formatted != tc.expected {
+				t.Fatalf("expected %q, given %q", tc.expected, formatted)
+			}
+		})
+	}
+}
+
+func TestEffectiveVariableValues(t *testing.T) {
+	varsFiles := map[string]string{
+		"terraform.tfvars": "name = \"default\"\nsize = 1\n",
+		"b.auto.tfvars":    "size = 3\n",
+		"a.auto.tfvars":    "size = 2\nregion = \"eu\"\n",
+		"dev.tfvars":       "region = \"us\"\n",
+		"unrelated.tfvars": "name = \"unrelated\"\n",
+	}
+	files := make(ast.VarsFiles, 0)
+	for name, src := range varsFiles {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		files[ast.VarsFilename(name)] = f
+	}
+	mod := &state.Module{
+		Path:            "/test",
+		ParsedVarsFiles: files,
+	}
+
+	values := effectiveVariableValues(mod, "dev.tfvars")
+
+	expected := map[string]string{
+		"name":   "terraform.tfvars",
+		"size":   "b.auto.tfvars",
+		"region": "dev.tfvars",
+	}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %d values, given %d: %#v", len(expected), len(values), values)
+	}
+	for name, source := range expected {
+		if values[name].Source != source {
+			t.Fatalf("expected %q to come from %q, given %q", name, source, values[name].Source)
+		}
+	}
+}
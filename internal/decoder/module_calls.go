@@ -0,0 +1,233 @@
+package decoder
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/pathcmp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ModuleCallBlock represents a module block and the module it calls
+type ModuleCallBlock struct {
+	// CallerPath is the path of the module declaring the block
+	CallerPath string
+	Name       string
+	SourceAddr string
+	// Range is the range of the whole block
+	Range hcl.Range
+	// NameRange is the range of the block label
+	NameRange hcl.Range
+	// Path is the path of the called module,
+	// or empty if it cannot be determined
+	Path string
+}
+
+// ModuleCallBlocks returns all module blocks declared in the module,
+// ordered by filename and position.
+//
+// Called modules are looked up in module manifests, i.e. as installed
+// by Terraform, or by the local source address if not installed yet.
+// Modules installed within another module (such as a child module
+// calling another module) are looked up by the full key of the record
+// in the manifest of the root module, e.g. "child.grandchild".
+func ModuleCallBlocks(modReader state.ModuleReader, mod *state.Module) ([]ModuleCallBlock, error) {
+	installs, err := moduleInstalls(modReader, mod)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := moduleCallBlocks(mod)
+	for i, call := range calls {
+		calls[i].Path = calledPath(installs, call.CallerPath, call.Name, call.SourceAddr)
+	}
+
+	return calls, nil
+}
+
+// ModuleCallerBlocks returns module blocks of all known modules
+// which call the module at the given path, as recorded
+// in the module manifests
+func ModuleCallerBlocks(modReader state.ModuleReader, modPath string) ([]ModuleCallBlock, error) {
+	callers := make([]ModuleCallBlock, 0)
+
+	roots, err := modReader.CallersOfModule(modPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].Path < roots[j].Path
+	})
+
+	seen := make(map[string]bool, 0)
+	for _, root := range roots {
+		for _, record := range root.ModManifest.Records {
+			if record.IsRoot() || !pathcmp.PathEquals(filepath.Join(root.Path, record.Dir), modPath) {
+				continue
+			}
+
+			callerPath := root.Path
+			callerKey, name := splitRecordKey(record.Key)
+			if callerKey != "" {
+				callerRecord, ok := manifestRecord(root.ModManifest, callerKey)
+				if !ok {
+					continue
+				}
+				callerPath = filepath.Join(root.Path, callerRecord.Dir)
+			}
+
+			caller, err := modReader.ModuleByPath(callerPath)
+			if err != nil {
+				// the calling module may not be loaded (yet)
+				continue
+			}
+
+			for _, call := range moduleCallBlocks(caller) {
+				if call.Name != name {
+					continue
+				}
+				key := filepath.Join(call.CallerPath, call.Range.Filename) + ":" + call.Name
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				call.Path = modPath
+				callers = append(callers, call)
+			}
+		}
+	}
+
+	return callers, nil
+}
+
+// moduleCallBlocks returns module blocks declared in the module,
+// without resolving paths of the called modules
+func moduleCallBlocks(mod *state.Module) []ModuleCallBlock {
+	calls := make([]ModuleCallBlock, 0)
+
+	for name, f := range mod.ParsedModuleFiles {
+		if name.IsOverride() {
+			continue
+		}
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "module" || len(block.Labels) != 1 {
+				continue
+			}
+
+			call := ModuleCallBlock{
+				CallerPath: mod.Path,
+				Name:       block.Labels[0],
+				Range:      block.Range(),
+				NameRange:  block.LabelRanges[0],
+			}
+			if attr, ok := block.Body.Attributes["source"]; ok {
+				val, diags := attr.Expr.Value(nil)
+				if !diags.HasErrors() && val.Type().Equals(cty.String) && val.IsKnown() && !val.IsNull() {
+					call.SourceAddr = val.AsString()
+				}
+			}
+
+			calls = append(calls, call)
+		}
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		if calls[i].Range.Filename != calls[j].Range.Filename {
+			return calls[i].Range.Filename < calls[j].Range.Filename
+		}
+		return calls[i].Range.Start.Byte < calls[j].Range.Start.Byte
+	})
+
+	return calls
+}
+
+// moduleInstall represents a module as recorded in the manifest
+// of a root module, under the given key (empty for the root module)
+type moduleInstall struct {
+	rootPath string
+	manifest *datadir.ModuleManifest
+	key      string
+}
+
+// moduleInstalls returns all known installations of the module,
+// i.e. as a root module, or as a module called by other modules
+func moduleInstalls(modReader state.ModuleReader, mod *state.Module) ([]moduleInstall, error) {
+	installs := make([]moduleInstall, 0)
+	if mod.ModManifest != nil {
+		installs = append(installs, moduleInstall{
+			rootPath: mod.Path,
+			manifest: mod.ModManifest,
+		})
+	}
+
+	roots, err := modReader.CallersOfModule(mod.Path)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].Path < roots[j].Path
+	})
+
+	for _, root := range roots {
+		for _, record := range root.ModManifest.Records {
+			if record.IsRoot() || !pathcmp.PathEquals(filepath.Join(root.Path, record.Dir), mod.Path) {
+				continue
+			}
+			installs = append(installs, moduleInstall{
+				rootPath: root.Path,
+				manifest: root.ModManifest,
+				key:      record.Key,
+			})
+		}
+	}
+
+	return installs, nil
+}
+
+func calledPath(installs []moduleInstall, callerPath, name, sourceAddr string) string {
+	for _, install := range installs {
+		key := name
+		if install.key != "" {
+			key = install.key + "." + name
+		}
+		if record, ok := manifestRecord(install.manifest, key); ok {
+			return filepath.Join(install.rootPath, record.Dir)
+		}
+	}
+
+	if strings.HasPrefix(sourceAddr, "./") || strings.HasPrefix(sourceAddr, "../") {
+		return filepath.Join(callerPath, filepath.FromSlash(sourceAddr))
+	}
+
+	return ""
+}
+
+func manifestRecord(mm *datadir.ModuleManifest, key string) (datadir.ModuleRecord, bool) {
+	for _, record := range mm.Records {
+		if record.Key == key {
+			return record, true
+		}
+	}
+	return datadir.ModuleRecord{}, false
+}
+
+// splitRecordKey splits the key of a manifest record (e.g. "child.grandchild")
+// into the key of the calling module ("child") and the name of the module call
+func splitRecordKey(key string) (string, string) {
+	i := strings.LastIndex(key, ".")
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
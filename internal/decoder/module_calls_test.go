@@ -0,0 +1,187 @@
+package decoder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+)
+
+func TestModuleCallBlocks(t *testing.T) {
+	modPath := filepath.Join("test", "root")
+	cfg := `module "local" {
+  source = "./child"
+}
+
+module "installed" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.11.0"
+}
+
+module "uninstalled" {
+  source = "terraform-aws-modules/eks/aws"
+}
+`
+	ss := testModuleStore(t, map[string]string{
+		modPath:                         cfg,
+		filepath.Join(modPath, "child"): childCfg,
+	}, map[string]*datadir.ModuleManifest{
+		modPath: testManifest(modPath),
+	})
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := ModuleCallBlocks(ss.Modules, mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCalls := []call{
+		{"local", "./child", filepath.Join(modPath, "child")},
+		{"installed", "terraform-aws-modules/vpc/aws", filepath.Join(modPath, ".terraform", "modules", "installed")},
+		{"uninstalled", "terraform-aws-modules/eks/aws", ""},
+	}
+	if diff := cmp.Diff(expectedCalls, toCalls(calls)); diff != "" {
+		t.Fatalf("unexpected calls: %s", diff)
+	}
+
+	// calls of nested modules are looked up by full key
+	childMod, err := ss.Modules.ModuleByPath(filepath.Join(modPath, "child"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err = ModuleCallBlocks(ss.Modules, childMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCalls = []call{
+		{"nested", "terraform-aws-modules/eks/aws", filepath.Join(modPath, "modules", "nested")},
+	}
+	if diff := cmp.Diff(expectedCalls, toCalls(calls)); diff != "" {
+		t.Fatalf("unexpected calls of child module: %s", diff)
+	}
+}
+
+func TestModuleCallerBlocks(t *testing.T) {
+	modPath := filepath.Join("test", "root")
+	cfg := `module "local" {
+  source = "./child"
+}
+`
+	ss := testModuleStore(t, map[string]string{
+		modPath:                         cfg,
+		filepath.Join(modPath, "child"): childCfg,
+	}, map[string]*datadir.ModuleManifest{
+		modPath: testManifest(modPath),
+	})
+
+	callers, err := ModuleCallerBlocks(ss.Modules, filepath.Join(modPath, "child"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []call{
+		{"local", "./child", filepath.Join(modPath, "child")},
+	}
+	if diff := cmp.Diff(expectedCalls, toCalls(callers)); diff != "" {
+		t.Fatalf("unexpected callers: %s", diff)
+	}
+	if callers[0].CallerPath != modPath {
+		t.Fatalf("expected caller path %q, given: %q", modPath, callers[0].CallerPath)
+	}
+
+	callers, err = ModuleCallerBlocks(ss.Modules, filepath.Join(modPath, "modules", "nested"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls = []call{
+		{"nested", "terraform-aws-modules/eks/aws", filepath.Join(modPath, "modules", "nested")},
+	}
+	if diff := cmp.Diff(expectedCalls, toCalls(callers)); diff != "" {
+		t.Fatalf("unexpected callers of nested module: %s", diff)
+	}
+	if expectedPath := filepath.Join(modPath, "child"); callers[0].CallerPath != expectedPath {
+		t.Fatalf("expected caller path %q, given: %q", expectedPath, callers[0].CallerPath)
+	}
+}
+
+const childCfg = `module "nested" {
+  source = "terraform-aws-modules/eks/aws"
+}
+`
+
+type call struct {
+	Name       string
+	SourceAddr string
+	Path       string
+}
+
+func toCalls(blocks []ModuleCallBlock) []call {
+	calls := make([]call, len(blocks))
+	for i, c := range blocks {
+		calls[i] = call{c.Name, c.SourceAddr, c.Path}
+	}
+	return calls
+}
+
+func testManifest(modPath string) *datadir.ModuleManifest {
+	return datadir.NewModuleManifest(modPath, []datadir.ModuleRecord{
+		{
+			Key: "",
+			Dir: ".",
+		},
+		{
+			Key:        "local",
+			SourceAddr: "./child",
+			Dir:        "child",
+		},
+		{
+			Key:        "local.nested",
+			SourceAddr: "terraform-aws-modules/eks/aws",
+			Dir:        filepath.Join("modules", "nested"),
+		},
+		{
+			Key:        "installed",
+			SourceAddr: "terraform-aws-modules/vpc/aws",
+			VersionStr: "3.11.0",
+			Dir:        filepath.Join(".terraform", "modules", "installed"),
+		},
+	})
+}
+
+func testModuleStore(t *testing.T, cfgs map[string]string, manifests map[string]*datadir.ModuleManifest) *state.StateStore {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for modPath, cfg := range cfgs {
+		f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		err = ss.Modules.Add(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.Modules.UpdateParsedModuleFiles(modPath, ast.ModFiles{"main.tf": f}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mm, ok := manifests[modPath]; ok {
+			err = ss.Modules.UpdateModManifest(modPath, mm, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return ss
+}
@@ -21,6 +21,11 @@ type ModuleReader interface {
 type PathReader struct {
 	ModuleReader ModuleReader
 	SchemaReader state.SchemaReader
+
+	// MergeOverrides provides module files with override files merged in,
+	// which is only suitable for decoding the effective configuration
+	// (e.g. reference targets and origins), not for position-based lookups
+	MergeOverrides bool
 }
 
 var _ decoder.PathReader = &PathReader{}
@@ -67,7 +72,7 @@ func (mr *PathReader) PathContext(path lang.Path) (*decoder.PathContext, error)
 
 	switch path.LanguageID {
 	case ilsp.Terraform.String():
-		return modulePathContext(mod, mr.SchemaReader, mr.ModuleReader)
+		return modulePathContext(mod, mr.SchemaReader, mr.ModuleReader, mr.MergeOverrides)
 	case ilsp.Tfvars.String():
 		return varsPathContext(mod)
 	}
@@ -0,0 +1,197 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// ValidateReferences reports reference origins which do not have
+// any matching target (i.e. references to undeclared variables,
+// locals, module calls and data sources) as errors.
+//
+// Variables, locals and data sources which are never referenced
+// are reported separately as warnings, such that these can be
+// presented as unnecessary code.
+func ValidateReferences(mod *state.Module) (ast.ModDiags, ast.ModDiags) {
+	diags := make(ast.ModDiags, len(mod.ParsedModuleFiles))
+	unusedDiags := make(ast.ModDiags, len(mod.ParsedModuleFiles))
+	for name := range mod.ParsedModuleFiles {
+		diags[name] = hcl.Diagnostics{}
+		unusedDiags[name] = hcl.Diagnostics{}
+	}
+
+	declared := make(map[string]bool, 0)
+	for _, target := range mod.RefTargets {
+		declared[target.Addr.String()] = true
+	}
+
+	used := make(map[string]bool, 0)
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || !ast.IsModuleFilename(localOrigin.Range.Filename) {
+			continue
+		}
+
+		addr, ok := declarableAddress(localOrigin.Addr)
+		if !ok {
+			continue
+		}
+		used[addr.String()] = true
+
+		if mod.RefTargetsErr != nil || declared[addr.String()] {
+			continue
+		}
+
+		filename := ast.ModFilename(localOrigin.Range.Filename)
+		diags[filename] = append(diags[filename], undeclaredReferenceDiag(addr, localOrigin.Range))
+	}
+
+	// Origins are only collected from bodies with known schema,
+	// so we also account for any traversals found in the configuration
+	// to avoid reporting declarations used in such bodies as unused.
+	for _, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+			if !ok {
+				return nil
+			}
+			traversalAddr, err := lang.TraversalToAddress(expr.Traversal)
+			if err != nil {
+				return nil
+			}
+			if addr, ok := declarableAddress(traversalAddr); ok {
+				used[addr.String()] = true
+			}
+			return nil
+		})
+	}
+
+	reported := make(map[string]bool, 0)
+	for _, target := range mod.RefTargets {
+		if target.DefRangePtr == nil || !ast.IsModuleFilename(target.DefRangePtr.Filename) {
+			continue
+		}
+
+		addr, ok := declarableAddress(target.Addr)
+		if !ok || len(addr) != len(target.Addr) {
+			continue
+		}
+		if addr.FirstSteps(1).String() == "module" {
+			// module calls may be used for their side effects
+			continue
+		}
+		if used[addr.String()] || reported[addr.String()] {
+			continue
+		}
+		reported[addr.String()] = true
+
+		filename := ast.ModFilename(target.DefRangePtr.Filename)
+		unusedDiags[filename] = append(unusedDiags[filename], unusedDeclarationDiag(addr, *target.DefRangePtr))
+	}
+
+	return diags, unusedDiags
+}
+
+// declarableAddress returns the part of the address
+// which identifies a declared variable, local value,
+// module call or data source
+func declarableAddress(addr lang.Address) (lang.Address, bool) {
+	if len(addr) == 0 {
+		return nil, false
+	}
+
+	steps := 0
+	switch addr[0].String() {
+	case "var", "local", "module":
+		steps = 2
+	case "data":
+		steps = 3
+	default:
+		return nil, false
+	}
+
+	if len(addr) < steps {
+		return nil, false
+	}
+	for _, step := range addr[1:steps] {
+		if _, ok := step.(lang.AttrStep); !ok {
+			return nil, false
+		}
+	}
+
+	return addr.FirstSteps(uint(steps)), true
+}
+
+func undeclaredReferenceDiag(addr lang.Address, rng hcl.Range) *hcl.Diagnostic {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Subject:  rng.Ptr(),
+	}
+
+	switch addr[0].String() {
+	case "var":
+		diag.Summary = "Reference to undeclared input variable"
+		diag.Detail = fmt.Sprintf("An input variable with the name %q has not been declared.",
+			StepName(addr[1]))
+	case "local":
+		diag.Summary = "Reference to undeclared local value"
+		diag.Detail = fmt.Sprintf("A local value with the name %q has not been declared.",
+			StepName(addr[1]))
+	case "module":
+		diag.Summary = "Reference to undeclared module"
+		diag.Detail = fmt.Sprintf("No module call named %q is declared in this module.",
+			StepName(addr[1]))
+	case "data":
+		diag.Summary = "Reference to undeclared data source"
+		diag.Detail = fmt.Sprintf("A data source %q %q has not been declared in this module.",
+			StepName(addr[1]), StepName(addr[2]))
+	}
+
+	return diag
+}
+
+func unusedDeclarationDiag(addr lang.Address, rng hcl.Range) *hcl.Diagnostic {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Subject:  rng.Ptr(),
+	}
+
+	switch addr[0].String() {
+	case "var":
+		diag.Summary = "Unused input variable"
+		diag.Detail = fmt.Sprintf("Input variable %q is declared but never referenced.",
+			StepName(addr[1]))
+	case "local":
+		diag.Summary = "Unused local value"
+		diag.Detail = fmt.Sprintf("Local value %q is declared but never referenced.",
+			StepName(addr[1]))
+	case "data":
+		diag.Summary = "Unused data source"
+		diag.Detail = fmt.Sprintf("Data source %q %q is declared but never referenced.",
+			StepName(addr[1]), StepName(addr[2]))
+	}
+
+	return diag
+}
+
+// StepName returns the name of the address step,
+// e.g. "foo" for both the root step and attribute step of foo.bar
+func StepName(step lang.AddressStep) string {
+	switch s := step.(type) {
+	case lang.RootStep:
+		return s.Name
+	case lang.AttrStep:
+		return s.Name
+	}
+	return step.String()
+}
@@ -0,0 +1,109 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+func TestValidateReferences(t *testing.T) {
+	cfg := `variable "used" {}
+variable "unused" {}
+locals {
+  foo = var.used
+  bar = var.undeclared
+}
+resource "unknown" "test" {
+  name = local.foo
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	mod := &state.Module{
+		Path: "/test",
+		ParsedModuleFiles: ast.ModFiles{
+			"main.tf": f,
+		},
+		RefTargets: reference.Targets{
+			{
+				Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "used"}},
+				DefRangePtr: rangeInFile("main.tf", 1, 1, 0, 1, 16, 15).Ptr(),
+			},
+			{
+				Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "unused"}},
+				DefRangePtr: rangeInFile("main.tf", 2, 1, 19, 2, 18, 36).Ptr(),
+			},
+			{
+				Addr:        lang.Address{lang.RootStep{Name: "local"}, lang.AttrStep{Name: "foo"}},
+				DefRangePtr: rangeInFile("main.tf", 4, 3, 50, 4, 6, 53).Ptr(),
+			},
+			{
+				Addr:        lang.Address{lang.RootStep{Name: "local"}, lang.AttrStep{Name: "bar"}},
+				DefRangePtr: rangeInFile("main.tf", 5, 3, 67, 5, 6, 70).Ptr(),
+			},
+		},
+		RefOrigins: reference.Origins{
+			reference.LocalOrigin{
+				Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "used"}},
+				Range: rangeInFile("main.tf", 4, 9, 56, 4, 17, 64),
+			},
+			reference.LocalOrigin{
+				Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "undeclared"}},
+				Range: rangeInFile("main.tf", 5, 9, 73, 5, 23, 87),
+			},
+		},
+	}
+
+	diags, unusedDiags := ValidateReferences(mod)
+
+	expectedDiags := ast.ModDiags{
+		"main.tf": hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Reference to undeclared input variable",
+				Detail:   `An input variable with the name "undeclared" has not been declared.`,
+				Subject:  rangeInFile("main.tf", 5, 9, 73, 5, 23, 87).Ptr(),
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+
+	expectedUnusedDiags := ast.ModDiags{
+		"main.tf": hcl.Diagnostics{
+			{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unused input variable",
+				Detail:   `Input variable "unused" is declared but never referenced.`,
+				Subject:  rangeInFile("main.tf", 2, 1, 19, 2, 18, 36).Ptr(),
+			},
+			{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unused local value",
+				Detail:   `Local value "bar" is declared but never referenced.`,
+				Subject:  rangeInFile("main.tf", 5, 3, 67, 5, 6, 70).Ptr(),
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedUnusedDiags, unusedDiags); diff != "" {
+		t.Fatalf("unexpected unused declaration diagnostics: %s", diff)
+	}
+}
+
+func rangeInFile(filename string, startLine, startCol, startByte, endLine, endCol, endByte int) hcl.Range {
+	return hcl.Range{
+		Filename: filename,
+		Start:    hcl.Pos{Line: startLine, Column: startCol, Byte: startByte},
+		End:      hcl.Pos{Line: endLine, Column: endCol, Byte: endByte},
+	}
+}
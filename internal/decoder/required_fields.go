@@ -0,0 +1,238 @@
+package decoder
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MissingRequiredFields represents a block which lacks
+// some of the attributes or nested blocks required by its schema
+type MissingRequiredFields struct {
+	// Address is the address of the block, e.g. aws_instance.web
+	Address string
+	// DefRange is the range of the block definition,
+	// where missing fields are reported
+	DefRange hcl.Range
+	// Edit inserts all missing fields with placeholder values
+	Edit lang.TextEdit
+}
+
+// BlocksMissingRequiredFields returns resource, data and module blocks
+// overlapping with the given range, which lack any required attributes
+// or blocks.
+//
+// Schema of module blocks is derived from variables
+// of the called module which have no default value.
+func BlocksMissingRequiredFields(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader, rng hcl.Range) ([]MissingRequiredFields, error) {
+	missing := make([]MissingRequiredFields, 0)
+
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(rng.Filename)]
+	if !ok {
+		return missing, nil
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return missing, nil
+	}
+
+	rootSchema, err := schemaForModule(mod, schemaReader, modReader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, block := range body.Blocks {
+		if !rangesOverlap(block.Range(), rng) || len(block.Labels) == 0 {
+			continue
+		}
+		bSchema, ok := rootSchema.Blocks[block.Type]
+		if !ok {
+			continue
+		}
+
+		var bodySchema *schema.BodySchema
+		var address string
+		switch block.Type {
+		case "resource":
+			bodySchema, ok = blockBodySchema(block, bSchema)
+			address = strings.Join(block.Labels, ".")
+		case "data":
+			bodySchema, ok = blockBodySchema(block, bSchema)
+			address = "data." + strings.Join(block.Labels, ".")
+		case "module":
+			bodySchema, ok = moduleCallBodySchema(mod, modReader, block, bSchema)
+			address = "module." + block.Labels[0]
+		default:
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		indent := blockIndent(block)
+		text := requiredFieldsText(block.Body, bodySchema, indent+"  ")
+		if text == "" {
+			continue
+		}
+
+		missing = append(missing, MissingRequiredFields{
+			Address:  address,
+			DefRange: block.DefRange(),
+			Edit:     insertIntoBlock(f.Bytes, block, indent, text),
+		})
+	}
+
+	return missing, nil
+}
+
+// moduleCallBodySchema returns schema of the module block
+// with variables of the called module, if the module is known
+func moduleCallBodySchema(mod *state.Module, modReader ModuleReader, block *hclsyntax.Block, bSchema *schema.BlockSchema) (*schema.BodySchema, bool) {
+	modPath, ok := calledModulePath(mod, modReader, block)
+	if !ok {
+		return nil, false
+	}
+	modMeta, err := modReader.ModuleMeta(modPath)
+	if err != nil {
+		return nil, false
+	}
+	varsSchema, err := tfschema.SchemaForVariables(modMeta.Variables, modPath)
+	if err != nil {
+		return nil, false
+	}
+
+	mergedSchema := &schema.BodySchema{}
+	if bSchema.Body != nil {
+		mergedSchema = bSchema.Body.Copy()
+	}
+	if mergedSchema.Attributes == nil {
+		mergedSchema.Attributes = make(map[string]*schema.AttributeSchema, 0)
+	}
+	for name, attr := range varsSchema.Attributes {
+		if _, exists := mergedSchema.Attributes[name]; !exists {
+			mergedSchema.Attributes[name] = attr
+		}
+	}
+
+	return mergedSchema, true
+}
+
+// calledModulePath returns path of the module called by the module block,
+// either as installed, or as referenced by a local source address
+func calledModulePath(mod *state.Module, modReader ModuleReader, block *hclsyntax.Block) (string, bool) {
+	calls, err := modReader.ModuleCalls(mod.Path)
+	if err == nil {
+		for _, call := range calls {
+			if call.LocalName == block.Labels[0] {
+				return call.Path, true
+			}
+		}
+	}
+
+	attr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return "", false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.Type().Equals(cty.String) || val.IsNull() {
+		return "", false
+	}
+	source := val.AsString()
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return "", false
+	}
+
+	return filepath.Join(mod.Path, filepath.FromSlash(source)), true
+}
+
+// requiredFieldsText returns declarations of all required attributes
+// and blocks missing in the body, sorted by name
+func requiredFieldsText(body *hclsyntax.Body, bodySchema *schema.BodySchema, indent string) string {
+	var b strings.Builder
+
+	for _, name := range sortedAttributeNames(bodySchema.Attributes) {
+		aSchema := bodySchema.Attributes[name]
+		if !aSchema.IsRequired {
+			continue
+		}
+		if _, ok := body.Attributes[name]; ok {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s = %s\n", indent, name, placeholderForExpr(aSchema.Expr))
+	}
+
+	for _, bType := range sortedBlockTypes(bodySchema.Blocks) {
+		bSchema := bodySchema.Blocks[bType]
+		if bSchema.MinItems == 0 {
+			continue
+		}
+
+		declared := uint64(0)
+		for _, block := range body.Blocks {
+			if block.Type == bType || (isDynamicBlock(block, bodySchema) && block.Labels[0] == bType) {
+				declared++
+			}
+		}
+
+		for i := declared; i < bSchema.MinItems; i++ {
+			labels := ""
+			for _, l := range bSchema.Labels {
+				labels += fmt.Sprintf(" %q", l.Name)
+			}
+
+			nestedText := ""
+			if bSchema.Body != nil {
+				nestedText = requiredFieldsText(&hclsyntax.Body{}, bSchema.Body, indent+"  ")
+			}
+			fmt.Fprintf(&b, "%s%s%s {\n%s%s}\n", indent, bType, labels, nestedText, indent)
+		}
+	}
+
+	return b.String()
+}
+
+// placeholderForExpr returns a placeholder value
+// matching the first of the given constraints
+func placeholderForExpr(ec schema.ExprConstraints) string {
+	for _, c := range ec {
+		switch et := c.(type) {
+		case schema.LiteralTypeExpr:
+			return placeholderForType(et.Type)
+		case schema.LiteralValue:
+			return string(hclwrite.TokensForValue(et.Val).Bytes())
+		case schema.KeywordExpr:
+			return et.Keyword
+		case schema.TupleConsExpr, schema.ListExpr, schema.SetExpr, schema.TupleExpr:
+			return "[]"
+		case schema.MapExpr, schema.ObjectExpr:
+			return "{}"
+		}
+	}
+	return "null"
+}
+
+func placeholderForType(typ cty.Type) string {
+	switch {
+	case typ.Equals(cty.String):
+		return `""`
+	case typ.Equals(cty.Number):
+		return "0"
+	case typ.Equals(cty.Bool):
+		return "false"
+	case typ.IsListType(), typ.IsSetType(), typ.IsTupleType():
+		return "[]"
+	case typ.IsMapType(), typ.IsObjectType():
+		return "{}"
+	}
+	return "null"
+}
@@ -0,0 +1,135 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRequiredFieldsText(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"ami": {
+				IsRequired: true,
+				Expr:       schema.LiteralTypeOnly(cty.String),
+			},
+			"count": {
+				IsRequired: true,
+				Expr:       schema.LiteralTypeOnly(cty.Number),
+			},
+			"enabled": {
+				IsRequired: true,
+				Expr:       schema.LiteralTypeOnly(cty.Bool),
+			},
+			"tags": {
+				IsRequired: true,
+				Expr:       schema.LiteralTypeOnly(cty.Map(cty.String)),
+			},
+			"zones": {
+				IsRequired: true,
+				Expr: schema.ExprConstraints{
+					schema.ListExpr{Elem: schema.LiteralTypeOnly(cty.String)},
+				},
+			},
+			"depends": {
+				IsRequired: true,
+				Expr: schema.ExprConstraints{
+					schema.TraversalExpr{OfScopeId: "resource"},
+				},
+			},
+			"optional": {
+				IsOptional: true,
+				Expr:       schema.LiteralTypeOnly(cty.String),
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"setting": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"key": {
+							IsRequired: true,
+							Expr:       schema.LiteralTypeOnly(cty.String),
+						},
+					},
+				},
+				MinItems: 2,
+			},
+			"nested": {
+				Body: &schema.BodySchema{},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		cfg          string
+		expectedText string
+	}{
+		{
+			"empty body",
+			`resource "aws_instance" "web" {}`,
+			`  ami = ""
+  count = 0
+  depends = null
+  enabled = false
+  tags = {}
+  zones = []
+  setting {
+    key = ""
+  }
+  setting {
+    key = ""
+  }
+`,
+		},
+		{
+			"partially filled body",
+			`resource "aws_instance" "web" {
+  ami = "ami-123"
+  count = 1
+  depends = null
+  enabled = true
+  setting {}
+}`,
+			`  tags = {}
+  zones = []
+  setting {
+    key = ""
+  }
+`,
+		},
+		{
+			"complete body",
+			`resource "aws_instance" "web" {
+  ami = "ami-123"
+  count = 1
+  depends = null
+  enabled = true
+  tags = {}
+  zones = []
+  setting {}
+  setting {}
+}`,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			text := requiredFieldsText(block.Body, bodySchema, "  ")
+			if diff := cmp.Diff(tc.expectedText, text); diff != "" {
+				t.Fatalf("unexpected text: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// RequiredProvider represents an entry of required_providers
+type RequiredProvider struct {
+	LocalName string
+	Source    tfaddr.Provider
+	Version   version.Constraints
+}
+
+// UndeclaredProviders returns local names of providers used in the module
+// (by provider blocks, resources or data sources), which are not declared
+// in required_providers, sorted by name
+func UndeclaredProviders(mod *state.Module) []string {
+	declared := declaredProviders(mod)
+
+	used := make(map[string]bool, 0)
+	for ref := range mod.Meta.ProviderReferences {
+		used[ref.LocalName] = true
+	}
+	for _, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if name, ok := providerOfBlock(block); ok {
+				used[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0)
+	for name := range used {
+		// terraform_remote_state comes from the built-in provider
+		if name == "" || name == "terraform" || declared[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ProvidersInRange returns local names of providers
+// used by blocks which overlap with the given range
+func ProvidersInRange(mod *state.Module, rng hcl.Range) []string {
+	names := make([]string, 0)
+
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(rng.Filename)]
+	if !ok {
+		return names
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return names
+	}
+
+	for _, block := range body.Blocks {
+		if !rangesOverlap(block.Range(), rng) {
+			continue
+		}
+		if name, ok := providerOfBlock(block); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// RequiredProvidersEdit returns an edit which adds the given providers
+// into an existing required_providers block, or an existing terraform block,
+// or into a new terraform block at the beginning of the given file
+// if the module has neither.
+//
+// The returned string is the name of the file to apply the edit to.
+func RequiredProvidersEdit(mod *state.Module, filename string, providers []RequiredProvider) (string, lang.TextEdit) {
+	var terraformBlock *hclsyntax.Block
+	var terraformFile string
+
+	names := make([]string, 0, len(mod.ParsedModuleFiles))
+	for name := range mod.ParsedModuleFiles {
+		names = append(names, name.String())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := mod.ParsedModuleFiles[ast.ModFilename(name)]
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "required_providers" {
+					indent := blockIndent(nested)
+					return name, insertIntoBlock(f.Bytes, nested, indent,
+						requiredProvidersEntries(providers, indent+"  "))
+				}
+			}
+			if terraformBlock == nil {
+				terraformBlock = block
+				terraformFile = name
+			}
+		}
+	}
+
+	if terraformBlock != nil {
+		f := mod.ParsedModuleFiles[ast.ModFilename(terraformFile)]
+		indent := blockIndent(terraformBlock) + "  "
+		text := fmt.Sprintf("%srequired_providers {\n%s%s}\n",
+			indent, requiredProvidersEntries(providers, indent+"  "), indent)
+		return terraformFile, insertIntoBlock(f.Bytes, terraformBlock, blockIndent(terraformBlock), text)
+	}
+
+	return filename, lang.TextEdit{
+		Range: hcl.Range{
+			Filename: filename,
+			Start:    hcl.InitialPos,
+			End:      hcl.InitialPos,
+		},
+		NewText: fmt.Sprintf("terraform {\n  required_providers {\n%s  }\n}\n\n",
+			requiredProvidersEntries(providers, "    ")),
+	}
+}
+
+func requiredProvidersEntries(providers []RequiredProvider, indent string) string {
+	var b strings.Builder
+	for _, p := range providers {
+		fmt.Fprintf(&b, "%s%s = {\n", indent, p.LocalName)
+		if len(p.Version) > 0 {
+			fmt.Fprintf(&b, "%s  source  = %q\n", indent, p.Source.ForDisplay())
+			fmt.Fprintf(&b, "%s  version = %q\n", indent, p.Version.String())
+		} else {
+			fmt.Fprintf(&b, "%s  source = %q\n", indent, p.Source.ForDisplay())
+		}
+		fmt.Fprintf(&b, "%s}\n", indent)
+	}
+	return b.String()
+}
+
+// insertIntoBlock returns an edit inserting text (consisting of whole lines)
+// before the closing brace of the block
+func insertIntoBlock(src []byte, block *hclsyntax.Block, indent, text string) lang.TextEdit {
+	closeBrace := block.CloseBraceRange
+	lineStart := bytes.LastIndexByte(src[:closeBrace.Start.Byte], '\n') + 1
+
+	if len(bytes.TrimSpace(src[lineStart:closeBrace.Start.Byte])) == 0 {
+		// closing brace is on its own line
+		pos := hcl.Pos{
+			Line:   closeBrace.Start.Line,
+			Column: 1,
+			Byte:   lineStart,
+		}
+		return lang.TextEdit{
+			Range: hcl.Range{
+				Filename: closeBrace.Filename,
+				Start:    pos,
+				End:      pos,
+			},
+			NewText: text,
+		}
+	}
+
+	return lang.TextEdit{
+		Range: hcl.Range{
+			Filename: closeBrace.Filename,
+			Start:    closeBrace.Start,
+			End:      closeBrace.Start,
+		},
+		NewText: "\n" + text + indent,
+	}
+}
+
+func blockIndent(block *hclsyntax.Block) string {
+	return strings.Repeat(" ", block.TypeRange.Start.Column-1)
+}
+
+func declaredProviders(mod *state.Module) map[string]bool {
+	declared := make(map[string]bool, 0)
+	for _, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type != "required_providers" {
+					continue
+				}
+				for name := range nested.Body.Attributes {
+					declared[name] = true
+				}
+			}
+		}
+	}
+	return declared
+}
+
+// providerOfBlock returns local name of the provider used by the block,
+// either as set via provider argument, or implied from the resource type
+func providerOfBlock(block *hclsyntax.Block) (string, bool) {
+	switch block.Type {
+	case "provider":
+		if len(block.Labels) != 1 {
+			return "", false
+		}
+		return block.Labels[0], true
+	case "resource", "data":
+		if len(block.Labels) != 2 {
+			return "", false
+		}
+		if attr, ok := block.Body.Attributes["provider"]; ok {
+			traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+			if diags.HasErrors() {
+				return "", false
+			}
+			return traversal.RootName(), true
+		}
+		return strings.SplitN(block.Labels[0], "_", 2)[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,165 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+func TestUndeclaredProviders(t *testing.T) {
+	cfg := `terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}
+
+provider "google" {}
+
+resource "aws_instance" "web" {}
+
+resource "random_pet" "name" {}
+
+data "terraform_remote_state" "net" {}
+
+data "http" "example" {
+  provider = custom.alias
+}
+`
+	mod := testModule(t, map[string]string{"main.tf": cfg})
+
+	names := UndeclaredProviders(mod)
+	expectedNames := []string{"custom", "google", "random"}
+	if diff := cmp.Diff(expectedNames, names); diff != "" {
+		t.Fatalf("unexpected providers: %s", diff)
+	}
+
+	names = ProvidersInRange(mod, rangeInFile("main.tf", 11, 1, 0, 13, 1, 0))
+	expectedNames = []string{"aws", "random"}
+	if diff := cmp.Diff(expectedNames, names); diff != "" {
+		t.Fatalf("unexpected providers in range: %s", diff)
+	}
+}
+
+func TestRequiredProvidersEdit(t *testing.T) {
+	providers := []RequiredProvider{
+		{
+			LocalName: "random",
+			Source:    tfaddr.NewDefaultProvider("random"),
+			Version:   version.MustConstraints(version.NewConstraint("~> 3.1")),
+		},
+		{
+			LocalName: "google",
+			Source:    tfaddr.NewDefaultProvider("google"),
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		files            map[string]string
+		expectedFilename string
+		expectedEdit     lang.TextEdit
+	}{
+		{
+			"no terraform block",
+			map[string]string{
+				"main.tf": "resource \"random_pet\" \"name\" {}\n",
+			},
+			"main.tf",
+			lang.TextEdit{
+				Range: rangeInFile("main.tf", 1, 1, 0, 1, 1, 0),
+				NewText: `terraform {
+  required_providers {
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.1"
+    }
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+}
+
+`,
+			},
+		},
+		{
+			"terraform block without required_providers",
+			map[string]string{
+				"main.tf":     "resource \"random_pet\" \"name\" {}\n",
+				"versions.tf": "terraform {\n  required_version = \">= 1.0\"\n}\n",
+			},
+			"versions.tf",
+			lang.TextEdit{
+				Range: rangeInFile("versions.tf", 3, 1, 42, 3, 1, 42),
+				NewText: `  required_providers {
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.1"
+    }
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+`,
+			},
+		},
+		{
+			"existing required_providers",
+			map[string]string{
+				"main.tf": "terraform {\n  required_providers {}\n}\n",
+			},
+			"main.tf",
+			lang.TextEdit{
+				Range: rangeInFile("main.tf", 2, 23, 34, 2, 23, 34),
+				NewText: `
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.1"
+    }
+    google = {
+      source = "hashicorp/google"
+    }
+  `,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mod := testModule(t, tc.files)
+
+			filename, edit := RequiredProvidersEdit(mod, "main.tf", providers)
+			if filename != tc.expectedFilename {
+				t.Fatalf("expected filename %q, given %q", tc.expectedFilename, filename)
+			}
+			if diff := cmp.Diff(tc.expectedEdit, edit); diff != "" {
+				t.Fatalf("unexpected edit: %s", diff)
+			}
+		})
+	}
+}
+
+func testModule(t *testing.T, files map[string]string) *state.Module {
+	parsedFiles := make(ast.ModFiles, 0)
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[ast.ModFilename(name)] = f
+	}
+
+	return &state.Module{
+		Path:              "/test",
+		ParsedModuleFiles: parsedFiles,
+	}
+}
@@ -0,0 +1,453 @@
+package decoder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// operatorTokenTypes represents lexer tokens which are
+// highlighted as operators, when they appear in between operands
+var operatorTokenTypes = map[hclsyntax.TokenType]bool{
+	hclsyntax.TokenPlus:          true,
+	hclsyntax.TokenMinus:         true,
+	hclsyntax.TokenStar:          true,
+	hclsyntax.TokenSlash:         true,
+	hclsyntax.TokenPercent:       true,
+	hclsyntax.TokenEqualOp:       true,
+	hclsyntax.TokenNotEqual:      true,
+	hclsyntax.TokenLessThan:      true,
+	hclsyntax.TokenLessThanEq:    true,
+	hclsyntax.TokenGreaterThan:   true,
+	hclsyntax.TokenGreaterThanEq: true,
+	hclsyntax.TokenAnd:           true,
+	hclsyntax.TokenOr:            true,
+	hclsyntax.TokenBang:          true,
+	hclsyntax.TokenQuestion:      true,
+	hclsyntax.TokenColon:         true,
+}
+
+// SemanticTokens returns tokens of the file which hcl-lang does not
+// report, or reports only as generic traversal steps, i.e. references
+// classified by what they refer to, function calls, operators
+// and comments.
+//
+// References to attributes of resources and data sources are marked
+// as deprecated or read-only (computed-only) according to their schema.
+//
+// If a range is given, only top-level blocks and attributes
+// overlapping with it are visited.
+func SemanticTokens(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader, filename string, rng *hcl.Range) []ilsp.SemanticToken {
+	tokens := make([]ilsp.SemanticToken, 0)
+
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+	if !ok {
+		f, ok = mod.ParsedVarsFiles[ast.VarsFilename(filename)]
+		if !ok {
+			return tokens
+		}
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return tokens
+	}
+	srcRng := body.SrcRange
+	if rng != nil {
+		body, srcRng = bodyInRange(body, *rng)
+	}
+
+	refs := &referenceClassifier{
+		mod:          mod,
+		schemaReader: schemaReader,
+		modReader:    modReader,
+	}
+
+	operatorSpans := make([]byteSpan, 0)
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.FunctionCallExpr:
+			tokens = append(tokens, ilsp.SemanticToken{
+				Type:  ilsp.TokenTypeFunction,
+				Range: n.NameRange,
+			})
+		case *hclsyntax.ScopeTraversalExpr:
+			tokens = append(tokens, refs.traversalTokens(n.Traversal)...)
+		case *hclsyntax.BinaryOpExpr:
+			operatorSpans = append(operatorSpans, byteSpan{
+				Start: n.LHS.Range().End.Byte,
+				End:   n.RHS.Range().Start.Byte,
+			})
+		case *hclsyntax.UnaryOpExpr:
+			operatorSpans = append(operatorSpans, byteSpan{
+				Start: n.SymbolRange.Start.Byte,
+				End:   n.SymbolRange.End.Byte,
+			})
+		case *hclsyntax.ConditionalExpr:
+			operatorSpans = append(operatorSpans, byteSpan{
+				Start: n.Condition.Range().End.Byte,
+				End:   n.TrueResult.Range().Start.Byte,
+			}, byteSpan{
+				Start: n.TrueResult.Range().End.Byte,
+				End:   n.FalseResult.Range().Start.Byte,
+			})
+		}
+		return nil
+	})
+
+	lexTokens, _ := hclsyntax.LexConfig(srcRng.SliceBytes(f.Bytes), filename, srcRng.Start)
+	tokens = append(tokens, operatorTokens(lexTokens, operatorSpans)...)
+	tokens = append(tokens, commentTokens(lexTokens)...)
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].Range.Start.Byte < tokens[j].Range.Start.Byte
+	})
+
+	return tokens
+}
+
+// SemanticTokensInRange returns tokens of the file reported by hcl-lang,
+// which are limited to top-level blocks and attributes overlapping
+// with the range, so that only these are decoded
+func SemanticTokensInRange(ctx context.Context, pathReader decoder.PathReader, path lang.Path, rng hcl.Range) ([]lang.SemanticToken, error) {
+	pathCtx, err := pathReader.PathContext(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if f, ok := pathCtx.Files[rng.Filename]; ok {
+		if body, ok := f.Body.(*hclsyntax.Body); ok {
+			body, _ = bodyInRange(body, rng)
+			pathCtx.Files[rng.Filename] = &hcl.File{
+				Body:  body,
+				Bytes: f.Bytes,
+			}
+		}
+	}
+
+	d, err := NewDecoder(ctx, &pathContextReader{
+		path:    path,
+		pathCtx: pathCtx,
+	}).Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.SemanticTokensInFile(rng.Filename)
+}
+
+// pathContextReader provides an already obtained context of a single path
+type pathContextReader struct {
+	path    lang.Path
+	pathCtx *decoder.PathContext
+}
+
+func (pr *pathContextReader) Paths(ctx context.Context) []lang.Path {
+	return []lang.Path{pr.path}
+}
+
+func (pr *pathContextReader) PathContext(path lang.Path) (*decoder.PathContext, error) {
+	if path != pr.path {
+		return nil, fmt.Errorf("unknown path: %q", path.Path)
+	}
+	return pr.pathCtx, nil
+}
+
+// bodyInRange returns a copy of the body with just the top-level blocks
+// and attributes overlapping with the range, along with the range of source
+// which spans these, up to the preceding and following blocks or attributes
+func bodyInRange(body *hclsyntax.Body, rng hcl.Range) (*hclsyntax.Body, hcl.Range) {
+	newBody := &hclsyntax.Body{
+		Attributes: make(hclsyntax.Attributes, 0),
+		Blocks:     make(hclsyntax.Blocks, 0),
+		SrcRange:   body.SrcRange,
+		EndRange:   body.EndRange,
+	}
+	srcRng := body.SrcRange
+
+	for name, attr := range body.Attributes {
+		if attr.SrcRange.Overlaps(rng) {
+			newBody.Attributes[name] = attr
+			continue
+		}
+		srcRng = narrowSrcRange(srcRng, attr.SrcRange, rng)
+	}
+	for _, block := range body.Blocks {
+		if block.Range().Overlaps(rng) {
+			newBody.Blocks = append(newBody.Blocks, block)
+			continue
+		}
+		srcRng = narrowSrcRange(srcRng, block.Range(), rng)
+	}
+
+	return newBody, srcRng
+}
+
+// narrowSrcRange excludes the given item range from the source range,
+// if the item lies entirely before or after the range of interest
+func narrowSrcRange(srcRng, itemRng, rng hcl.Range) hcl.Range {
+	if itemRng.End.Byte <= rng.Start.Byte && itemRng.End.Byte > srcRng.Start.Byte {
+		srcRng.Start = itemRng.End
+	}
+	if itemRng.Start.Byte >= rng.End.Byte && itemRng.Start.Byte < srcRng.End.Byte {
+		srcRng.End = itemRng.Start
+	}
+	return srcRng
+}
+
+type byteSpan struct {
+	Start, End int
+}
+
+// operatorTokens returns operators found within the spans,
+// which represent the space in between operands
+func operatorTokens(lexTokens hclsyntax.Tokens, spans []byteSpan) []ilsp.SemanticToken {
+	tokens := make([]ilsp.SemanticToken, 0)
+
+	for _, span := range spans {
+		i := sort.Search(len(lexTokens), func(i int) bool {
+			return lexTokens[i].Range.Start.Byte >= span.Start
+		})
+		for ; i < len(lexTokens) && lexTokens[i].Range.Start.Byte < span.End; i++ {
+			if !operatorTokenTypes[lexTokens[i].Type] {
+				continue
+			}
+			tokens = append(tokens, ilsp.SemanticToken{
+				Type:  ilsp.TokenTypeOperator,
+				Range: lexTokens[i].Range,
+			})
+		}
+	}
+
+	return tokens
+}
+
+func commentTokens(lexTokens hclsyntax.Tokens) []ilsp.SemanticToken {
+	tokens := make([]ilsp.SemanticToken, 0)
+
+	for _, token := range lexTokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+
+		rng := token.Range
+		if bytes.HasSuffix(token.Bytes, []byte("\n")) {
+			// line comments include the trailing newline
+			// which we leave out to keep the token on a single line
+			text := bytes.TrimRight(token.Bytes, "\r\n")
+			rng.End = hcl.Pos{
+				Line:   rng.Start.Line,
+				Column: rng.Start.Column + utf8.RuneCount(text),
+				Byte:   rng.Start.Byte + len(text),
+			}
+		}
+
+		tokens = append(tokens, ilsp.SemanticToken{
+			Type:  ilsp.TokenTypeComment,
+			Range: rng,
+		})
+	}
+
+	return tokens
+}
+
+// referenceClassifier classifies references by the kind
+// of object they refer to, looking up schema of referenced
+// resources and data sources as needed
+type referenceClassifier struct {
+	mod          *state.Module
+	schemaReader state.SchemaReader
+	modReader    ModuleReader
+
+	rootSchema       *schema.BodySchema
+	rootSchemaLoaded bool
+	blocks           map[string]*hclsyntax.Block
+}
+
+type traversalStep struct {
+	Name  string
+	Range hcl.Range
+}
+
+// traversalTokens returns tokens for named steps of the traversal,
+// where steps making up the address of the referenced object
+// (e.g. var.name or aws_instance.name) are classified by the kind
+// of the object and any further steps as its properties
+func (rc *referenceClassifier) traversalTokens(traversal hcl.Traversal) []ilsp.SemanticToken {
+	tokens := make([]ilsp.SemanticToken, 0)
+
+	if len(traversal) < 2 {
+		return tokens
+	}
+
+	var tokenType ilsp.TokenType
+	addrLen := 2
+	switch traversal.RootName() {
+	case "var":
+		tokenType = ilsp.TokenTypeParameter
+	case "local":
+		tokenType = ilsp.TokenTypeVariable
+	case "module":
+		tokenType = ilsp.TokenTypeNamespace
+	case "data":
+		tokenType = ilsp.TokenTypeStruct
+		addrLen = 3
+	case "count", "each", "path", "terraform", "self":
+		return tokens
+	default:
+		tokenType = ilsp.TokenTypeClass
+	}
+
+	if len(traversal) < addrLen {
+		return tokens
+	}
+
+	// steps of the address must all be named
+	addrSteps := make([]traversalStep, 0, addrLen)
+	for _, step := range traversal[:addrLen] {
+		s, ok := namedTraversalStep(step)
+		if !ok {
+			return tokens
+		}
+		addrSteps = append(addrSteps, s)
+	}
+
+	if tokenType == ilsp.TokenTypeClass {
+		// any other root may also be e.g. an iterator
+		// of a for expression or a dynamic block,
+		// so we only classify declared resources
+		if _, ok := rc.declaredBlock("resource", addrSteps); !ok {
+			return tokens
+		}
+	}
+
+	for _, step := range addrSteps {
+		tokens = append(tokens, ilsp.SemanticToken{
+			Type:  tokenType,
+			Range: step.Range,
+		})
+	}
+
+	isFirstAttr := true
+	for _, step := range traversal[addrLen:] {
+		s, ok := namedTraversalStep(step)
+		if !ok {
+			continue
+		}
+
+		modifiers := ilsp.TokenModifiers{}
+		if isFirstAttr {
+			switch tokenType {
+			case ilsp.TokenTypeClass:
+				modifiers = rc.attributeModifiers("resource", addrSteps, s.Name)
+			case ilsp.TokenTypeStruct:
+				modifiers = rc.attributeModifiers("data", addrSteps[1:], s.Name)
+			}
+			isFirstAttr = false
+		}
+
+		tokens = append(tokens, ilsp.SemanticToken{
+			Type:      ilsp.TokenTypeProperty,
+			Modifiers: modifiers,
+			Range:     s.Range,
+		})
+	}
+
+	return tokens
+}
+
+// namedTraversalStep returns name and range of a root or attribute
+// step, where the range of an attribute excludes the leading dot
+func namedTraversalStep(step hcl.Traverser) (traversalStep, bool) {
+	switch s := step.(type) {
+	case hcl.TraverseRoot:
+		return traversalStep{Name: s.Name, Range: s.SrcRange}, true
+	case hcl.TraverseAttr:
+		rng := s.SrcRange
+		rng.Start.Column++
+		rng.Start.Byte++
+		return traversalStep{Name: s.Name, Range: rng}, true
+	}
+	return traversalStep{}, false
+}
+
+// attributeModifiers returns modifiers of the attribute of a resource
+// or data source with the given labels (type and name), as declared
+// in the module
+func (rc *referenceClassifier) attributeModifiers(blockType string, labels []traversalStep, attrName string) ilsp.TokenModifiers {
+	modifiers := ilsp.TokenModifiers{}
+
+	rootSchema := rc.schema()
+	if rootSchema == nil {
+		return modifiers
+	}
+	bSchema, ok := rootSchema.Blocks[blockType]
+	if !ok {
+		return modifiers
+	}
+	block, ok := rc.declaredBlock(blockType, labels)
+	if !ok {
+		return modifiers
+	}
+	bodySchema, ok := blockBodySchema(block, bSchema)
+	if !ok {
+		return modifiers
+	}
+	aSchema, ok := bodySchema.Attributes[attrName]
+	if !ok {
+		return modifiers
+	}
+
+	if aSchema.IsDeprecated {
+		modifiers = append(modifiers, ilsp.TokenModifierDeprecated)
+	}
+	if aSchema.IsComputed && !aSchema.IsOptional && !aSchema.IsRequired {
+		modifiers = append(modifiers, ilsp.TokenModifierReadonly)
+	}
+
+	return modifiers
+}
+
+func (rc *referenceClassifier) schema() *schema.BodySchema {
+	if !rc.rootSchemaLoaded {
+		rc.rootSchema, _ = schemaForModule(rc.mod, rc.schemaReader, rc.modReader)
+		rc.rootSchemaLoaded = true
+	}
+	return rc.rootSchema
+}
+
+// declaredBlock returns a block of the given type
+// and labels, as declared in any file of the module
+func (rc *referenceClassifier) declaredBlock(blockType string, labels []traversalStep) (*hclsyntax.Block, bool) {
+	if rc.blocks == nil {
+		rc.blocks = make(map[string]*hclsyntax.Block, 0)
+		for _, f := range rc.mod.ParsedModuleFiles {
+			body, ok := f.Body.(*hclsyntax.Body)
+			if !ok {
+				continue
+			}
+			for _, block := range body.Blocks {
+				key := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+				rc.blocks[key] = block
+			}
+		}
+	}
+
+	key := blockType
+	for _, label := range labels {
+		key += "." + label.Name
+	}
+	block, ok := rc.blocks[key]
+	return block, ok
+}
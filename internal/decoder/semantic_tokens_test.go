@@ -0,0 +1,199 @@
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	cfg := `# networking
+locals {
+  a = var.foo + 1
+  b = upper(local.a)
+  c = aws_instance.web.private_ip
+  d = data.aws_ami.ubuntu.arn
+  e = module.net.vpc_id
+  f = !var.enabled ? 1 : -1
+  g = [for s in var.list : s.name]
+}
+
+resource "aws_instance" "web" {}
+
+data "aws_ami" "ubuntu" {}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	mod := &state.Module{
+		Path: "/test",
+		ParsedModuleFiles: ast.ModFiles{
+			"main.tf": f,
+		},
+	}
+
+	tokens := SemanticTokens(mod, nil, nil, "main.tf", nil)
+
+	expectedTokens := []string{
+		"comment:# networking",
+		"parameter:var",
+		"parameter:foo",
+		"operator:+",
+		"function:upper",
+		"variable:local",
+		"variable:a",
+		"class:aws_instance",
+		"class:web",
+		"property:private_ip",
+		"struct:data",
+		"struct:aws_ami",
+		"struct:ubuntu",
+		"property:arn",
+		"namespace:module",
+		"namespace:net",
+		"property:vpc_id",
+		"operator:!",
+		"parameter:var",
+		"parameter:enabled",
+		"operator:?",
+		"operator::",
+		"operator:-",
+		"parameter:var",
+		"parameter:list",
+	}
+	if diff := cmp.Diff(expectedTokens, tokensAsStrings(f.Bytes, tokens)); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestSemanticTokens_range(t *testing.T) {
+	cfg := `locals {
+  a = var.foo + 1
+}
+
+# outputs
+output "b" {
+  value = upper(local.a)
+}
+
+locals {
+  c = !var.enabled
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	mod := &state.Module{
+		Path: "/test",
+		ParsedModuleFiles: ast.ModFiles{
+			"main.tf": f,
+		},
+	}
+
+	rng := hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 5, Column: 1, Byte: 30},
+		End:      hcl.Pos{Line: 7, Column: 1, Byte: 53},
+	}
+	tokens := SemanticTokens(mod, nil, nil, "main.tf", &rng)
+
+	expectedTokens := []string{
+		"comment:# outputs",
+		"function:upper",
+		"variable:local",
+		"variable:a",
+	}
+	if diff := cmp.Diff(expectedTokens, tokensAsStrings(f.Bytes, tokens)); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestSemanticTokens_attributeModifiers(t *testing.T) {
+	cfg := `output "a" {
+  value = [
+    aws_instance.web.id,
+    aws_instance.web.ami,
+    aws_instance.web.legacy,
+  ]
+}
+
+resource "aws_instance" "web" {}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	mod := &state.Module{
+		Path: "/test",
+		ParsedModuleFiles: ast.ModFiles{
+			"main.tf": f,
+		},
+	}
+
+	rc := &referenceClassifier{
+		mod: mod,
+		rootSchema: &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"resource": {
+					Labels: []*schema.LabelSchema{
+						{Name: "type"},
+						{Name: "name"},
+					},
+					Body: &schema.BodySchema{
+						Attributes: map[string]*schema.AttributeSchema{
+							"id":     {IsComputed: true},
+							"ami":    {IsOptional: true, IsComputed: true},
+							"legacy": {IsOptional: true, IsDeprecated: true},
+						},
+					},
+				},
+			},
+		},
+		rootSchemaLoaded: true,
+	}
+
+	tokens := make([]ilsp.SemanticToken, 0)
+	hclsyntax.VisitAll(f.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+		if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+			tokens = append(tokens, rc.traversalTokens(expr.Traversal)...)
+		}
+		return nil
+	})
+
+	expectedTokens := []string{
+		"class:aws_instance",
+		"class:web",
+		"property[readonly]:id",
+		"class:aws_instance",
+		"class:web",
+		"property:ami",
+		"class:aws_instance",
+		"class:web",
+		"property[deprecated]:legacy",
+	}
+	if diff := cmp.Diff(expectedTokens, tokensAsStrings(f.Bytes, tokens)); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func tokensAsStrings(src []byte, tokens []ilsp.SemanticToken) []string {
+	strs := make([]string, len(tokens))
+	for i, token := range tokens {
+		text := string(src[token.Range.Start.Byte:token.Range.End.Byte])
+		if len(token.Modifiers) > 0 {
+			strs[i] = fmt.Sprintf("%s%s:%s", token.Type, token.Modifiers.AsStrings(), text)
+			continue
+		}
+		strs[i] = fmt.Sprintf("%s:%s", token.Type, text)
+	}
+	return strs
+}
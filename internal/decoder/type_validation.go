@@ -0,0 +1,228 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// typeInferrer infers types of expressions from literal values,
+// types of reference targets (e.g. typed variables or resource
+// attributes) and return types of built-in functions
+type typeInferrer struct {
+	targets   reference.Targets
+	functions map[string]*functions.Signature
+}
+
+func newTypeInferrer(targets reference.Targets, funcs map[string]*functions.Signature) *typeInferrer {
+	return &typeInferrer{
+		targets:   targets,
+		functions: funcs,
+	}
+}
+
+// inferType returns type of the expression, or cty.DynamicPseudoType
+// if the type cannot be determined statically
+func (ti *typeInferrer) inferType(expr hclsyntax.Expression) cty.Type {
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		if e.Val.IsNull() {
+			return cty.DynamicPseudoType
+		}
+		return e.Val.Type()
+	case *hclsyntax.TemplateExpr:
+		return cty.String
+	case *hclsyntax.TemplateWrapExpr:
+		return ti.inferType(e.Wrapped)
+	case *hclsyntax.ParenthesesExpr:
+		return ti.inferType(e.Expression)
+	case *hclsyntax.TupleConsExpr:
+		elemTypes := make([]cty.Type, len(e.Exprs))
+		for i, elemExpr := range e.Exprs {
+			elemTypes[i] = ti.inferType(elemExpr)
+		}
+		return cty.Tuple(elemTypes)
+	case *hclsyntax.ObjectConsExpr:
+		attrTypes := make(map[string]cty.Type, len(e.Items))
+		for _, item := range e.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || !key.IsKnown() || key.IsNull() || !key.Type().Equals(cty.String) {
+				// keys which are not literal may be anything
+				return cty.DynamicPseudoType
+			}
+			attrTypes[key.AsString()] = ti.inferType(item.ValueExpr)
+		}
+		return cty.Object(attrTypes)
+	case *hclsyntax.FunctionCallExpr:
+		sig, ok := ti.functions[e.Name]
+		if !ok || sig.ReturnType == cty.NilType {
+			return cty.DynamicPseudoType
+		}
+		return sig.ReturnType
+	case *hclsyntax.ConditionalExpr:
+		trueType := ti.inferType(e.TrueResult)
+		falseType := ti.inferType(e.FalseResult)
+		unified, _ := convert.UnifyUnsafe([]cty.Type{trueType, falseType})
+		if unified == cty.NilType {
+			return cty.DynamicPseudoType
+		}
+		return unified
+	case *hclsyntax.BinaryOpExpr:
+		return e.Op.Type
+	case *hclsyntax.UnaryOpExpr:
+		return e.Op.Type
+	case *hclsyntax.IndexExpr:
+		collType := ti.inferType(e.Collection)
+		if collType.IsListType() || collType.IsMapType() {
+			return collType.ElementType()
+		}
+		return cty.DynamicPseudoType
+	case *hclsyntax.ScopeTraversalExpr:
+		return ti.traversalType(e.Traversal)
+	}
+
+	return cty.DynamicPseudoType
+}
+
+// traversalType returns type of the reference target
+// which the traversal points to
+func (ti *typeInferrer) traversalType(traversal hcl.Traversal) cty.Type {
+	addr, err := lang.TraversalToAddress(traversal)
+	if err != nil {
+		return cty.DynamicPseudoType
+	}
+	return ti.addressType(addr)
+}
+
+// addressType returns type of the reference target
+// (or of its attribute) at the given address
+func (ti *typeInferrer) addressType(addr lang.Address) cty.Type {
+	// find the most specific target, since attributes of some
+	// targets (e.g. of variables of object type) are not targets
+	targets := ti.targets
+	var target *reference.Target
+	for {
+		var next *reference.Target
+		for i, t := range targets {
+			if len(t.Addr) > len(addr) || !t.Addr.Equals(addr.FirstSteps(uint(len(t.Addr)))) {
+				continue
+			}
+			if next != nil && t.Addr.Equals(next.Addr) && !sameType(t.Type, next.Type) {
+				// ambiguous target, e.g. a resource with count
+				return cty.DynamicPseudoType
+			}
+			if next == nil || len(t.Addr) > len(next.Addr) {
+				next = &targets[i]
+			}
+		}
+		if next == nil {
+			break
+		}
+		target = next
+		targets = next.NestedTargets
+	}
+
+	if target == nil || target.Type == cty.NilType {
+		return cty.DynamicPseudoType
+	}
+
+	typ := target.Type
+	for _, step := range addr[len(target.Addr):] {
+		switch s := step.(type) {
+		case lang.AttrStep:
+			if !typ.IsObjectType() || !typ.HasAttribute(s.Name) {
+				return cty.DynamicPseudoType
+			}
+			typ = typ.AttributeType(s.Name)
+		case lang.IndexStep:
+			if !typ.IsListType() && !typ.IsMapType() {
+				return cty.DynamicPseudoType
+			}
+			typ = typ.ElementType()
+		default:
+			return cty.DynamicPseudoType
+		}
+	}
+
+	return typ
+}
+
+func sameType(a, b cty.Type) bool {
+	if a == cty.NilType || b == cty.NilType {
+		return a == cty.NilType && b == cty.NilType
+	}
+	return a.Equals(b)
+}
+
+// validateAttributeType reports expressions whose inferred type
+// cannot be converted to any of the types expected by the schema
+func (ti *typeInferrer) validateAttributeType(attr *hclsyntax.Attribute, aSchema *schema.AttributeSchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	expectedTypes := make([]cty.Type, 0)
+	for _, ec := range aSchema.Expr {
+		typ, ok := constraintType(ec)
+		if !ok {
+			// the value may be legitimately represented
+			// by expressions which have no type, such as keywords
+			return diags
+		}
+		if typ != cty.NilType {
+			expectedTypes = append(expectedTypes, typ)
+		}
+	}
+	if len(expectedTypes) == 0 {
+		return diags
+	}
+
+	givenType := ti.inferType(attr.Expr)
+	if givenType == cty.DynamicPseudoType {
+		return diags
+	}
+
+	for _, typ := range expectedTypes {
+		if typ == cty.DynamicPseudoType || givenType.Equals(typ) ||
+			convert.GetConversionUnsafe(givenType, typ) != nil {
+			return diags
+		}
+	}
+
+	return append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Incorrect attribute value type",
+		Detail: fmt.Sprintf("Inappropriate value for attribute %q: %s required, but %s given.",
+			attr.Name, expectedTypes[0].FriendlyName(), givenType.FriendlyName()),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+}
+
+// constraintType returns type of values accepted by the constraint,
+// where cty.NilType means the constraint does not imply any type
+// (e.g. references to any targets)
+func constraintType(ec schema.ExprConstraint) (cty.Type, bool) {
+	switch e := ec.(type) {
+	case schema.LiteralTypeExpr:
+		return e.Type, true
+	case schema.LiteralValue:
+		return e.Val.Type(), true
+	case schema.TraversalExpr:
+		return e.OfType, true
+	case schema.ListExpr, schema.TupleExpr, schema.TupleConsExpr:
+		return cty.List(cty.DynamicPseudoType), true
+	case schema.SetExpr:
+		return cty.Set(cty.DynamicPseudoType), true
+	case schema.MapExpr:
+		return cty.Map(cty.DynamicPseudoType), true
+	case schema.ObjectExpr:
+		// any object can be converted to an empty object
+		return cty.EmptyObject, true
+	}
+	return cty.NilType, false
+}
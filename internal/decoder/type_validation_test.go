@@ -0,0 +1,186 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestValidateBody_types(t *testing.T) {
+	attrExpr := func(typ cty.Type) schema.ExprConstraints {
+		return schema.ExprConstraints{
+			schema.TraversalExpr{OfType: typ},
+			schema.LiteralTypeExpr{Type: typ},
+		}
+	}
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name":    {IsOptional: true, Expr: attrExpr(cty.String)},
+			"names":   {IsOptional: true, Expr: attrExpr(cty.List(cty.String))},
+			"count":   {IsOptional: true, Expr: attrExpr(cty.Number)},
+			"tags":    {IsOptional: true, Expr: attrExpr(cty.Map(cty.String))},
+			"anyattr": {IsOptional: true, Expr: attrExpr(cty.DynamicPseudoType)},
+			"provider": {
+				IsOptional: true,
+				Expr: schema.ExprConstraints{
+					schema.LiteralTypeExpr{Type: cty.String},
+					schema.ObjectExpr{},
+				},
+			},
+			"changes": {
+				IsOptional: true,
+				Expr: schema.ExprConstraints{
+					schema.KeywordExpr{Keyword: "all"},
+					schema.LiteralTypeExpr{Type: cty.List(cty.String)},
+				},
+			},
+		},
+	}
+
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "names"}},
+			Type: cty.List(cty.String),
+		},
+		{
+			Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "settings"}},
+			Type: cty.Object(map[string]cty.Type{
+				"name": cty.String,
+			}),
+		},
+		{
+			Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "untyped"}},
+			Type: cty.DynamicPseudoType,
+		},
+		{
+			Addr: lang.Address{lang.RootStep{Name: "aws_instance"}, lang.AttrStep{Name: "web"}},
+			Type: cty.Object(map[string]cty.Type{
+				"id":              cty.String,
+				"security_groups": cty.Set(cty.String),
+			}),
+			NestedTargets: reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "web"},
+						lang.AttrStep{Name: "id"},
+					},
+					Type: cty.String,
+				},
+			},
+		},
+	}
+	types := newTypeInferrer(targets, functions.UniversalFunctions())
+
+	testCases := []struct {
+		name          string
+		cfg           string
+		expectedDiags []string
+	}{
+		{
+			"convertible values",
+			`name = var.settings.name
+names = var.names
+count = "5"
+tags = { foo = "bar" }
+anyattr = ["foo"]
+`,
+			[]string{},
+		},
+		{
+			"list literal to string",
+			`name = ["foo", "bar"]
+`,
+			[]string{"Inappropriate value for attribute \"name\": string required, but tuple given."},
+		},
+		{
+			"typed variable",
+			`name = var.names
+`,
+			[]string{"Inappropriate value for attribute \"name\": string required, but list of string given."},
+		},
+		{
+			"untyped variable",
+			`name = var.untyped
+names = var.untyped
+`,
+			[]string{},
+		},
+		{
+			"resource attributes",
+			`name = aws_instance.web.id
+names = aws_instance.web.security_groups
+count = aws_instance.web.id
+tags = aws_instance.web.id
+`,
+			[]string{"Inappropriate value for attribute \"tags\": map of string required, but string given."},
+		},
+		{
+			"function return types",
+			`name = upper("foo")
+names = split(",", "a,b")
+count = length(var.names)
+tags = join(",", var.names)
+`,
+			[]string{"Inappropriate value for attribute \"tags\": map of string required, but string given."},
+		},
+		{
+			"operators and conditionals",
+			`name = 1 + 2
+count = var.untyped ? 1 : 2
+names = 1 > 2
+`,
+			[]string{"Inappropriate value for attribute \"names\": list of string required, but bool given."},
+		},
+		{
+			"other constraints",
+			`provider = { source = "hashicorp/aws" }
+changes = all
+`,
+			[]string{},
+		},
+		{
+			"mismatching other constraints",
+			`provider = ["hashicorp/aws"]
+`,
+			[]string{"Inappropriate value for attribute \"provider\": string required, but tuple given."},
+		},
+		{
+			"undeclared references",
+			`name = var.unknown
+names = local.foo
+`,
+			[]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			body := f.Body.(*hclsyntax.Body)
+
+			diags := validateBody(body, bodySchema, body.SrcRange, false, types)
+
+			details := make([]string, len(diags))
+			for i, diag := range diags {
+				if diag.Severity != hcl.DiagWarning {
+					t.Fatalf("expected warning, given: %#v", diag)
+				}
+				details[i] = diag.Detail
+			}
+			if diff := cmp.Diff(tc.expectedDiags, details); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package decoder
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// ValidateModule validates all parsed files of the given module
+// against the module schema, i.e. without requiring Terraform CLI
+// or an initialized working directory.
+//
+// Only violations which can be determined with certainty are reported,
+// e.g. bodies with unknown schema (such as resources of providers
+// whose schema is not available) are skipped.
+func ValidateModule(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader) (ast.ModDiags, error) {
+	bodySchema, err := schemaForModule(mod, schemaReader, modReader)
+	if err != nil {
+		return nil, err
+	}
+
+	types := newTypeInferrer(mod.RefTargets, functions.Functions(mod.TerraformVersion))
+
+	// Required attributes and blocks may be declared in override files
+	// so these are not reported for overridden blocks of primary files.
+	overridden := parser.OverriddenBlocks(mod.ParsedModuleFiles)
+
+	diags := make(ast.ModDiags, len(mod.ParsedModuleFiles))
+	for name, f := range mod.ParsedModuleFiles {
+		diags[name] = hcl.Diagnostics{}
+
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			// JSON bodies cannot be walked without schema
+			// and are not validated yet
+			continue
+		}
+
+		if name.IsOverride() {
+			diags[name] = validateBody(body, bodySchema, body.SrcRange, true, types)
+			continue
+		}
+
+		complete, partial := splitOverriddenBlocks(body, overridden)
+		diags[name] = append(diags[name], validateBody(complete, bodySchema, body.SrcRange, false, types)...)
+		diags[name] = append(diags[name], validateBody(partial, bodySchema, body.SrcRange, true, types)...)
+	}
+
+	return diags, nil
+}
+
+// splitOverriddenBlocks splits the body into one with blocks which are
+// not overridden, and one with just the overridden blocks
+func splitOverriddenBlocks(body *hclsyntax.Body, overridden map[string]bool) (*hclsyntax.Body, *hclsyntax.Body) {
+	complete := *body
+	complete.Blocks = make(hclsyntax.Blocks, 0, len(body.Blocks))
+	partial := *body
+	partial.Attributes = make(hclsyntax.Attributes, 0)
+	partial.Blocks = make(hclsyntax.Blocks, 0)
+
+	for _, block := range body.Blocks {
+		key, ok := parser.OverrideKey(block.AsHCLBlock())
+		if ok && overridden[key] {
+			partial.Blocks = append(partial.Blocks, block)
+			continue
+		}
+		complete.Blocks = append(complete.Blocks, block)
+	}
+
+	return &complete, &partial
+}
+
+// validateBody validates the given body against bodySchema and reports
+// any missing required items against missingItemRng, unless the body
+// is only partial (e.g. in an override file).
+//
+// Types of expressions which are not literal are checked
+// only if types is not nil.
+func validateBody(body *hclsyntax.Body, bodySchema *schema.BodySchema, missingItemRng hcl.Range, partial bool, types *typeInferrer) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if bodySchema == nil {
+		return diags
+	}
+
+	for _, attr := range sortedAttributes(body.Attributes) {
+		aSchema, ok := bodySchema.Attributes[attr.Name]
+		if !ok {
+			if bodySchema.AnyAttribute == nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Unsupported argument",
+					Detail:   fmt.Sprintf("An argument named %q is not expected here.", attr.Name),
+					Subject:  attr.NameRange.Ptr(),
+				})
+				continue
+			}
+			aSchema = bodySchema.AnyAttribute
+		}
+
+		valueDiags := validateAttributeValue(attr, aSchema)
+		if len(valueDiags) == 0 && types != nil {
+			valueDiags = types.validateAttributeType(attr, aSchema)
+		}
+		diags = append(diags, valueDiags...)
+	}
+
+	for _, name := range sortedAttributeNames(bodySchema.Attributes) {
+		aSchema := bodySchema.Attributes[name]
+		if !aSchema.IsRequired || partial {
+			continue
+		}
+		if _, ok := body.Attributes[name]; !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing required argument",
+				Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", name),
+				Subject:  missingItemRng.Ptr(),
+			})
+		}
+	}
+
+	blocksByType := make(map[string][]*hclsyntax.Block, 0)
+	dynamicBlockTypes := make(map[string]bool, 0)
+
+	for _, block := range body.Blocks {
+		bSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok {
+			if isDynamicBlock(block, bodySchema) {
+				// content of dynamic blocks is generated,
+				// so we cannot validate it statically
+				dynamicBlockTypes[block.Labels[0]] = true
+				continue
+			}
+
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported block type",
+				Detail:   fmt.Sprintf("Blocks of type %q are not expected here.", block.Type),
+				Subject:  block.TypeRange.Ptr(),
+			})
+			continue
+		}
+
+		blocksByType[block.Type] = append(blocksByType[block.Type], block)
+
+		labelDiags := validateBlockLabels(block, bSchema)
+		diags = append(diags, labelDiags...)
+		if labelDiags.HasErrors() {
+			continue
+		}
+
+		blockBodySchema, ok := blockBodySchema(block, bSchema)
+		if !ok {
+			// schema of the body depends on labels or attributes
+			// which we do not have schema for
+			continue
+		}
+
+		diags = append(diags, validateBody(block.Body, blockBodySchema, block.DefRange(), partial, types)...)
+	}
+
+	for _, bType := range sortedBlockTypes(bodySchema.Blocks) {
+		if dynamicBlockTypes[bType] {
+			continue
+		}
+
+		bSchema := bodySchema.Blocks[bType]
+		blocks := blocksByType[bType]
+
+		if !partial && bSchema.MinItems > 0 && uint64(len(blocks)) < bSchema.MinItems {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Insufficient %s blocks", bType),
+				Detail:   fmt.Sprintf("At least %d %q blocks are required.", bSchema.MinItems, bType),
+				Subject:  missingItemRng.Ptr(),
+			})
+		}
+		if bSchema.MaxItems > 0 && uint64(len(blocks)) > bSchema.MaxItems {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Too many %s blocks", bType),
+				Detail:   fmt.Sprintf("No more than %d %q blocks are allowed.", bSchema.MaxItems, bType),
+				Subject:  blocks[bSchema.MaxItems].DefRange().Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+func validateBlockLabels(block *hclsyntax.Block, bSchema *schema.BlockSchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if len(block.Labels) > len(bSchema.Labels) {
+		extraRng := block.LabelRanges[len(bSchema.Labels)]
+		detail := "No more labels are expected."
+		if len(bSchema.Labels) > 0 {
+			detail = fmt.Sprintf("Only %d labels are expected.", len(bSchema.Labels))
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Extraneous label for %s", block.Type),
+			Detail:   detail,
+			Subject:  extraRng.Ptr(),
+		})
+	}
+
+	if len(block.Labels) < len(bSchema.Labels) {
+		missingLabel := bSchema.Labels[len(block.Labels)]
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Missing %s for %s", missingLabel.Name, block.Type),
+			Detail:   fmt.Sprintf("All %s blocks must have %d labels.", block.Type, len(bSchema.Labels)),
+			Subject:  block.OpenBraceRange.Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// blockBodySchema returns body schema of the block, merged with
+// any dependent body schema, if known
+func blockBodySchema(block *hclsyntax.Block, bSchema *schema.BlockSchema) (*schema.BodySchema, bool) {
+	if len(bSchema.DependentBody) == 0 {
+		return bSchema.Body, true
+	}
+
+	depSchema, _, ok := decoder.NewBlockSchema(bSchema).DependentBodySchema(block.AsHCLBlock())
+	if !ok {
+		return nil, false
+	}
+
+	mergedSchema := &schema.BodySchema{}
+	if bSchema.Body != nil {
+		mergedSchema = bSchema.Body.Copy()
+	}
+	if mergedSchema.Attributes == nil {
+		mergedSchema.Attributes = make(map[string]*schema.AttributeSchema, 0)
+	}
+	if mergedSchema.Blocks == nil {
+		mergedSchema.Blocks = make(map[string]*schema.BlockSchema, 0)
+	}
+	for name, attr := range depSchema.Attributes {
+		if _, exists := mergedSchema.Attributes[name]; !exists {
+			mergedSchema.Attributes[name] = attr
+		}
+	}
+	for bType, block := range depSchema.Blocks {
+		if _, exists := mergedSchema.Blocks[bType]; !exists {
+			mergedSchema.Blocks[bType] = block
+		}
+	}
+	if mergedSchema.AnyAttribute == nil {
+		mergedSchema.AnyAttribute = depSchema.AnyAttribute
+	}
+
+	return mergedSchema, true
+}
+
+// isDynamicBlock reports whether the block is a dynamic block
+// generating one of the nested blocks declared in bodySchema
+func isDynamicBlock(block *hclsyntax.Block, bodySchema *schema.BodySchema) bool {
+	if block.Type != "dynamic" || len(block.Labels) != 1 {
+		return false
+	}
+	_, ok := bodySchema.Blocks[block.Labels[0]]
+	return ok
+}
+
+// validateAttributeValue reports values of literal types
+// which do not match any of the literal types in the schema
+func validateAttributeValue(attr *hclsyntax.Attribute, aSchema *schema.AttributeSchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if len(aSchema.Expr) == 0 {
+		return diags
+	}
+
+	types := make([]cty.Type, 0)
+	for _, ec := range aSchema.Expr {
+		lt, ok := ec.(schema.LiteralTypeExpr)
+		if !ok {
+			// the value may be legitimately represented
+			// by other kinds of expressions
+			return diags
+		}
+		types = append(types, lt.Type)
+	}
+
+	if len(attr.Expr.Variables()) > 0 {
+		return diags
+	}
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() {
+		return diags
+	}
+
+	for _, typ := range types {
+		if _, err := convert.Convert(val, typ); err == nil {
+			return diags
+		}
+	}
+
+	return append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Incorrect attribute value type",
+		Detail: fmt.Sprintf("Inappropriate value for attribute %q: %s required.",
+			attr.Name, aSchema.Expr.FriendlyName()),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+}
+
+func sortedAttributes(attrs hclsyntax.Attributes) []*hclsyntax.Attribute {
+	sorted := make([]*hclsyntax.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SrcRange.Start.Byte < sorted[j].SrcRange.Start.Byte
+	})
+	return sorted
+}
+
+func sortedAttributeNames(attrs map[string]*schema.AttributeSchema) []string {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedBlockTypes(blocks map[string]*schema.BlockSchema) []string {
+	types := make([]string, 0, len(blocks))
+	for bType := range blocks {
+		types = append(types, bType)
+	}
+	sort.Strings(types)
+	return types
+}
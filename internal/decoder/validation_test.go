@@ -0,0 +1,183 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestValidateBody(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type", IsDepKey: true},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {
+							IsOptional: true,
+							Expr:       schema.LiteralTypeOnly(cty.Number),
+						},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "known"},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"name": {
+								IsRequired: true,
+								Expr:       schema.LiteralTypeOnly(cty.String),
+							},
+						},
+						Blocks: map[string]*schema.BlockSchema{
+							"nested": {
+								Body:     &schema.BodySchema{},
+								MaxItems: 1,
+							},
+							"setting": {
+								Body:     &schema.BodySchema{},
+								MinItems: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		cfg           string
+		expectedDiags []string
+	}{
+		{
+			"valid",
+			`resource "known" "test" {
+  count = 1
+  name = "foo"
+  setting {}
+}
+`,
+			[]string{},
+		},
+		{
+			"unknown dependent body",
+			`resource "unknown" "test" {
+  foo = "bar"
+}
+`,
+			[]string{},
+		},
+		{
+			"unknown attribute and block",
+			`resource "known" "test" {
+  name = "foo"
+  foo = "bar"
+  setting {}
+  bar {}
+}
+`,
+			[]string{
+				"Unsupported argument",
+				"Unsupported block type",
+			},
+		},
+		{
+			"missing required attribute",
+			`resource "known" "test" {
+  setting {}
+}
+`,
+			[]string{"Missing required argument"},
+		},
+		{
+			"block counts",
+			`resource "known" "test" {
+  name = "foo"
+  nested {}
+  nested {}
+}
+`,
+			[]string{
+				"Too many nested blocks",
+				"Insufficient setting blocks",
+			},
+		},
+		{
+			"dynamic block",
+			`resource "known" "test" {
+  name = "foo"
+  dynamic "setting" {
+    for_each = var.settings
+    content {}
+  }
+}
+`,
+			[]string{},
+		},
+		{
+			"mismatching literal type",
+			`resource "known" "test" {
+  count = "foo"
+  name = ["foo"]
+  setting {}
+}
+`,
+			[]string{
+				"Incorrect attribute value type",
+				"Incorrect attribute value type",
+			},
+		},
+		{
+			"convertible literal type",
+			`resource "known" "test" {
+  count = "1"
+  name = 42
+  setting {}
+}
+`,
+			[]string{},
+		},
+		{
+			"labels",
+			`resource "known" {
+}
+resource "known" "test" "extra" {
+}
+`,
+			[]string{
+				"Missing name for resource",
+				"Extraneous label for resource",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			body := f.Body.(*hclsyntax.Body)
+
+			diags := validateBody(body, bodySchema, body.SrcRange, false, nil)
+
+			summaries := make([]string, len(diags))
+			for i, diag := range diags {
+				summaries[i] = diag.Summary
+			}
+			if diff := cmp.Diff(tc.expectedDiags, summaries); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// UndeclaredVariable represents a reference to an input variable
+// which is not declared in the module
+type UndeclaredVariable struct {
+	Name  string
+	Range hcl.Range
+
+	// Type represents the type inferred from how the variable
+	// is referenced, or from its value in autoloaded variable files.
+	// cty.DynamicPseudoType is used if the type cannot be inferred.
+	Type cty.Type
+}
+
+// UndeclaredVariables returns references to undeclared input variables
+// which overlap with the given range
+func UndeclaredVariables(mod *state.Module, rng hcl.Range) []UndeclaredVariable {
+	vars := make([]UndeclaredVariable, 0)
+	if mod.RefTargetsErr != nil {
+		return vars
+	}
+
+	declared := make(map[string]bool, 0)
+	for _, target := range mod.RefTargets {
+		declared[target.Addr.String()] = true
+	}
+
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || !rangesOverlap(localOrigin.Range, rng) {
+			continue
+		}
+
+		addr, ok := declarableAddress(localOrigin.Addr)
+		if !ok || addr[0].String() != "var" || declared[addr.String()] {
+			continue
+		}
+
+		name := StepName(addr[1])
+		vars = append(vars, UndeclaredVariable{
+			Name:  name,
+			Range: localOrigin.Range,
+			Type:  inferVariableType(mod, name, localOrigin.Constraints),
+		})
+	}
+
+	return vars
+}
+
+// VariableDeclaration returns configuration declaring
+// an input variable of the given name and type
+func VariableDeclaration(name string, typ cty.Type) string {
+	if typ == cty.DynamicPseudoType {
+		return fmt.Sprintf("variable %q {}\n", name)
+	}
+	return fmt.Sprintf("variable %q {\n  type = %s\n}\n", name, typeexpr.TypeString(typ))
+}
+
+func inferVariableType(mod *state.Module, name string, constraints reference.OriginConstraints) cty.Type {
+	for _, oc := range constraints {
+		if oc.OfType != cty.NilType && oc.OfType != cty.DynamicPseudoType {
+			return oc.OfType
+		}
+	}
+
+	for filename, f := range mod.ParsedVarsFiles {
+		if !filename.IsAutoloaded() {
+			continue
+		}
+		attrs, _ := f.Body.JustAttributes()
+		attr, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || val.IsNull() {
+			continue
+		}
+		return declarableType(val.Type())
+	}
+
+	return cty.DynamicPseudoType
+}
+
+// declarableType converts structural types as they come out
+// of literal values into collection types where possible,
+// as these are more commonly used in variable declarations
+// (e.g. list(string) instead of tuple([string, string]))
+func declarableType(typ cty.Type) cty.Type {
+	switch {
+	case typ.IsTupleType():
+		elemTypes := typ.TupleElementTypes()
+		if len(elemTypes) == 0 {
+			return cty.List(cty.DynamicPseudoType)
+		}
+		elemType, ok := commonType(elemTypes)
+		if !ok {
+			return typ
+		}
+		return cty.List(elemType)
+	case typ.IsObjectType():
+		attrTypes := make([]cty.Type, 0)
+		for _, attrType := range typ.AttributeTypes() {
+			attrTypes = append(attrTypes, attrType)
+		}
+		if len(attrTypes) == 0 {
+			return cty.Map(cty.DynamicPseudoType)
+		}
+		elemType, ok := commonType(attrTypes)
+		if !ok {
+			return typ
+		}
+		return cty.Map(elemType)
+	}
+	return typ
+}
+
+func commonType(types []cty.Type) (cty.Type, bool) {
+	first := declarableType(types[0])
+	for _, typ := range types[1:] {
+		if !declarableType(typ).Equals(first) {
+			return cty.NilType, false
+		}
+	}
+	return first, true
+}
+
+func rangesOverlap(a, b hcl.Range) bool {
+	if a.Filename != b.Filename {
+		return false
+	}
+	return !posBefore(a.End, b.Start) && !posBefore(b.End, a.Start)
+}
+
+func posBefore(a, b hcl.Pos) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
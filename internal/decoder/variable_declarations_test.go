@@ -0,0 +1,110 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestUndeclaredVariables(t *testing.T) {
+	tfvars := `tags = { env = "prod", team = "core" }
+mixed = [1, "two"]
+`
+	vf, pDiags := hclsyntax.ParseConfig([]byte(tfvars), "terraform.tfvars", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	other, pDiags := hclsyntax.ParseConfig([]byte(`count = 1`), "dev.tfvars", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	varAddr := func(name string) lang.Address {
+		return lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: name}}
+	}
+
+	mod := &state.Module{
+		Path: "/test",
+		ParsedVarsFiles: ast.VarsFiles{
+			"terraform.tfvars": vf,
+			"dev.tfvars":       other,
+		},
+		RefTargets: reference.Targets{
+			{Addr: varAddr("declared")},
+		},
+		RefOrigins: reference.Origins{
+			reference.LocalOrigin{
+				Addr:  varAddr("declared"),
+				Range: rangeInFile("main.tf", 1, 1, 0, 1, 13, 12),
+			},
+			reference.LocalOrigin{
+				Addr:  varAddr("name"),
+				Range: rangeInFile("main.tf", 2, 1, 13, 2, 9, 21),
+				Constraints: reference.OriginConstraints{
+					{OfType: cty.String},
+				},
+			},
+			reference.LocalOrigin{
+				Addr:  varAddr("tags"),
+				Range: rangeInFile("main.tf", 3, 1, 22, 3, 9, 30),
+			},
+			reference.LocalOrigin{
+				Addr:  varAddr("mixed"),
+				Range: rangeInFile("main.tf", 4, 1, 31, 4, 10, 40),
+			},
+			reference.LocalOrigin{
+				Addr:  varAddr("count"),
+				Range: rangeInFile("main.tf", 5, 1, 41, 5, 10, 50),
+			},
+			reference.LocalOrigin{
+				Addr:  varAddr("outside"),
+				Range: rangeInFile("main.tf", 9, 1, 80, 9, 12, 91),
+			},
+		},
+	}
+
+	vars := UndeclaredVariables(mod, rangeInFile("main.tf", 1, 1, 0, 5, 1, 41))
+	expectedVars := []UndeclaredVariable{
+		{Name: "name", Range: rangeInFile("main.tf", 2, 1, 13, 2, 9, 21), Type: cty.String},
+		{Name: "tags", Range: rangeInFile("main.tf", 3, 1, 22, 3, 9, 30), Type: cty.Map(cty.String)},
+		{
+			Name:  "mixed",
+			Range: rangeInFile("main.tf", 4, 1, 31, 4, 10, 40),
+			Type:  cty.Tuple([]cty.Type{cty.Number, cty.String}),
+		},
+		{Name: "count", Range: rangeInFile("main.tf", 5, 1, 41, 5, 10, 50), Type: cty.DynamicPseudoType},
+	}
+	if diff := cmp.Diff(expectedVars, vars, ctyTypeCmpOpts...); diff != "" {
+		t.Fatalf("unexpected variables: %s", diff)
+	}
+}
+
+func TestVariableDeclaration(t *testing.T) {
+	testCases := []struct {
+		typ      cty.Type
+		expected string
+	}{
+		{cty.DynamicPseudoType, "variable \"foo\" {}\n"},
+		{cty.String, "variable \"foo\" {\n  type = string\n}\n"},
+		{cty.List(cty.Number), "variable \"foo\" {\n  type = list(number)\n}\n"},
+	}
+	for _, tc := range testCases {
+		decl := VariableDeclaration("foo", tc.typ)
+		if decl != tc.expected {
+			t.Errorf("%s: expected %q, given %q", tc.typ.FriendlyName(), tc.expected, decl)
+		}
+	}
+}
+
+var ctyTypeCmpOpts = []cmp.Option{
+	cmp.Comparer(func(a, b cty.Type) bool {
+		return a.Equals(b)
+	}),
+}
@@ -0,0 +1,229 @@
+package decoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// variableValidationRule represents a validation block
+// of a variable declaration
+type variableValidationRule struct {
+	Condition    hcl.Expression
+	ErrorMessage hcl.Expression
+	DeclRange    hcl.Range
+}
+
+var (
+	variableBlockSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "variable", LabelNames: []string{"name"}},
+		},
+	}
+	validationBlockSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "validation"},
+		},
+	}
+	validationRuleSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "condition", Required: true},
+			{Name: "error_message", Required: true},
+		},
+	}
+)
+
+// ValidateVariables validates values assigned in all parsed variable
+// files (i.e. including those which are not autoloaded) against
+// the declared type constraints of variables.
+//
+// Validation rules of variables are evaluated where possible,
+// i.e. when the value and the condition can be evaluated statically.
+func ValidateVariables(mod *state.Module) ast.VarsDiags {
+	diags := make(ast.VarsDiags, len(mod.ParsedVarsFiles))
+
+	declRanges, rules := variableValidationRules(mod)
+
+	for name, f := range mod.ParsedVarsFiles {
+		diags[name] = hcl.Diagnostics{}
+
+		attrs, _ := f.Body.JustAttributes()
+		for _, attr := range sortedHCLAttributes(attrs) {
+			variable, ok := mod.Meta.Variables[attr.Name]
+			if !ok {
+				continue
+			}
+
+			val, valDiags := attr.Expr.Value(nil)
+			if valDiags.HasErrors() || val.IsNull() {
+				continue
+			}
+
+			typ := variable.Type
+			if typ == cty.NilType {
+				typ = cty.DynamicPseudoType
+			}
+			val, err := convert.Convert(val, typ)
+			if err != nil {
+				detail := fmt.Sprintf("The given value is not suitable for var.%s: %s.",
+					attr.Name, conversionErrorString(err))
+				if rng, ok := declRanges[attr.Name]; ok {
+					detail = fmt.Sprintf("The given value is not suitable for var.%s declared at %s: %s.",
+						attr.Name, rng.String(), conversionErrorString(err))
+				}
+				diags[name] = append(diags[name], &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid value for input variable",
+					Detail:   detail,
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+				continue
+			}
+
+			diags[name] = append(diags[name], evaluateValidationRules(attr, val, rules[attr.Name])...)
+		}
+	}
+
+	return diags
+}
+
+// evaluateValidationRules reports any validation rules whose condition
+// evaluates to false for the given value. Rules which cannot be evaluated
+// (e.g. because they use functions not available outside of Terraform)
+// are ignored.
+func evaluateValidationRules(attr *hcl.Attribute, val cty.Value, rules []variableValidationRule) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	evalCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{
+				attr.Name: val,
+			}),
+		},
+		Functions: functions.Implementations(),
+	}
+
+	for _, rule := range rules {
+		result, condDiags := rule.Condition.Value(evalCtx)
+		if condDiags.HasErrors() || !result.IsWhollyKnown() || result.IsNull() {
+			continue
+		}
+		result, err := convert.Convert(result, cty.Bool)
+		if err != nil || result.True() {
+			continue
+		}
+
+		msg, msgDiags := rule.ErrorMessage.Value(evalCtx)
+		if msgDiags.HasErrors() || !msg.IsWhollyKnown() || msg.IsNull() {
+			continue
+		}
+		msg, err = convert.Convert(msg, cty.String)
+		if err != nil {
+			continue
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid value for variable",
+			Detail: fmt.Sprintf("%s\n\nThis was checked by the validation rule at %s.",
+				strings.TrimSpace(msg.AsString()), rule.DeclRange.String()),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// variableValidationRules returns declaration ranges of all variables
+// along with any validation rules, by name of the variable
+func variableValidationRules(mod *state.Module) (map[string]hcl.Range, map[string][]variableValidationRule) {
+	declRanges := make(map[string]hcl.Range, 0)
+	rules := make(map[string][]variableValidationRule, 0)
+
+	for name, f := range mod.ParsedModuleFiles {
+		if name.IsOverride() {
+			continue
+		}
+
+		content, _, _ := f.Body.PartialContent(variableBlockSchema)
+		for _, block := range content.Blocks {
+			if len(block.Labels) != 1 {
+				continue
+			}
+			varName := block.Labels[0]
+			declRanges[varName] = block.DefRange
+
+			varContent, _, _ := block.Body.PartialContent(validationBlockSchema)
+			for _, vBlock := range varContent.Blocks {
+				ruleContent, _, diags := vBlock.Body.PartialContent(validationRuleSchema)
+				if diags.HasErrors() {
+					continue
+				}
+				rules[varName] = append(rules[varName], variableValidationRule{
+					Condition:    ruleContent.Attributes["condition"].Expr,
+					ErrorMessage: ruleContent.Attributes["error_message"].Expr,
+					DeclRange:    vBlock.DefRange,
+				})
+			}
+		}
+	}
+
+	return declRanges, rules
+}
+
+// conversionErrorString returns the error prefixed
+// with the path within the value where it occurred, if any
+func conversionErrorString(err error) string {
+	pathErr, ok := err.(cty.PathError)
+	if !ok || len(pathErr.Path) == 0 {
+		return err.Error()
+	}
+
+	var sb strings.Builder
+	for i, step := range pathErr.Path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if i == 0 {
+				fmt.Fprintf(&sb, "attribute %q", s.Name)
+			} else {
+				fmt.Fprintf(&sb, ".%s", s.Name)
+			}
+		case cty.IndexStep:
+			switch s.Key.Type() {
+			case cty.Number:
+				bf := s.Key.AsBigFloat()
+				if i == 0 {
+					fmt.Fprintf(&sb, "element %s", bf.Text('f', -1))
+				} else {
+					fmt.Fprintf(&sb, "[%s]", bf.Text('f', -1))
+				}
+			case cty.String:
+				if i == 0 {
+					fmt.Fprintf(&sb, "element %q", s.Key.AsString())
+				} else {
+					fmt.Fprintf(&sb, "[%q]", s.Key.AsString())
+				}
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s: %s", sb.String(), pathErr.Error())
+}
+
+func sortedHCLAttributes(attrs hcl.Attributes) []*hcl.Attribute {
+	sorted := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+	return sorted
+}
@@ -0,0 +1,91 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestValidateVariables(t *testing.T) {
+	cfg := `variable "count_min" {
+  type = number
+  validation {
+    condition     = var.count_min >= 1
+    error_message = "The count_min must be at least 1."
+  }
+}
+variable "name" {
+  type = string
+  validation {
+    condition     = can(regex("^[a-z]+$", var.name))
+    error_message = "The name must only contain lowercase letters."
+  }
+}
+variable "tags" {
+  type = map(string)
+  validation {
+    condition     = unknownfunc(var.tags)
+    error_message = "Cannot be evaluated."
+  }
+}
+`
+	vars := `count_min = 0
+name = "foobar"
+tags = ["one"]
+undeclared = true
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "variables.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	vf, pDiags := hclsyntax.ParseConfig([]byte(vars), "test.tfvars", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	mod := &state.Module{
+		Path: "/test",
+		ParsedModuleFiles: ast.ModFiles{
+			"variables.tf": f,
+		},
+		ParsedVarsFiles: ast.VarsFiles{
+			"test.tfvars": vf,
+		},
+		Meta: state.ModuleMetadata{
+			Variables: map[string]tfmod.Variable{
+				"count_min": {Type: cty.Number},
+				"name":      {Type: cty.String},
+				"tags":      {Type: cty.Map(cty.String)},
+			},
+		},
+	}
+
+	diags := ValidateVariables(mod)
+
+	expectedDiags := ast.VarsDiags{
+		"test.tfvars": hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for variable",
+				Detail:   "The count_min must be at least 1.\n\nThis was checked by the validation rule at variables.tf:3,3-13.",
+				Subject:  rangeInFile("test.tfvars", 1, 13, 12, 1, 14, 13).Ptr(),
+			},
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for input variable",
+				Detail:   "The given value is not suitable for var.tags declared at variables.tf:15,1-16: map of string required.",
+				Subject:  rangeInFile("test.tfvars", 3, 8, 37, 3, 15, 44).Ptr(),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
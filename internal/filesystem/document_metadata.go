@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/hashicorp/terraform-ls/internal/source"
@@ -14,6 +15,9 @@ type documentMetadata struct {
 	version int
 	langId  string
 	lines   source.Lines
+
+	semTokens    *SemanticTokens
+	semTokensSeq int
 }
 
 func NewDocumentMetadata(dh DocumentHandler, langId string, content []byte) *documentMetadata {
@@ -60,3 +64,20 @@ func (d *documentMetadata) IsOpen() bool {
 	defer d.mu.RUnlock()
 	return d.isOpen
 }
+
+func (d *documentMetadata) setSemanticTokens(data []uint32) *SemanticTokens {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.semTokensSeq++
+	d.semTokens = &SemanticTokens{
+		ResultID: fmt.Sprintf("%d", d.semTokensSeq),
+		Data:     data,
+	}
+	return d.semTokens
+}
+
+func (d *documentMetadata) SemanticTokens() *SemanticTokens {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.semTokens
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/hashicorp/terraform-ls/internal/source"
@@ -14,8 +15,9 @@ import (
 )
 
 type fsystem struct {
-	memFs afero.Fs
-	osFs  afero.Fs
+	memFs     afero.Fs
+	osFs      afero.Fs
+	osWriteFs afero.Fs
 
 	docMeta   map[string]*documentMetadata
 	docMetaMu *sync.RWMutex
@@ -27,6 +29,7 @@ func NewFilesystem() *fsystem {
 	return &fsystem{
 		memFs:     afero.NewMemMapFs(),
 		osFs:      afero.NewReadOnlyFs(afero.NewOsFs()),
+		osWriteFs: afero.NewOsFs(),
 		docMeta:   make(map[string]*documentMetadata, 0),
 		docMetaMu: &sync.RWMutex{},
 		logger:    log.New(ioutil.Discard, "", 0),
@@ -254,3 +257,42 @@ func (fs *fsystem) Stat(name string) (os.FileInfo, error) {
 
 	return fi, err
 }
+
+// WriteFile writes the file to disk, bypassing any open documents
+func (fs *fsystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return afero.WriteFile(fs.osWriteFs, name, data, perm)
+}
+
+// CreateDirAll creates the directory on disk along with any missing
+// parents, and returns the top-most directory which was created.
+// It fails if the directory already exists.
+func (fs *fsystem) CreateDirAll(path string, perm os.FileMode) (string, error) {
+	dirs := []string{path}
+	for dir := filepath.Dir(path); dir != dirs[len(dirs)-1]; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+
+	createdDir := ""
+	for i := len(dirs) - 1; i >= 0; i-- {
+		err := fs.osWriteFs.Mkdir(dirs[i], perm)
+		if err != nil {
+			if os.IsExist(err) && i > 0 {
+				continue
+			}
+			if createdDir != "" {
+				fs.osWriteFs.RemoveAll(createdDir)
+			}
+			return "", err
+		}
+		if createdDir == "" {
+			createdDir = dirs[i]
+		}
+	}
+
+	return createdDir, nil
+}
+
+// RemoveAll removes the path from disk along with any children
+func (fs *fsystem) RemoveAll(path string) error {
+	return fs.osWriteFs.RemoveAll(path)
+}
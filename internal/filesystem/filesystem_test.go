@@ -163,6 +163,55 @@ func TestFilesystem_GetDocument_unknownDocument(t *testing.T) {
 	}
 }
 
+func TestFilesystem_SemanticTokens(t *testing.T) {
+	fs := testDocumentStorage()
+
+	dh := &testHandler{uri: "file:///test.tf"}
+	err := fs.CreateAndOpenDocument(dh, "test", []byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := fs.SemanticTokens(dh, "1")
+	if ok {
+		t.Fatal("expected no tokens to be cached")
+	}
+
+	first, err := fs.SetSemanticTokens(dh, []uint32{0, 0, 5, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := fs.SetSemanticTokens(dh, []uint32{0, 6, 5, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ResultID == second.ResultID {
+		t.Fatalf("expected unique result IDs, both are %q", first.ResultID)
+	}
+
+	_, ok = fs.SemanticTokens(dh, first.ResultID)
+	if ok {
+		t.Fatal("expected superseded tokens not to be returned")
+	}
+
+	tokens, ok := fs.SemanticTokens(dh, second.ResultID)
+	if !ok {
+		t.Fatal("expected tokens to be cached")
+	}
+	if diff := cmp.Diff(second, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+
+	err = fs.CloseAndRemoveDocument(dh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok = fs.SemanticTokens(dh, second.ResultID)
+	if ok {
+		t.Fatal("expected tokens to be removed along with the document")
+	}
+}
+
 func TestFilesystem_ReadFile_osOnly(t *testing.T) {
 	tmpDir := TempDir(t)
 	f, err := os.Create(filepath.Join(tmpDir, "testfile"))
@@ -608,3 +657,44 @@ func testLogger() *log.Logger {
 
 	return log.New(ioutil.Discard, "", 0)
 }
+
+func TestFilesystem_CreateDirAll(t *testing.T) {
+	tmpDir := TempDir(t)
+	fs := NewFilesystem()
+
+	path := filepath.Join(tmpDir, "modules", "web")
+	createdDir, err := fs.CreateDirAll(path, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDir := filepath.Join(tmpDir, "modules")
+	if createdDir != expectedDir {
+		t.Fatalf("expected created dir %q, given %q", expectedDir, createdDir)
+	}
+
+	err = fs.WriteFile(filepath.Join(path, "main.tf"), []byte("# web"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := fs.ReadFile(filepath.Join(path, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "# web" {
+		t.Fatalf("unexpected content: %q", string(b))
+	}
+
+	_, err = fs.CreateDirAll(path, 0755)
+	if !os.IsExist(err) {
+		t.Fatalf("expected directory to exist, given error: %v", err)
+	}
+
+	err = fs.RemoveAll(createdDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fs.Stat(createdDir)
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected directory to be removed, given error: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package filesystem
+
+// SemanticTokens represents encoded semantic tokens of a document,
+// as last sent to the client, so that subsequent requests
+// can be answered with just the difference
+type SemanticTokens struct {
+	ResultID string
+	Data     []uint32
+}
+
+// SetSemanticTokens caches the encoded tokens of an open document
+// under a new result ID, replacing any previously cached tokens
+func (fs *fsystem) SetSemanticTokens(dh DocumentHandler, data []uint32) (*SemanticTokens, error) {
+	dm, err := fs.getDocumentMetadata(dh)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dm.IsOpen() {
+		return nil, &DocumentNotOpenErr{dh}
+	}
+
+	return dm.setSemanticTokens(data), nil
+}
+
+// SemanticTokens returns the tokens cached for the document,
+// as long as they match the given result ID
+func (fs *fsystem) SemanticTokens(dh DocumentHandler, resultId string) (*SemanticTokens, bool) {
+	dm, err := fs.getDocumentMetadata(dh)
+	if err != nil {
+		return nil, false
+	}
+
+	tokens := dm.SemanticTokens()
+	if tokens == nil || tokens.ResultID != resultId {
+		return nil, false
+	}
+
+	return tokens, true
+}
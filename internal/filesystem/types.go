@@ -43,6 +43,8 @@ type DocumentStorage interface {
 	CloseAndRemoveDocument(DocumentHandler) error
 	ChangeDocument(VersionedDocumentHandler, DocumentChanges) error
 	HasOpenFiles(path string) (bool, error)
+	SetSemanticTokens(DocumentHandler, []uint32) (*SemanticTokens, error)
+	SemanticTokens(dh DocumentHandler, resultId string) (*SemanticTokens, bool)
 }
 
 type Filesystem interface {
@@ -55,4 +57,9 @@ type Filesystem interface {
 	ReadDir(name string) ([]fs.DirEntry, error)
 	Open(name string) (fs.File, error)
 	Stat(name string) (os.FileInfo, error)
+
+	// direct FS write methods, bypassing documents
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	CreateDirAll(path string, perm os.FileMode) (string, error)
+	RemoveAll(path string) error
 }
@@ -0,0 +1,244 @@
+// Package format implements formatting of Terraform configuration
+// in the canonical style, as produced by terraform fmt
+package format
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Format returns the source in the canonical style, which
+// matches the output of terraform fmt, including:
+//   - alignment of attributes and indentation (as done by hclwrite)
+//   - unwrapping of interpolation-only expressions, e.g. "${var.foo}"
+//   - normalization of legacy type constraints of variables, e.g. "string"
+//   - normalization of block labels to quoted strings
+//
+// Source which is not syntactically valid is not formatted
+// and any syntax errors are returned instead.
+//
+// Files of JSON syntax (e.g. *.tf.json) are returned unchanged,
+// as terraform fmt doesn't format these either.
+func Format(src []byte, filename string) ([]byte, error) {
+	if IsJSON(filename) {
+		return src, nil
+	}
+
+	// File must be parseable as HCL native syntax before we try
+	// to format it, otherwise the formatter would likely make changes
+	// that would be hard for the user to undo.
+	_, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	formatBody(f.Body(), nil)
+
+	return f.Bytes(), nil
+}
+
+// IsJSON reports whether the file is of JSON syntax,
+// such as *.tf.json or *.tfvars.json
+func IsJSON(filename string) bool {
+	return strings.HasSuffix(filename, ".json")
+}
+
+func formatBody(body *hclwrite.Body, inBlocks []string) {
+	for name, attr := range body.Attributes() {
+		if len(inBlocks) == 1 && inBlocks[0] == "variable" && name == "type" {
+			body.SetAttributeRaw(name, formatTypeExpr(attr.Expr().BuildTokens(nil)))
+			continue
+		}
+		body.SetAttributeRaw(name, formatValueExpr(attr.Expr().BuildTokens(nil)))
+	}
+
+	for _, block := range body.Blocks() {
+		// Normalize the label formatting, removing any weird stuff like
+		// interleaved inline comments and using the idiomatic quoted
+		// label syntax.
+		block.SetLabels(block.Labels())
+
+		blockTypes := make([]string, len(inBlocks), len(inBlocks)+1)
+		copy(blockTypes, inBlocks)
+		formatBody(block.Body(), append(blockTypes, block.Type()))
+	}
+}
+
+// formatValueExpr unwraps expressions consisting of a single
+// interpolation sequence, e.g. "${var.foo}" becomes var.foo
+func formatValueExpr(tokens hclwrite.Tokens) hclwrite.Tokens {
+	if len(tokens) < 5 {
+		// Can't possibly be a "${ ... }" sequence without at least enough
+		// tokens for the delimiters and one token inside them.
+		return tokens
+	}
+	oQuote := tokens[0]
+	oBrace := tokens[1]
+	cBrace := tokens[len(tokens)-2]
+	cQuote := tokens[len(tokens)-1]
+	if oQuote.Type != hclsyntax.TokenOQuote || oBrace.Type != hclsyntax.TokenTemplateInterp ||
+		cBrace.Type != hclsyntax.TokenTemplateSeqEnd || cQuote.Type != hclsyntax.TokenCQuote {
+		// Not an interpolation sequence at all
+		return tokens
+	}
+
+	inside := tokens[2 : len(tokens)-2]
+
+	// We're only interested in sequences that are provable to be single
+	// interpolation sequences, so we look for any other interpolation
+	// sequences or literals within the outermost quotes.
+	quotes := 0
+	for _, token := range inside {
+		switch token.Type {
+		case hclsyntax.TokenOQuote:
+			quotes++
+			continue
+		case hclsyntax.TokenCQuote:
+			quotes--
+			continue
+		}
+		if quotes > 0 {
+			// Interpolation sequences inside nested quotes are okay,
+			// because they are part of a nested expression, e.g.
+			// "${foo("${bar}")}"
+			continue
+		}
+		switch token.Type {
+		case hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateSeqEnd:
+			// e.g. "${foo}${bar}"
+			return tokens
+		case hclsyntax.TokenQuotedLit:
+			// e.g. "${foo}-bar"
+			return tokens
+		}
+	}
+
+	// Leading and trailing newlines would result in an invalid
+	// expression after unwrapping, e.g.
+	// "${
+	//    foo
+	// }"
+	trimmed := trimNewlines(inside)
+
+	// Multi-line expressions (such as conditionals)
+	// need to be wrapped in parentheses to remain valid.
+	isMultiLine := false
+	hasLeadingParen := false
+	hasTrailingParen := false
+	for i, token := range trimmed {
+		switch {
+		case i == 0 && token.Type == hclsyntax.TokenOParen:
+			hasLeadingParen = true
+		case token.Type == hclsyntax.TokenNewline:
+			isMultiLine = true
+		case i == len(trimmed)-1 && token.Type == hclsyntax.TokenCParen:
+			hasTrailingParen = true
+		}
+	}
+	if isMultiLine && !(hasLeadingParen && hasTrailingParen) {
+		wrapped := make(hclwrite.Tokens, 0, len(trimmed)+2)
+		wrapped = append(wrapped, &hclwrite.Token{
+			Type:  hclsyntax.TokenOParen,
+			Bytes: []byte("("),
+		})
+		wrapped = append(wrapped, trimmed...)
+		wrapped = append(wrapped, &hclwrite.Token{
+			Type:  hclsyntax.TokenCParen,
+			Bytes: []byte(")"),
+		})
+		return wrapped
+	}
+
+	return trimmed
+}
+
+// formatTypeExpr normalizes legacy type constraints of variables,
+// e.g. "string" becomes string and list becomes list(any)
+func formatTypeExpr(tokens hclwrite.Tokens) hclwrite.Tokens {
+	switch len(tokens) {
+	case 1:
+		kwTok := tokens[0]
+		if kwTok.Type != hclsyntax.TokenIdent {
+			return tokens
+		}
+
+		// Collection types without an explicit element type
+		// mean the element type is "any"
+		switch string(kwTok.Bytes) {
+		case "list", "map", "set":
+			return collectionTypeTokens(string(kwTok.Bytes), "any")
+		}
+	case 3:
+		// Legacy quoted type constraint from Terraform 0.11 and earlier
+		oQuote := tokens[0]
+		strTok := tokens[1]
+		cQuote := tokens[2]
+		if oQuote.Type != hclsyntax.TokenOQuote || strTok.Type != hclsyntax.TokenQuotedLit ||
+			cQuote.Type != hclsyntax.TokenCQuote {
+			return tokens
+		}
+
+		// Terraform 0.11 had no concept of "any" element type
+		// and converted elements to strings, so we default to string.
+		switch string(strTok.Bytes) {
+		case "string":
+			return hclwrite.Tokens{
+				{
+					Type:  hclsyntax.TokenIdent,
+					Bytes: []byte("string"),
+				},
+			}
+		case "list", "map":
+			return collectionTypeTokens(string(strTok.Bytes), "string")
+		}
+	}
+
+	return tokens
+}
+
+func collectionTypeTokens(collType, elemType string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		{
+			Type:  hclsyntax.TokenIdent,
+			Bytes: []byte(collType),
+		},
+		{
+			Type:  hclsyntax.TokenOParen,
+			Bytes: []byte("("),
+		},
+		{
+			Type:  hclsyntax.TokenIdent,
+			Bytes: []byte(elemType),
+		},
+		{
+			Type:  hclsyntax.TokenCParen,
+			Bytes: []byte(")"),
+		},
+	}
+}
+
+func trimNewlines(tokens hclwrite.Tokens) hclwrite.Tokens {
+	if len(tokens) == 0 {
+		return nil
+	}
+	var start, end int
+	for start = 0; start < len(tokens); start++ {
+		if tokens[start].Type != hclsyntax.TokenNewline {
+			break
+		}
+	}
+	for end = len(tokens); end > 0; end-- {
+		if tokens[end-1].Type != hclsyntax.TokenNewline {
+			break
+		}
+	}
+	return tokens[start:end]
+}
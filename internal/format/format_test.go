@@ -0,0 +1,136 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			"alignment and indentation",
+			`resource "aws_instance" "web" {
+ami = "ami-123"
+  instance_type   =   "t2.micro"
+    tags = {
+  Name = "web"
+    }
+}
+`,
+			`resource "aws_instance" "web" {
+  ami           = "ami-123"
+  instance_type = "t2.micro"
+  tags = {
+    Name = "web"
+  }
+}
+`,
+		},
+		{
+			"interpolation-only expressions",
+			`locals {
+  a = "${var.foo}"
+  b = "${var.foo}-bar"
+  c = "${var.foo}${var.bar}"
+  d = "${upper("${var.foo}")}"
+  e = "${
+    var.foo
+  }"
+  f = "${var.enabled
+    ? 1
+    : 0}"
+}
+`,
+			`locals {
+  a = var.foo
+  b = "${var.foo}-bar"
+  c = "${var.foo}${var.bar}"
+  d = upper("${var.foo}")
+  e = var.foo
+  f = (var.enabled
+    ? 1
+  : 0)
+}
+`,
+		},
+		{
+			"legacy variable types",
+			`variable "a" {
+  type = "string"
+}
+variable "b" {
+  type = "list"
+}
+variable "c" {
+  type = "map"
+}
+variable "d" {
+  type = set
+}
+variable "e" {
+  type = list(number)
+}
+`,
+			`variable "a" {
+  type = string
+}
+variable "b" {
+  type = list(string)
+}
+variable "c" {
+  type = map(string)
+}
+variable "d" {
+  type = set(any)
+}
+variable "e" {
+  type = list(number)
+}
+`,
+		},
+		{
+			"block labels",
+			`resource aws_instance web {
+}
+`,
+			`resource "aws_instance" "web" {
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatted, err := Format([]byte(tc.src), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expected, string(formatted)); diff != "" {
+				t.Fatalf("unexpected output: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat_invalid(t *testing.T) {
+	_, err := Format([]byte(`resource "aws_instance" "web" {`), "test.tf")
+	if err == nil {
+		t.Fatal("expected error for invalid source")
+	}
+}
+
+func TestFormat_json(t *testing.T) {
+	src := []byte(`{"resource": {"aws_instance": {"web": {"ami":   "ami-123"}}}}`)
+	formatted, err := Format(src, "main.tf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(src), string(formatted)); diff != "" {
+		t.Fatalf("expected JSON to be left intact: %s", diff)
+	}
+}
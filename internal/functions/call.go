@@ -0,0 +1,156 @@
+package functions
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Call represents a (possibly incomplete) function call
+// enclosing a position
+type Call struct {
+	Name      string
+	NameRange hcl.Range
+	// ArgIndex is the index of the argument at the position
+	ArgIndex int
+}
+
+// CallAtPos returns the innermost function call whose
+// argument list encloses the given position.
+//
+// The call is found by scanning tokens backwards from the position,
+// so that calls which are still being typed (e.g. cidrsubnet( without
+// the closing parenthesis) are found too.
+func CallAtPos(src []byte, filename string, pos hcl.Pos) (*Call, bool) {
+	tokens := tokensBeforePos(src, filename, pos)
+
+	depth := 0
+	argIdx := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i].Type {
+		case hclsyntax.TokenCParen, hclsyntax.TokenCBrack, hclsyntax.TokenCBrace, hclsyntax.TokenTemplateSeqEnd:
+			depth++
+		case hclsyntax.TokenOParen, hclsyntax.TokenOBrack, hclsyntax.TokenOBrace, hclsyntax.TokenTemplateInterp:
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if tokens[i].Type == hclsyntax.TokenOParen && i > 0 && tokens[i-1].Type == hclsyntax.TokenIdent {
+				return &Call{
+					Name:      string(tokens[i-1].Bytes),
+					NameRange: tokens[i-1].Range,
+					ArgIndex:  argIdx,
+				}, true
+			}
+			if tokens[i].Type == hclsyntax.TokenOBrace && !isExpressionStart(tokens, i) {
+				// body of a block
+				return nil, false
+			}
+			// the position is within a nested expression,
+			// such as a list or object within a call
+			argIdx = 0
+		case hclsyntax.TokenComma:
+			if depth == 0 {
+				argIdx++
+			}
+		case hclsyntax.TokenEqual:
+			if depth == 0 && isAttributeStart(tokens, i) {
+				return nil, false
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// NameAtPos returns the name of the called function,
+// if the position is on the name
+func NameAtPos(src []byte, filename string, pos hcl.Pos) (string, hcl.Range, bool) {
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenIdent || i+1 >= len(tokens) ||
+			tokens[i+1].Type != hclsyntax.TokenOParen {
+			continue
+		}
+		if token.Range.ContainsOffset(pos.Byte) {
+			return string(token.Bytes), token.Range, true
+		}
+	}
+	return "", hcl.Range{}, false
+}
+
+// NamePrefixAtPos returns the identifier (possibly empty) preceding
+// the position, if the position is where a function name may be typed,
+// i.e. within an expression and not within a traversal
+func NamePrefixAtPos(src []byte, filename string, pos hcl.Pos) (string, hcl.Range, bool) {
+	tokens := tokensBeforePos(src, filename, pos)
+
+	prefix := ""
+	rng := hcl.Range{Filename: filename, Start: pos, End: pos}
+	if len(tokens) > 0 {
+		last := tokens[len(tokens)-1]
+		if last.Type == hclsyntax.TokenIdent && last.Range.End.Byte == pos.Byte {
+			prefix = string(last.Bytes)
+			rng = last.Range
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+	if len(tokens) == 0 {
+		return "", hcl.Range{}, false
+	}
+
+	switch tokens[len(tokens)-1].Type {
+	case hclsyntax.TokenEqual, hclsyntax.TokenOParen, hclsyntax.TokenComma, hclsyntax.TokenOBrack,
+		hclsyntax.TokenTemplateInterp, hclsyntax.TokenQuestion, hclsyntax.TokenColon,
+		hclsyntax.TokenPlus, hclsyntax.TokenMinus, hclsyntax.TokenStar, hclsyntax.TokenSlash,
+		hclsyntax.TokenPercent, hclsyntax.TokenEqualOp, hclsyntax.TokenNotEqual,
+		hclsyntax.TokenLessThan, hclsyntax.TokenLessThanEq, hclsyntax.TokenGreaterThan,
+		hclsyntax.TokenGreaterThanEq, hclsyntax.TokenAnd, hclsyntax.TokenOr,
+		hclsyntax.TokenBang, hclsyntax.TokenFatArrow:
+	default:
+		return "", hcl.Range{}, false
+	}
+
+	return prefix, rng, true
+}
+
+func tokensBeforePos(src []byte, filename string, pos hcl.Pos) hclsyntax.Tokens {
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+
+	before := make(hclsyntax.Tokens, 0)
+	for _, token := range tokens {
+		if token.Range.End.Byte > pos.Byte || token.Type == hclsyntax.TokenEOF {
+			break
+		}
+		before = append(before, token)
+	}
+	return before
+}
+
+// isAttributeStart returns true if the token at the given index
+// is the equals sign of an attribute (rather than of an object item)
+func isAttributeStart(tokens hclsyntax.Tokens, idx int) bool {
+	if idx < 1 || tokens[idx-1].Type != hclsyntax.TokenIdent {
+		return false
+	}
+	if idx == 1 {
+		return true
+	}
+	// line comments include the trailing newline
+	return tokens[idx-2].Type == hclsyntax.TokenNewline ||
+		tokens[idx-2].Type == hclsyntax.TokenComment
+}
+
+// isExpressionStart returns true if the token at the given index
+// starts an expression (e.g. an object) rather than a block body
+func isExpressionStart(tokens hclsyntax.Tokens, idx int) bool {
+	if idx < 1 {
+		return false
+	}
+	switch tokens[idx-1].Type {
+	case hclsyntax.TokenEqual, hclsyntax.TokenOParen, hclsyntax.TokenComma,
+		hclsyntax.TokenOBrack, hclsyntax.TokenColon, hclsyntax.TokenQuestion,
+		hclsyntax.TokenTemplateInterp, hclsyntax.TokenNewline:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestCallAtPos(t *testing.T) {
+	testCases := []struct {
+		name         string
+		src          string
+		offset       int
+		expectedCall *Call
+	}{
+		{
+			"incomplete call",
+			`subnet = cidrsubnet(`,
+			20,
+			&Call{Name: "cidrsubnet", ArgIndex: 0},
+		},
+		{
+			"second argument",
+			`subnet = cidrsubnet(var.cidr, `,
+			30,
+			&Call{Name: "cidrsubnet", ArgIndex: 1},
+		},
+		{
+			"nested call",
+			`subnet = cidrsubnet(var.cidr, max(1, 2), `,
+			41,
+			&Call{Name: "cidrsubnet", ArgIndex: 2},
+		},
+		{
+			"within nested call",
+			`subnet = cidrsubnet(var.cidr, max(1, `,
+			37,
+			&Call{Name: "max", ArgIndex: 1},
+		},
+		{
+			"within list argument",
+			`list = concat(["a", "b"], ["c", `,
+			32,
+			&Call{Name: "concat", ArgIndex: 1},
+		},
+		{
+			"multi-line call",
+			"subnet = cidrsubnet(\n  var.cidr,\n  ",
+			35,
+			&Call{Name: "cidrsubnet", ArgIndex: 1},
+		},
+		{
+			"after complete call",
+			`subnet = cidrsubnet(var.cidr, 8, 1)`,
+			35,
+			nil,
+		},
+		{
+			"next attribute",
+			"subnet = cidrsubnet(\nname = ",
+			28,
+			nil,
+		},
+		{
+			"block body",
+			"resource \"aws_instance\" \"web\" {\n  ",
+			35,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pos := hcl.Pos{Byte: tc.offset}
+			call, ok := CallAtPos([]byte(tc.src), "test.tf", pos)
+			if tc.expectedCall == nil {
+				if ok {
+					t.Fatalf("expected no call, given: %#v", call)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("expected call to be found")
+			}
+			call.NameRange = hcl.Range{}
+			if diff := cmp.Diff(tc.expectedCall, call); diff != "" {
+				t.Fatalf("unexpected call: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNamePrefixAtPos(t *testing.T) {
+	testCases := []struct {
+		name           string
+		src            string
+		offset         int
+		expectedPrefix string
+		expectedOk     bool
+	}{
+		{"attribute value", `subnet = cidr`, 13, "cidr", true},
+		{"empty attribute value", `subnet = `, 9, "", true},
+		{"function argument", `subnet = cidrsubnet(var.cidr, ma`, 32, "ma", true},
+		{"traversal step", `subnet = var.ci`, 15, "", false},
+		{"attribute name", "resource \"aws_instance\" \"web\" {\n  am", 36, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pos := hcl.Pos{Byte: tc.offset}
+			prefix, _, ok := NamePrefixAtPos([]byte(tc.src), "test.tf", pos)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if prefix != tc.expectedPrefix {
+				t.Fatalf("expected prefix %q, given %q", tc.expectedPrefix, prefix)
+			}
+		})
+	}
+}
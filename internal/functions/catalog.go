@@ -0,0 +1,445 @@
+package functions
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	v0_12_8  = version.Must(version.NewVersion("0.12.8"))
+	v0_12_10 = version.Must(version.NewVersion("0.12.10"))
+	v0_12_17 = version.Must(version.NewVersion("0.12.17"))
+	v0_12_20 = version.Must(version.NewVersion("0.12.20"))
+	v0_12_21 = version.Must(version.NewVersion("0.12.21"))
+	v0_13_2  = version.Must(version.NewVersion("0.13.2"))
+	v0_14_0  = version.Must(version.NewVersion("0.14.0"))
+	v0_15_0  = version.Must(version.NewVersion("0.15.0"))
+	v1_3_0   = version.Must(version.NewVersion("1.3.0"))
+)
+
+// functionsSince describes versions of Terraform which introduced
+// functions that were not available in 0.12.0
+var functionsSince = map[string]*version.Version{
+	"alltrue":          v0_14_0,
+	"anytrue":          v0_14_0,
+	"can":              v0_12_20,
+	"cidrsubnets":      v0_12_10,
+	"endswith":         v1_3_0,
+	"fileset":          v0_12_8,
+	"nonsensitive":     v0_15_0,
+	"one":              v0_15_0,
+	"parseint":         v0_12_10,
+	"sensitive":        v0_15_0,
+	"setsubtract":      v0_12_21,
+	"startswith":       v1_3_0,
+	"sum":              v0_13_2,
+	"textdecodebase64": v0_14_0,
+	"textencodebase64": v0_14_0,
+	"timecmp":          v1_3_0,
+	"trim":             v0_12_17,
+	"trimprefix":       v0_12_17,
+	"trimsuffix":       v0_12_17,
+	"try":              v0_12_20,
+}
+
+var (
+	anyType     = cty.DynamicPseudoType
+	listOfAny   = cty.List(cty.DynamicPseudoType)
+	listOfStr   = cty.List(cty.String)
+	setOfAny    = cty.Set(cty.DynamicPseudoType)
+	setOfString = cty.Set(cty.String)
+)
+
+func param(name string, typ cty.Type, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+	}
+}
+
+func varParam(name string, typ cty.Type, description string) *Parameter {
+	p := param(name, typ, description)
+	return &p
+}
+
+func fn(name, description string, params []Parameter, varParam *Parameter, returnType cty.Type) *Signature {
+	return &Signature{
+		Name:        name,
+		Description: description,
+		Params:      params,
+		VarParam:    varParam,
+		ReturnType:  returnType,
+	}
+}
+
+func pathParam() Parameter {
+	return param("path", cty.String, "Path to the file, relative to the current working directory")
+}
+
+func strParam() Parameter {
+	return param("str", cty.String, "")
+}
+
+var catalog = map[string]*Signature{
+	// Numeric functions
+	"abs": fn("abs", "Returns the absolute value of the given number.",
+		[]Parameter{param("num", cty.Number, "")}, nil, cty.Number),
+	"ceil": fn("ceil", "Returns the closest whole number that is greater than or equal to the given value.",
+		[]Parameter{param("num", cty.Number, "")}, nil, cty.Number),
+	"floor": fn("floor", "Returns the closest whole number that is less than or equal to the given value.",
+		[]Parameter{param("num", cty.Number, "")}, nil, cty.Number),
+	"log": fn("log", "Returns the logarithm of a given number in a given base.",
+		[]Parameter{
+			param("num", cty.Number, ""),
+			param("base", cty.Number, ""),
+		}, nil, cty.Number),
+	"max": fn("max", "Takes one or more numbers and returns the greatest number from the set.",
+		nil, varParam("numbers", cty.Number, ""), cty.Number),
+	"min": fn("min", "Takes one or more numbers and returns the smallest number from the set.",
+		nil, varParam("numbers", cty.Number, ""), cty.Number),
+	"parseint": fn("parseint", "Parses the given string as a representation of an integer in the specified base and returns the resulting number.",
+		[]Parameter{
+			param("number", cty.String, "String representation of the integer"),
+			param("base", cty.Number, "Base between 2 and 62"),
+		}, nil, cty.Number),
+	"pow": fn("pow", "Calculates an exponent, by raising its first argument to the power of the second argument.",
+		[]Parameter{
+			param("num", cty.Number, ""),
+			param("power", cty.Number, ""),
+		}, nil, cty.Number),
+	"signum": fn("signum", "Determines the sign of a number, returning a number between -1 and 1 to represent the sign.",
+		[]Parameter{param("num", cty.Number, "")}, nil, cty.Number),
+	"sum": fn("sum", "Takes a list or set of numbers and returns the sum of those numbers.",
+		[]Parameter{param("list", cty.List(cty.Number), "")}, nil, cty.Number),
+
+	// String functions
+	"chomp": fn("chomp", "Removes newline characters at the end of a string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"endswith": fn("endswith", "Takes two values: a string to check and a suffix string. The function returns true if the first string ends with that exact suffix.",
+		[]Parameter{
+			strParam(),
+			param("suffix", cty.String, ""),
+		}, nil, cty.Bool),
+	"format": fn("format", "Produces a string by formatting a number of other values according to a specification string.",
+		[]Parameter{param("format", cty.String, "Specification string, e.g. \"Hello, %s!\"")},
+		varParam("args", anyType, "Values to be formatted"), cty.String),
+	"formatlist": fn("formatlist", "Produces a list of strings by formatting a number of other values according to a specification string.",
+		[]Parameter{param("format", cty.String, "Specification string, e.g. \"Hello, %s!\"")},
+		varParam("args", anyType, "Values (or lists of values) to be formatted"), listOfStr),
+	"indent": fn("indent", "Adds a given number of spaces to the beginnings of all but the first line in a given multi-line string.",
+		[]Parameter{
+			param("spaces", cty.Number, "Number of spaces to add"),
+			strParam(),
+		}, nil, cty.String),
+	"join": fn("join", "Produces a string by concatenating together all elements of a given list of strings with the given delimiter.",
+		[]Parameter{param("separator", cty.String, "")},
+		varParam("lists", listOfStr, ""), cty.String),
+	"lower": fn("lower", "Converts all cased letters in the given string to lowercase.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"regex": fn("regex", "Applies a regular expression to a string and returns the matching substrings.",
+		[]Parameter{
+			param("pattern", cty.String, "Regular expression"),
+			param("string", cty.String, ""),
+		}, nil, anyType),
+	"regexall": fn("regexall", "Applies a regular expression to a string and returns a list of all matches.",
+		[]Parameter{
+			param("pattern", cty.String, "Regular expression"),
+			param("string", cty.String, ""),
+		}, nil, listOfAny),
+	"replace": fn("replace", "Searches a given string for another given substring, and replaces each occurrence with a given replacement string.",
+		[]Parameter{
+			strParam(),
+			param("substr", cty.String, "Substring or a regular expression wrapped in forward slashes"),
+			param("replace", cty.String, "Replacement string"),
+		}, nil, cty.String),
+	"split": fn("split", "Produces a list by dividing a given string at all occurrences of a given separator.",
+		[]Parameter{
+			param("separator", cty.String, ""),
+			strParam(),
+		}, nil, listOfStr),
+	"startswith": fn("startswith", "Takes two values: a string to check and a prefix string. The function returns true if the string begins with that exact prefix.",
+		[]Parameter{
+			strParam(),
+			param("prefix", cty.String, ""),
+		}, nil, cty.Bool),
+	"strrev": fn("strrev", "Reverses the characters in a string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"substr": fn("substr", "Extracts a substring from a given string by offset and (maximum) length.",
+		[]Parameter{
+			strParam(),
+			param("offset", cty.Number, "Offset of the first character"),
+			param("length", cty.Number, "Maximum length of the substring, -1 for the rest of the string"),
+		}, nil, cty.String),
+	"title": fn("title", "Converts the first letter of each word in the given string to uppercase.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"trim": fn("trim", "Removes the specified set of characters from the start and end of the given string.",
+		[]Parameter{
+			strParam(),
+			param("cutset", cty.String, "Characters to remove"),
+		}, nil, cty.String),
+	"trimprefix": fn("trimprefix", "Removes the specified prefix from the start of the given string.",
+		[]Parameter{
+			strParam(),
+			param("prefix", cty.String, ""),
+		}, nil, cty.String),
+	"trimsuffix": fn("trimsuffix", "Removes the specified suffix from the end of the given string.",
+		[]Parameter{
+			strParam(),
+			param("suffix", cty.String, ""),
+		}, nil, cty.String),
+	"trimspace": fn("trimspace", "Removes any space characters from the start and end of the given string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"upper": fn("upper", "Converts all cased letters in the given string to uppercase.",
+		[]Parameter{strParam()}, nil, cty.String),
+
+	// Collection functions
+	"alltrue": fn("alltrue", "Returns true if all elements in a given collection are true or \"true\". It also returns true if the collection is empty.",
+		[]Parameter{param("list", cty.List(cty.Bool), "")}, nil, cty.Bool),
+	"anytrue": fn("anytrue", "Returns true if any element in a given collection is true or \"true\". It also returns false if the collection is empty.",
+		[]Parameter{param("list", cty.List(cty.Bool), "")}, nil, cty.Bool),
+	"chunklist": fn("chunklist", "Splits a single list into fixed-size chunks, returning a list of lists.",
+		[]Parameter{
+			param("list", listOfAny, ""),
+			param("size", cty.Number, "Maximum size of each chunk"),
+		}, nil, cty.List(listOfAny)),
+	"coalesce": fn("coalesce", "Takes any number of arguments and returns the first one that isn't null or an empty string.",
+		nil, varParam("vals", anyType, ""), anyType),
+	"coalescelist": fn("coalescelist", "Takes any number of list arguments and returns the first one that isn't empty.",
+		nil, varParam("vals", anyType, ""), anyType),
+	"compact": fn("compact", "Takes a list of strings and returns a new list with any empty string elements removed.",
+		[]Parameter{param("list", listOfStr, "")}, nil, listOfStr),
+	"concat": fn("concat", "Takes two or more lists and combines them into a single list.",
+		nil, varParam("seqs", anyType, ""), anyType),
+	"contains": fn("contains", "Determines whether a given list or set contains a given single value as one of its elements.",
+		[]Parameter{
+			param("list", anyType, ""),
+			param("value", anyType, ""),
+		}, nil, cty.Bool),
+	"distinct": fn("distinct", "Takes a list and returns a new list with any duplicate elements removed.",
+		[]Parameter{param("list", listOfAny, "")}, nil, listOfAny),
+	"element": fn("element", "Retrieves a single element from a list.",
+		[]Parameter{
+			param("list", anyType, ""),
+			param("index", cty.Number, "Index of the element, wrapping around the length of the list"),
+		}, nil, anyType),
+	"flatten": fn("flatten", "Takes a list and replaces any elements that are lists with a flattened sequence of the list contents.",
+		[]Parameter{param("list", anyType, "")}, nil, anyType),
+	"index": fn("index", "Finds the element index for a given value in a list.",
+		[]Parameter{
+			param("list", anyType, ""),
+			param("value", anyType, ""),
+		}, nil, cty.Number),
+	"keys": fn("keys", "Takes a map and returns a list containing the keys from that map.",
+		[]Parameter{param("inputMap", anyType, "")}, nil, anyType),
+	"length": fn("length", "Determines the length of a given list, map, or string.",
+		[]Parameter{param("value", anyType, "")}, nil, cty.Number),
+	"lookup": fn("lookup", "Retrieves the value of a single element from a map, given its key. If the given key does not exist, the given default value is returned instead.",
+		[]Parameter{
+			param("inputMap", anyType, ""),
+			param("key", cty.String, ""),
+		}, varParam("default", anyType, "Value to return if the key does not exist"), anyType),
+	"matchkeys": fn("matchkeys", "Constructs a new list by taking a subset of elements from one list whose indexes match the corresponding indexes of values in another list.",
+		[]Parameter{
+			param("values", listOfAny, ""),
+			param("keys", listOfAny, ""),
+			param("searchset", listOfAny, ""),
+		}, nil, listOfAny),
+	"merge": fn("merge", "Takes an arbitrary number of maps or objects, and returns a single map or object that contains a merged set of elements from all arguments.",
+		nil, varParam("maps", anyType, ""), anyType),
+	"one": fn("one", "Takes a list, set, or tuple value with either zero or one elements. If the collection is empty, one returns null. Otherwise, one returns the first element.",
+		[]Parameter{param("list", anyType, "")}, nil, anyType),
+	"range": fn("range", "Generates a list of numbers using a start value, a limit value, and a step value.",
+		nil, varParam("params", cty.Number, "Start, limit and step, where only the limit is required"), cty.List(cty.Number)),
+	"reverse": fn("reverse", "Takes a sequence and produces a new sequence of the same length with all of the same elements as the given sequence but in reverse order.",
+		[]Parameter{param("list", anyType, "")}, nil, anyType),
+	"setintersection": fn("setintersection", "Takes multiple sets and produces a single set containing only the elements that all of the given sets have in common.",
+		[]Parameter{param("first_set", setOfAny, "")},
+		varParam("other_sets", setOfAny, ""), setOfAny),
+	"setproduct": fn("setproduct", "Finds all of the possible combinations of elements from all of the given sets by computing the Cartesian product.",
+		nil, varParam("sets", anyType, ""), anyType),
+	"setsubtract": fn("setsubtract", "Returns a new set containing the elements from the first set that are not present in the second set.",
+		[]Parameter{
+			param("a", setOfAny, ""),
+			param("b", setOfAny, ""),
+		}, nil, setOfAny),
+	"setunion": fn("setunion", "Takes multiple sets and produces a single set containing the elements from all of the given sets.",
+		[]Parameter{param("first_set", setOfAny, "")},
+		varParam("other_sets", setOfAny, ""), setOfAny),
+	"slice": fn("slice", "Extracts some consecutive elements from within a list.",
+		[]Parameter{
+			param("list", anyType, ""),
+			param("start_index", cty.Number, "Index of the first element (inclusive)"),
+			param("end_index", cty.Number, "Index of the last element (exclusive)"),
+		}, nil, anyType),
+	"sort": fn("sort", "Takes a list of strings and returns a new list with those strings sorted lexicographically.",
+		[]Parameter{param("list", listOfStr, "")}, nil, listOfStr),
+	"transpose": fn("transpose", "Takes a map of lists of strings and swaps the keys and values to produce a new map of lists of strings.",
+		[]Parameter{param("values", cty.Map(listOfStr), "")}, nil, cty.Map(listOfStr)),
+	"values": fn("values", "Takes a map and returns a list containing the values of the elements in that map.",
+		[]Parameter{param("mapping", anyType, "")}, nil, anyType),
+	"zipmap": fn("zipmap", "Constructs a map from a list of keys and a corresponding list of values.",
+		[]Parameter{
+			param("keys", listOfStr, ""),
+			param("values", anyType, ""),
+		}, nil, anyType),
+
+	// Encoding functions
+	"base64decode": fn("base64decode", "Takes a string containing a Base64 character sequence and returns the original string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"base64encode": fn("base64encode", "Applies Base64 encoding to a string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"base64gzip": fn("base64gzip", "Compresses a string with gzip and then encodes the result in Base64 encoding.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"csvdecode": fn("csvdecode", "Decodes a string containing CSV-formatted data and produces a list of maps representing that data.",
+		[]Parameter{strParam()}, nil, anyType),
+	"jsondecode": fn("jsondecode", "Interprets a given string as JSON, returning a representation of the result of decoding that string.",
+		[]Parameter{strParam()}, nil, anyType),
+	"jsonencode": fn("jsonencode", "Encodes a given value to a string using JSON syntax.",
+		[]Parameter{param("val", anyType, "")}, nil, cty.String),
+	"textdecodebase64": fn("textdecodebase64", "Decodes a string that was previously Base64-encoded, and then interprets the result as characters in a specified character encoding.",
+		[]Parameter{
+			param("source", cty.String, ""),
+			param("encoding", cty.String, "Name of the character encoding, e.g. UTF-16LE"),
+		}, nil, cty.String),
+	"textencodebase64": fn("textencodebase64", "Encodes the unicode characters in a given string using a specified character encoding, returning the result Base64 encoded.",
+		[]Parameter{
+			param("string", cty.String, ""),
+			param("encoding", cty.String, "Name of the character encoding, e.g. UTF-16LE"),
+		}, nil, cty.String),
+	"urlencode": fn("urlencode", "Applies URL encoding to a given string.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"yamldecode": fn("yamldecode", "Parses a string as a subset of YAML, and produces a representation of its value.",
+		[]Parameter{param("src", cty.String, "")}, nil, anyType),
+	"yamlencode": fn("yamlencode", "Encodes a given value to a string using YAML 1.2 block syntax.",
+		[]Parameter{param("value", anyType, "")}, nil, cty.String),
+
+	// Filesystem functions
+	"abspath": fn("abspath", "Takes a string containing a filesystem path and converts it to an absolute path.",
+		[]Parameter{param("path", cty.String, "")}, nil, cty.String),
+	"basename": fn("basename", "Takes a string containing a filesystem path and removes all except the last portion from it.",
+		[]Parameter{param("path", cty.String, "")}, nil, cty.String),
+	"dirname": fn("dirname", "Takes a string containing a filesystem path and removes the last portion from it.",
+		[]Parameter{param("path", cty.String, "")}, nil, cty.String),
+	"file": fn("file", "Reads the contents of a file at the given path and returns them as a string.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filebase64": fn("filebase64", "Reads the contents of a file at the given path and returns them as a base64-encoded string.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"fileexists": fn("fileexists", "Determines whether a file exists at a given path.",
+		[]Parameter{pathParam()}, nil, cty.Bool),
+	"fileset": fn("fileset", "Enumerates a set of regular file names given a path and pattern.",
+		[]Parameter{
+			param("path", cty.String, "Base directory"),
+			param("pattern", cty.String, "Pattern of file names, e.g. *.txt"),
+		}, nil, setOfString),
+	"pathexpand": fn("pathexpand", "Takes a filesystem path that might begin with a ~ segment, and if so it replaces that segment with the current user's home directory path.",
+		[]Parameter{param("path", cty.String, "")}, nil, cty.String),
+	"templatefile": fn("templatefile", "Reads the file at the given path and renders its content as a template using a supplied set of template variables.",
+		[]Parameter{
+			pathParam(),
+			param("vars", anyType, "Object of template variables"),
+		}, nil, cty.String),
+
+	// Date and time functions
+	"formatdate": fn("formatdate", "Converts a timestamp into a different time format.",
+		[]Parameter{
+			param("format", cty.String, "Format specification, e.g. \"YYYY-MM-DD\""),
+			param("time", cty.String, "Timestamp in RFC 3339 format"),
+		}, nil, cty.String),
+	"timeadd": fn("timeadd", "Adds a duration to a timestamp, returning a new timestamp.",
+		[]Parameter{
+			param("timestamp", cty.String, "Timestamp in RFC 3339 format"),
+			param("duration", cty.String, "Duration, e.g. \"10m\""),
+		}, nil, cty.String),
+	"timecmp": fn("timecmp", "Compares two timestamps and returns a number that represents the ordering of the instants those timestamps represent.",
+		[]Parameter{
+			param("timestamp_a", cty.String, "Timestamp in RFC 3339 format"),
+			param("timestamp_b", cty.String, "Timestamp in RFC 3339 format"),
+		}, nil, cty.Number),
+	"timestamp": fn("timestamp", "Returns a UTC timestamp string in RFC 3339 format.",
+		nil, nil, cty.String),
+
+	// Hash and crypto functions
+	"base64sha256": fn("base64sha256", "Computes the SHA256 hash of a given string and encodes it with Base64.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"base64sha512": fn("base64sha512", "Computes the SHA512 hash of a given string and encodes it with Base64.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"bcrypt": fn("bcrypt", "Computes a hash of the given string using the Blowfish cipher, returning a string in the Modular Crypt Format.",
+		[]Parameter{strParam()},
+		varParam("cost", cty.Number, "Cost factor, 10 by default"), cty.String),
+	"filebase64sha256": fn("filebase64sha256", "Computes the SHA256 hash of the contents of a given file and encodes it with Base64.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filebase64sha512": fn("filebase64sha512", "Computes the SHA512 hash of the contents of a given file and encodes it with Base64.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filemd5": fn("filemd5", "Computes the MD5 hash of the contents of a given file and encodes it as hex.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filesha1": fn("filesha1", "Computes the SHA1 hash of the contents of a given file and encodes it as hex.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filesha256": fn("filesha256", "Computes the SHA256 hash of the contents of a given file and encodes it as hex.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"filesha512": fn("filesha512", "Computes the SHA512 hash of the contents of a given file and encodes it as hex.",
+		[]Parameter{pathParam()}, nil, cty.String),
+	"md5": fn("md5", "Computes the MD5 hash of a given string and encodes it with hexadecimal digits.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"rsadecrypt": fn("rsadecrypt", "Decrypts an RSA-encrypted ciphertext, returning the corresponding cleartext.",
+		[]Parameter{
+			param("ciphertext", cty.String, "Base64-encoded ciphertext"),
+			param("privatekey", cty.String, "PEM-encoded RSA private key"),
+		}, nil, cty.String),
+	"sha1": fn("sha1", "Computes the SHA1 hash of a given string and encodes it with hexadecimal digits.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"sha256": fn("sha256", "Computes the SHA256 hash of a given string and encodes it with hexadecimal digits.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"sha512": fn("sha512", "Computes the SHA512 hash of a given string and encodes it with hexadecimal digits.",
+		[]Parameter{strParam()}, nil, cty.String),
+	"uuid": fn("uuid", "Generates a unique identifier string.",
+		nil, nil, cty.String),
+	"uuidv5": fn("uuidv5", "Generates a name-based UUID, as described in RFC 4122 section 4.3.",
+		[]Parameter{
+			param("namespace", cty.String, "One of dns, url, oid, x500 or a UUID"),
+			param("name", cty.String, ""),
+		}, nil, cty.String),
+
+	// IP network functions
+	"cidrhost": fn("cidrhost", "Calculates a full host IP address for a given host number within a given IP network address prefix.",
+		[]Parameter{
+			param("prefix", cty.String, "IP network address prefix in CIDR notation"),
+			param("hostnum", cty.Number, "Whole number of the host within the network"),
+		}, nil, cty.String),
+	"cidrnetmask": fn("cidrnetmask", "Converts an IPv4 address prefix given in CIDR notation into a subnet mask address.",
+		[]Parameter{
+			param("prefix", cty.String, "IPv4 address prefix in CIDR notation"),
+		}, nil, cty.String),
+	"cidrsubnet": fn("cidrsubnet", "Calculates a subnet address within given IP network address prefix.",
+		[]Parameter{
+			param("prefix", cty.String, "IP network address prefix in CIDR notation"),
+			param("newbits", cty.Number, "Number of additional bits with which to extend the prefix"),
+			param("netnum", cty.Number, "Whole number that can be represented as a binary integer with no more than newbits binary digits"),
+		}, nil, cty.String),
+	"cidrsubnets": fn("cidrsubnets", "Calculates a sequence of consecutive IP address ranges within a particular CIDR prefix.",
+		[]Parameter{
+			param("prefix", cty.String, "IP network address prefix in CIDR notation"),
+		}, varParam("newbits", cty.Number, "Number of additional bits with which to extend the prefix for each subnet"), listOfStr),
+
+	// Type conversion functions
+	"can": fn("can", "Evaluates the given expression and returns a boolean value indicating whether the expression produced a result without any errors.",
+		[]Parameter{param("expression", anyType, "")}, nil, cty.Bool),
+	"nonsensitive": fn("nonsensitive", "Takes a sensitive value and returns a copy of that value with the sensitive marking removed.",
+		[]Parameter{param("value", anyType, "")}, nil, anyType),
+	"sensitive": fn("sensitive", "Takes any value and returns a copy of it marked so that Terraform will treat it as sensitive.",
+		[]Parameter{param("value", anyType, "")}, nil, anyType),
+	"tobool": fn("tobool", "Converts its argument to a boolean value.",
+		[]Parameter{param("v", anyType, "")}, nil, cty.Bool),
+	"tolist": fn("tolist", "Converts its argument to a list value.",
+		[]Parameter{param("v", anyType, "")}, nil, listOfAny),
+	"tomap": fn("tomap", "Converts its argument to a map value.",
+		[]Parameter{param("v", anyType, "")}, nil, cty.Map(anyType)),
+	"tonumber": fn("tonumber", "Converts its argument to a number value.",
+		[]Parameter{param("v", anyType, "")}, nil, cty.Number),
+	"toset": fn("toset", "Converts its argument to a set value.",
+		[]Parameter{param("v", anyType, "")}, nil, setOfAny),
+	"tostring": fn("tostring", "Converts its argument to a string value.",
+		[]Parameter{param("v", anyType, "")}, nil, cty.String),
+	"try": fn("try", "Evaluates all of its argument expressions in turn and returns the result of the first one that does not produce any errors.",
+		nil, varParam("expressions", anyType, ""), anyType),
+}
@@ -0,0 +1,125 @@
+// Package functions provides signatures of Terraform built-in functions
+// for signature help, completion and hover
+package functions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Signature represents a signature of a built-in function
+type Signature struct {
+	Name        string
+	Description string
+	Params      []Parameter
+	// VarParam is the variadic parameter which follows
+	// all other parameters, if any
+	VarParam   *Parameter
+	ReturnType cty.Type
+}
+
+// Parameter represents a parameter of a function
+type Parameter struct {
+	Name        string
+	Type        cty.Type
+	Description string
+}
+
+func (p Parameter) label() string {
+	return fmt.Sprintf("%s %s", p.Name, typeString(p.Type))
+}
+
+// Label returns the signature in a human-readable form,
+// e.g. cidrsubnet(prefix string, newbits number, netnum number) string
+func (s *Signature) Label() string {
+	return fmt.Sprintf("%s(%s) %s", s.Name, strings.Join(s.ParamLabels(), ", "), typeString(s.ReturnType))
+}
+
+// ParamLabels returns labels of all parameters (as found in Label),
+// where label of the variadic parameter is prefixed with "..."
+func (s *Signature) ParamLabels() []string {
+	labels := make([]string, 0, len(s.Params)+1)
+	for _, p := range s.Params {
+		labels = append(labels, p.label())
+	}
+	if s.VarParam != nil {
+		labels = append(labels, "..."+s.VarParam.label())
+	}
+	return labels
+}
+
+// AllParams returns all parameters, including the variadic one
+func (s *Signature) AllParams() []Parameter {
+	params := make([]Parameter, len(s.Params), len(s.Params)+1)
+	copy(params, s.Params)
+	if s.VarParam != nil {
+		params = append(params, *s.VarParam)
+	}
+	return params
+}
+
+// ParamIndex returns index of the parameter (within AllParams)
+// which corresponds to an argument with the given index
+func (s *Signature) ParamIndex(argIdx int) (int, bool) {
+	if argIdx < len(s.Params) {
+		return argIdx, true
+	}
+	if s.VarParam != nil {
+		return len(s.Params), true
+	}
+	return 0, false
+}
+
+func typeString(typ cty.Type) string {
+	if typ == cty.NilType {
+		return "any"
+	}
+	return typeexpr.TypeString(typ)
+}
+
+// FunctionsForVersion returns signatures of all functions
+// available in the given version of Terraform
+func FunctionsForVersion(v *version.Version) map[string]*Signature {
+	sigs := make(map[string]*Signature, 0)
+	for name, sig := range catalog {
+		if since, ok := functionsSince[name]; ok && v.LessThan(since) {
+			continue
+		}
+		sigs[name] = sig
+	}
+	return sigs
+}
+
+// UniversalFunctions returns signatures of all known functions,
+// which is useful when Terraform version is not known
+func UniversalFunctions() map[string]*Signature {
+	sigs := make(map[string]*Signature, len(catalog))
+	for name, sig := range catalog {
+		sigs[name] = sig
+	}
+	return sigs
+}
+
+// Functions returns signatures of functions for the given
+// Terraform version, or all known functions if the version is nil
+func Functions(v *version.Version) map[string]*Signature {
+	if v == nil {
+		return UniversalFunctions()
+	}
+	return FunctionsForVersion(v)
+}
+
+// SortedNames returns names of the given functions sorted alphabetically
+func SortedNames(sigs map[string]*Signature) []string {
+	names := make([]string, 0, len(sigs))
+	for name := range sigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
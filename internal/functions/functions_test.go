@@ -0,0 +1,63 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestFunctionsForVersion(t *testing.T) {
+	v0_12 := version.Must(version.NewVersion("0.12.0"))
+	sigs := FunctionsForVersion(v0_12)
+	if _, ok := sigs["cidrsubnet"]; !ok {
+		t.Fatal("expected cidrsubnet to be available in 0.12.0")
+	}
+	if _, ok := sigs["try"]; ok {
+		t.Fatal("expected try not to be available in 0.12.0")
+	}
+
+	v1_0 := version.Must(version.NewVersion("1.0.0"))
+	sigs = FunctionsForVersion(v1_0)
+	if _, ok := sigs["try"]; !ok {
+		t.Fatal("expected try to be available in 1.0.0")
+	}
+	if _, ok := sigs["startswith"]; ok {
+		t.Fatal("expected startswith not to be available in 1.0.0")
+	}
+}
+
+func TestSignature_Label(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedLabel string
+	}{
+		{"cidrsubnet", "cidrsubnet(prefix string, newbits number, netnum number) string"},
+		{"join", "join(separator string, ...lists list(string)) string"},
+		{"timestamp", "timestamp() string"},
+		{"merge", "merge(...maps any) any"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			label := catalog[tc.name].Label()
+			if label != tc.expectedLabel {
+				t.Fatalf("expected label %q, given %q", tc.expectedLabel, label)
+			}
+		})
+	}
+}
+
+func TestSignature_ParamIndex(t *testing.T) {
+	join := catalog["join"]
+	for argIdx, expectedIdx := range []int{0, 1, 1, 1} {
+		idx, ok := join.ParamIndex(argIdx)
+		if !ok || idx != expectedIdx {
+			t.Fatalf("argument %d: expected parameter %d, given %d (%t)", argIdx, expectedIdx, idx, ok)
+		}
+	}
+
+	abs := catalog["abs"]
+	if _, ok := abs.ParamIndex(1); ok {
+		t.Fatal("expected no parameter for second argument of abs")
+	}
+}
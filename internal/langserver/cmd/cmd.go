@@ -15,6 +15,15 @@ func Name(name string) string {
 	return langServerPrefix + name
 }
 
+// PrefixedName returns the name of the command (as returned by Name)
+// as advertised to the client with the given command prefix
+func PrefixedName(name, commandPrefix string) string {
+	if commandPrefix != "" {
+		return commandPrefix + "." + name
+	}
+	return name
+}
+
 func (h Handlers) Names(commandPrefix string) (names []string) {
 	if commandPrefix != "" {
 		commandPrefix += "."
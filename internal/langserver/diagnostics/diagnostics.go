@@ -18,7 +18,15 @@ type diagContext struct {
 	diags []lsp.Diagnostic
 }
 
-type DiagnosticSource string
+// DiagnosticSource represents the source of diagnostics
+type DiagnosticSource struct {
+	Name string
+
+	// Unnecessary indicates diagnostics about unused or otherwise
+	// unnecessary configuration, which are reported to the client
+	// as hints with the Unnecessary tag
+	Unnecessary bool
+}
 
 type ClientNotifier interface {
 	Notify(ctx context.Context, method string, params interface{}) error
@@ -58,7 +66,11 @@ func (n *Notifier) PublishHCLDiags(ctx context.Context, dirPath string, diags Di
 	for filename, ds := range diags {
 		fileDiags := make([]lsp.Diagnostic, 0)
 		for source, diags := range ds {
-			fileDiags = append(fileDiags, ilsp.HCLDiagsToLSP(diags, string(source))...)
+			if source.Unnecessary {
+				fileDiags = append(fileDiags, ilsp.UnnecessaryHCLDiagsToLSP(diags, source.Name)...)
+				continue
+			}
+			fileDiags = append(fileDiags, ilsp.HCLDiagsToLSP(diags, source.Name)...)
 		}
 
 		n.diags <- diagContext{
@@ -94,11 +106,21 @@ func (d Diagnostics) EmptyRootDiagnostic() Diagnostics {
 }
 
 func (d Diagnostics) Append(src string, diagsMap map[string]hcl.Diagnostics) Diagnostics {
+	return d.append(DiagnosticSource{Name: src}, diagsMap)
+}
+
+// AppendUnnecessary appends diagnostics about unused or otherwise
+// unnecessary configuration, such as unused variables
+func (d Diagnostics) AppendUnnecessary(src string, diagsMap map[string]hcl.Diagnostics) Diagnostics {
+	return d.append(DiagnosticSource{Name: src, Unnecessary: true}, diagsMap)
+}
+
+func (d Diagnostics) append(src DiagnosticSource, diagsMap map[string]hcl.Diagnostics) Diagnostics {
 	for uri, uriDiags := range diagsMap {
 		if _, ok := d[uri]; !ok {
 			d[uri] = make(map[DiagnosticSource]hcl.Diagnostics, 0)
 		}
-		d[uri][DiagnosticSource(src)] = uriDiags
+		d[uri][src] = uriDiags
 	}
 
 	return d
@@ -94,7 +94,7 @@ func TestDiagnostics_Append(t *testing.T) {
 
 	expectedDiags := Diagnostics{
 		"first.tf": map[DiagnosticSource]hcl.Diagnostics{
-			DiagnosticSource("foo"): {
+			{Name: "foo"}: {
 				&hcl.Diagnostic{
 					Severity: hcl.DiagError,
 					Summary:  "Something went wrong",
@@ -110,7 +110,7 @@ func TestDiagnostics_Append(t *testing.T) {
 					},
 				},
 			},
-			DiagnosticSource("bar"): {
+			{Name: "bar"}: {
 				&hcl.Diagnostic{
 					Severity: hcl.DiagError,
 					Summary:  "Something else went wrong",
@@ -119,7 +119,7 @@ func TestDiagnostics_Append(t *testing.T) {
 			},
 		},
 		"second.tf": map[DiagnosticSource]hcl.Diagnostics{
-			DiagnosticSource("bar"): {
+			{Name: "bar"}: {
 				&hcl.Diagnostic{
 					Severity: hcl.DiagWarning,
 					Summary:  "Beware",
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+// The call hierarchy represents modules calling each other.
+//
+// Items are either module blocks, or whole modules (directories)
+// represented by one of their files, so that ranges of calls
+// within that file can be reported. Path of the (called) module
+// is passed between requests in the item's data.
+
+func (svc *service) TextDocumentPrepareCallHierarchy(ctx context.Context, params lsp.CallHierarchyPrepareParams) ([]lsp.CallHierarchyItem, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.LanguageID() != ilsp.Terraform.String() {
+		return nil, nil
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		// the module may not be loaded yet, in which case
+		// the document represents the module on its own
+		text, err := doc.Text()
+		if err != nil {
+			return nil, err
+		}
+		f, _ := hclsyntax.ParseConfig(text, doc.Filename(), hcl.InitialPos)
+		return []lsp.CallHierarchyItem{moduleItem(doc.Dir(), doc.Filename(), f)}, nil
+	}
+
+	calls, err := decoder.ModuleCallBlocks(svc.modStore, mod)
+	if err != nil {
+		return nil, err
+	}
+	for _, call := range calls {
+		if call.Range.Filename == doc.Filename() && call.Range.ContainsPos(fPos.Position()) {
+			return []lsp.CallHierarchyItem{moduleCallItem(call)}, nil
+		}
+	}
+
+	f := mod.ParsedModuleFiles[ast.ModFilename(doc.Filename())]
+	return []lsp.CallHierarchyItem{moduleItem(doc.Dir(), doc.Filename(), f)}, nil
+}
+
+func (svc *service) CallHierarchyIncomingCalls(ctx context.Context, params lsp.CallHierarchyIncomingCallsParams) ([]lsp.CallHierarchyIncomingCall, error) {
+	calls := make([]lsp.CallHierarchyIncomingCall, 0)
+
+	modPath, ok := modulePathFromItem(params.Item)
+	if !ok {
+		return calls, nil
+	}
+
+	callers, err := decoder.ModuleCallerBlocks(svc.modStore, modPath)
+	if err != nil {
+		return calls, err
+	}
+
+	// calls from the same file are reported together
+	for _, caller := range callers {
+		itemUri := lsp.DocumentURI(uri.FromPath(filepath.Join(caller.CallerPath, caller.Range.Filename)))
+		rng := ilsp.HCLRangeToLSP(caller.Range)
+
+		if len(calls) > 0 && calls[len(calls)-1].From.URI == itemUri {
+			last := &calls[len(calls)-1]
+			last.FromRanges = append(last.FromRanges, rng)
+			continue
+		}
+
+		var f *hcl.File
+		if callerMod, err := svc.modStore.ModuleByPath(caller.CallerPath); err == nil {
+			f = callerMod.ParsedModuleFiles[ast.ModFilename(caller.Range.Filename)]
+		}
+
+		calls = append(calls, lsp.CallHierarchyIncomingCall{
+			From:       moduleItem(caller.CallerPath, caller.Range.Filename, f),
+			FromRanges: []lsp.Range{rng},
+		})
+	}
+
+	return calls, nil
+}
+
+func (svc *service) CallHierarchyOutgoingCalls(ctx context.Context, params lsp.CallHierarchyOutgoingCallsParams) ([]lsp.CallHierarchyOutgoingCall, error) {
+	calls := make([]lsp.CallHierarchyOutgoingCall, 0)
+
+	modPath, ok := modulePathFromItem(params.Item)
+	if !ok {
+		return calls, nil
+	}
+
+	mod, err := svc.modStore.ModuleByPath(modPath)
+	if err != nil {
+		// the module may not be known (e.g. not installed)
+		return calls, nil
+	}
+
+	moduleCalls, err := decoder.ModuleCallBlocks(svc.modStore, mod)
+	if err != nil {
+		return calls, err
+	}
+
+	for _, call := range moduleCalls {
+		calls = append(calls, lsp.CallHierarchyOutgoingCall{
+			To:         moduleCallItem(call),
+			FromRanges: []lsp.Range{ilsp.HCLRangeToLSP(call.Range)},
+		})
+	}
+
+	return calls, nil
+}
+
+// moduleItem represents the module at modPath by one of its files,
+// ranging over the whole (parsed) file, with the first block selected
+func moduleItem(modPath, filename string, f *hcl.File) lsp.CallHierarchyItem {
+	item := lsp.CallHierarchyItem{
+		Name:   filepath.Base(modPath),
+		Kind:   lsp.Module,
+		Detail: modPath,
+		URI:    lsp.DocumentURI(uri.FromPath(filepath.Join(modPath, filename))),
+		Data: map[string]interface{}{
+			"path": modPath,
+		},
+	}
+
+	if f == nil {
+		return item
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return item
+	}
+
+	item.Range = ilsp.HCLRangeToLSP(body.SrcRange)
+	item.SelectionRange = item.Range
+	if len(body.Blocks) > 0 {
+		item.SelectionRange = ilsp.HCLRangeToLSP(body.Blocks[0].DefRange())
+	}
+
+	return item
+}
+
+// moduleCallItem represents a module block, i.e. the called module
+func moduleCallItem(call decoder.ModuleCallBlock) lsp.CallHierarchyItem {
+	item := lsp.CallHierarchyItem{
+		Name:           call.Name,
+		Kind:           lsp.Module,
+		Detail:         call.SourceAddr,
+		URI:            lsp.DocumentURI(uri.FromPath(filepath.Join(call.CallerPath, call.Range.Filename))),
+		Range:          ilsp.HCLRangeToLSP(call.Range),
+		SelectionRange: ilsp.HCLRangeToLSP(call.NameRange),
+	}
+	if call.Path != "" {
+		item.Data = map[string]interface{}{
+			"path": call.Path,
+		}
+	}
+	return item
+}
+
+func modulePathFromItem(item lsp.CallHierarchyItem) (string, bool) {
+	data, ok := item.Data.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	modPath, ok := data["path"].(string)
+	if !ok || modPath == "" {
+		return "", false
+	}
+	return modPath, true
+}
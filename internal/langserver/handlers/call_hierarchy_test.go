@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPrepareCallHierarchy_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/prepareCallHierarchy",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {"line": 0, "character": 9}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestCallHierarchy_withValidData(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	// callers are looked up via the module manifest
+	modulesDir := filepath.Join(tmpDir.Dir(), ".terraform", "modules")
+	err := os.MkdirAll(modulesDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestBytes := []byte(`{
+    "Modules": [
+        {
+            "Key": "",
+            "Source": "",
+            "Dir": "."
+        },
+        {
+            "Key": "child",
+            "Source": "./child",
+            "Dir": "child"
+        }
+    ]
+}`)
+	err = os.WriteFile(filepath.Join(modulesDir, "modules.json"), manifestBytes, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "module \"child\" {\n  source = \"./child\"\n}\n\nlocals {}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	childPath := filepath.Join(tmpDir.Dir(), "child")
+	rootName := filepath.Base(tmpDir.Dir())
+
+	// module block
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/prepareCallHierarchy",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {"line": 0, "character": 9}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"name": "child",
+					"kind": 2,
+					"detail": "./child",
+					"uri": "%s/main.tf",
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 2, "character": 1}
+					},
+					"selectionRange": {
+						"start": {"line": 0, "character": 7},
+						"end": {"line": 0, "character": 14}
+					},
+					"data": {"path": %q}
+				}
+			]
+		}`, tmpDir.URI(), childPath))
+
+	// module directory
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/prepareCallHierarchy",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {"line": 4, "character": 2}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"name": %q,
+					"kind": 2,
+					"detail": %q,
+					"uri": "%s/main.tf",
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 5, "character": 0}
+					},
+					"selectionRange": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 0, "character": 14}
+					},
+					"data": {"path": %q}
+				}
+			]
+		}`, rootName, tmpDir.Dir(), tmpDir.URI(), tmpDir.Dir()))
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "callHierarchy/outgoingCalls",
+		ReqParams: fmt.Sprintf(`{
+			"item": {
+				"name": %q,
+				"kind": 2,
+				"detail": %q,
+				"uri": "%s/main.tf",
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 5, "character": 0}
+				},
+				"selectionRange": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 14}
+				},
+				"data": {"path": %q}
+			}
+		}`, rootName, tmpDir.Dir(), tmpDir.URI(), tmpDir.Dir())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": [
+				{
+					"to": {
+						"name": "child",
+						"kind": 2,
+						"detail": "./child",
+						"uri": "%s/main.tf",
+						"range": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 2, "character": 1}
+						},
+						"selectionRange": {
+							"start": {"line": 0, "character": 7},
+							"end": {"line": 0, "character": 14}
+						},
+						"data": {"path": %q}
+					},
+					"fromRanges": [
+						{
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 2, "character": 1}
+						}
+					]
+				}
+			]
+		}`, tmpDir.URI(), childPath))
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "callHierarchy/incomingCalls",
+		ReqParams: fmt.Sprintf(`{
+			"item": {
+				"name": "child",
+				"kind": 2,
+				"detail": "./child",
+				"uri": "%s/main.tf",
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 2, "character": 1}
+				},
+				"selectionRange": {
+					"start": {"line": 0, "character": 7},
+					"end": {"line": 0, "character": 14}
+				},
+				"data": {"path": %q}
+			}
+		}`, tmpDir.URI(), childPath)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 6,
+			"result": [
+				{
+					"from": {
+						"name": %q,
+						"kind": 2,
+						"detail": %q,
+						"uri": "%s/main.tf",
+						"range": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 5, "character": 0}
+						},
+						"selectionRange": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 0, "character": 14}
+						},
+						"data": {"path": %q}
+					},
+					"fromRanges": [
+						{
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 2, "character": 1}
+						}
+					]
+				}
+			]
+		}`, rootName, tmpDir.Dir(), tmpDir.URI(), tmpDir.Dir()))
+}
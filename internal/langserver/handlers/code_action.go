@@ -5,43 +5,48 @@ import (
 	"fmt"
 
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
-	"github.com/hashicorp/terraform-ls/internal/langserver/errors"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
-	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 )
 
-func (h *logHandler) TextDocumentCodeAction(ctx context.Context, params lsp.CodeActionParams) []lsp.CodeAction {
-	ca, err := h.textDocumentCodeAction(ctx, params)
+// TextDocumentCodeAction returns code actions, i.e. lsp.CodeAction
+// or lsp.ResourceOpsCodeAction where files are created
+func (svc *service) TextDocumentCodeAction(ctx context.Context, params lsp.CodeActionParams) []interface{} {
+	ca, err := svc.textDocumentCodeAction(ctx, params)
 	if err != nil {
-		h.logger.Printf("code action failed: %s", err)
+		svc.logger.Printf("code action failed: %s", err)
 	}
 
 	return ca
 }
 
-func (h *logHandler) textDocumentCodeAction(ctx context.Context, params lsp.CodeActionParams) ([]lsp.CodeAction, error) {
-	var ca []lsp.CodeAction
+func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeActionParams) ([]interface{}, error) {
+	var ca []interface{}
 
 	// For action definitions, refer to https://code.visualstudio.com/api/references/vscode-api#CodeActionKind
-	// We only support format type code actions at the moment, and do not want to format without the client asking for
-	// them, so exit early here if nothing is requested.
+	// We do not want to format without the client asking for it, so only quick fixes
+	// and refactorings are provided if no particular kind is requested.
+	var wantedCodeActions ilsp.CodeActions
 	if len(params.Context.Only) == 0 {
-		h.logger.Printf("No code action requested, exiting")
-		return ca, nil
-	}
-
-	for _, o := range params.Context.Only {
-		h.logger.Printf("Code actions requested: %q", o)
-	}
+		svc.logger.Printf("No code action kind requested, providing quick fixes and refactorings")
+		wantedCodeActions = ilsp.CodeActions{
+			lsp.QuickFix:        true,
+			lsp.RefactorExtract: true,
+			lsp.RefactorRewrite: true,
+		}
+	} else {
+		for _, o := range params.Context.Only {
+			svc.logger.Printf("Code actions requested: %q", o)
+		}
 
-	wantedCodeActions := ilsp.SupportedCodeActions.Only(params.Context.Only)
-	if len(wantedCodeActions) == 0 {
-		return nil, fmt.Errorf("could not find a supported code action to execute for %s, wanted %v",
-			params.TextDocument.URI, params.Context.Only)
+		wantedCodeActions = ilsp.SupportedCodeActions.Only(params.Context.Only)
+		if len(wantedCodeActions) == 0 {
+			return nil, fmt.Errorf("could not find a supported code action to execute for %s, wanted %v",
+				params.TextDocument.URI, params.Context.Only)
+		}
 	}
 
-	h.logger.Printf("Code actions supported: %v", wantedCodeActions)
+	svc.logger.Printf("Code actions supported: %v", wantedCodeActions)
 
 	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
 
@@ -58,19 +63,13 @@ func (h *logHandler) textDocumentCodeAction(ctx context.Context, params lsp.Code
 		return ca, err
 	}
 
-	for action := range wantedCodeActions {
+	for _, action := range wantedCodeActions.AsSlice() {
 		switch action {
 		case ilsp.SourceFormatAllTerraform:
-			tfExec, err := module.TerraformExecutorForModule(ctx, fh.Dir())
+			edits, err := svc.formatDocument(ctx, original, file)
 			if err != nil {
-				return ca, errors.EnrichTfExecError(err)
-			}
-
-			h.logger.Printf("Formatting document via %q", tfExec.GetExecPath())
-
-			edits, err := formatDocument(ctx, tfExec, original, file)
-			if err != nil {
-				return ca, err
+				svc.logger.Printf("unable to format document: %s", err)
+				continue
 			}
 
 			ca = append(ca, lsp.CodeAction{
@@ -82,8 +81,51 @@ func (h *logHandler) textDocumentCodeAction(ctx context.Context, params lsp.Code
 					},
 				},
 			})
+		case lsp.QuickFix:
+			actions, err := undeclaredVariableActions(ctx, params, file)
+			if err != nil {
+				svc.logger.Printf("unable to provide undeclared variable actions: %s", err)
+			}
+			ca = append(ca, actions...)
+
+			providerActions, err := svc.requiredProvidersActions(ctx, params, file)
+			if err != nil {
+				svc.logger.Printf("unable to provide required providers actions: %s", err)
+			}
+			ca = appendCodeActions(ca, providerActions)
+		case lsp.RefactorExtract:
+			actions, err := svc.extractLocalActions(ctx, params, file)
+			if err != nil {
+				svc.logger.Printf("unable to provide extract local actions: %s", err)
+			}
+			ca = appendCodeActions(ca, actions)
+
+			actions, err = svc.extractModuleActions(ctx, params, file)
+			if err != nil {
+				svc.logger.Printf("unable to provide extract module actions: %s", err)
+			}
+			ca = appendCodeActions(ca, actions)
+		}
+	}
+
+	if wantedCodeActions[lsp.QuickFix] || wantedCodeActions[lsp.RefactorRewrite] {
+		actions, err := svc.requiredFieldsActions(ctx, params, file)
+		if err != nil {
+			svc.logger.Printf("unable to provide required fields actions: %s", err)
+		}
+		for _, action := range actions {
+			if wantedCodeActions[action.Kind] {
+				ca = append(ca, action)
+			}
 		}
 	}
 
 	return ca, nil
 }
+
+func appendCodeActions(ca []interface{}, actions []lsp.CodeAction) []interface{} {
+	for _, action := range actions {
+		ca = append(ca, action)
+	}
+	return ca
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/refactor"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+// extractLocalActions returns an action extracting the selected
+// expression into a local value, if the selection spans an expression.
+//
+// The action invokes the module.extractLocal command, so that
+// the module is only scanned for identical expressions
+// (and the local value named) once the action is chosen.
+func (svc *service) extractLocalActions(ctx context.Context, params lsp.CodeActionParams, doc filesystem.Document) ([]lsp.CodeAction, error) {
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := refactor.ExtractableExpressionAtRange(svc.modStore, doc.Dir(), rng)
+	if err != nil {
+		var noExprErr *refactor.NoExpressionFoundError
+		if errors.As(err, &noExprErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	exprRng := ilsp.HCLRangeToLSP(expr.Range)
+	args := []string{
+		"uri=" + string(params.TextDocument.URI),
+		fmt.Sprintf("start_line=%d", exprRng.Start.Line),
+		fmt.Sprintf("start_character=%d", exprRng.Start.Character),
+		fmt.Sprintf("end_line=%d", exprRng.End.Line),
+		fmt.Sprintf("end_character=%d", exprRng.End.Character),
+		"name=" + expr.Name,
+	}
+	jsonArgs, err := commandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	commandPrefix, _ := lsctx.CommandPrefix(ctx)
+	return []lsp.CodeAction{
+		{
+			Title: fmt.Sprintf("Extract to local value %q", expr.Name),
+			Kind:  lsp.RefactorExtract,
+			Command: &lsp.Command{
+				Title:     "Extract to local value",
+				Command:   cmd.PrefixedName(cmd.Name("module.extractLocal"), commandPrefix),
+				Arguments: jsonArgs,
+			},
+		},
+	}, nil
+}
+
+// extractModuleActions returns an action moving the selected resource
+// and data blocks into a new child module, if the selection spans any.
+//
+// The action invokes the module.extract command, which creates the new
+// module via resource operations of the workspace edit, or writes
+// the files itself if the client doesn't support these.
+func (svc *service) extractModuleActions(ctx context.Context, params lsp.CodeActionParams, doc filesystem.Document) ([]lsp.CodeAction, error) {
+	if params.Range.Start == params.Range.End {
+		return nil, nil
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := refactor.ExtractableBlocksInRange(mod, rng)
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+
+	name := strings.TrimPrefix(addrs[0][len(addrs[0])-1].String(), ".")
+	err = refactor.CanExtractModule(mod, addrs, name)
+	if err != nil {
+		svc.logger.Printf("unable to extract module: %s", err)
+		return nil, nil
+	}
+
+	rawAddrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		rawAddrs[i] = addr.String()
+	}
+
+	args := []string{
+		"uri=" + uri.FromPath(mod.Path),
+		"addresses=" + strings.Join(rawAddrs, ","),
+		"name=" + name,
+	}
+	jsonArgs, err := commandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	commandPrefix, _ := lsctx.CommandPrefix(ctx)
+	return []lsp.CodeAction{
+		{
+			Title: fmt.Sprintf("Extract to module %q", name),
+			Kind:  lsp.RefactorExtract,
+			Command: &lsp.Command{
+				Title:     "Extract to module",
+				Command:   cmd.PrefixedName(cmd.Name("module.extract"), commandPrefix),
+				Arguments: jsonArgs,
+			},
+		},
+	}, nil
+}
+
+func commandArgs(args []string) ([]json.RawMessage, error) {
+	jsonArgs := make([]json.RawMessage, len(args))
+	for i, arg := range args {
+		var err error
+		jsonArgs[i], err = json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jsonArgs, nil
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// requiredProvidersActions returns a quick fix declaring all providers
+// which are used in the module, but missing in required_providers,
+// if any block within the requested range uses such provider
+func (svc *service) requiredProvidersActions(ctx context.Context, params lsp.CodeActionParams, doc filesystem.Document) ([]lsp.CodeAction, error) {
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	undeclared := decoder.UndeclaredProviders(mod)
+	if !anyProviderInRange(undeclared, decoder.ProvidersInRange(mod, rng)) {
+		return nil, nil
+	}
+
+	locks, _, err := datadir.ParseDependencyLockFile(svc.fs, mod.Path)
+	if err != nil {
+		svc.logger.Printf("failed to parse dependency lock file: %s", err)
+	}
+
+	providers := make([]decoder.RequiredProvider, 0, len(undeclared))
+	for _, name := range undeclared {
+		src := svc.providerSourceForName(name, locks)
+
+		var constraints version.Constraints
+		pv, ok := mod.InstalledProviders[src]
+		if !ok || pv == nil {
+			if lock, ok := locks[src]; ok {
+				pv = lock.Version
+			}
+		}
+		if pv != nil {
+			constraints = pessimisticConstraint(pv)
+		}
+
+		providers = append(providers, decoder.RequiredProvider{
+			LocalName: name,
+			Source:    src,
+			Version:   constraints,
+		})
+	}
+
+	filename, edit := decoder.RequiredProvidersEdit(mod, doc.Filename(), providers)
+
+	title := fmt.Sprintf("Add %q to required_providers", undeclared[0])
+	if len(undeclared) > 1 {
+		title = fmt.Sprintf("Add %d missing providers to required_providers", len(undeclared))
+	}
+
+	return []lsp.CodeAction{
+		{
+			Title: title,
+			Kind:  lsp.QuickFix,
+			Edit: ilsp.WorkspaceEdit(map[string][]lang.TextEdit{
+				filepath.Join(mod.Path, filename): {edit},
+			}),
+		},
+	}, nil
+}
+
+// providerSourceForName returns source address of a provider
+// of the given local name as recorded in the dependency lock file,
+// or of a known schema of a provider with the same type,
+// or the implied default (hashicorp) address
+func (svc *service) providerSourceForName(name string, locks map[tfaddr.Provider]datadir.ProviderLock) tfaddr.Provider {
+	candidates := make([]tfaddr.Provider, 0)
+	for pAddr := range locks {
+		if pAddr.Type == name {
+			candidates = append(candidates, pAddr)
+		}
+	}
+	if len(candidates) > 0 {
+		return preferredProvider(candidates)
+	}
+
+	si, err := svc.schemaStore.ListSchemas()
+	if err == nil {
+		for ps := si.Next(); ps != nil; ps = si.Next() {
+			if ps.Address.Type == name && !ps.Address.IsLegacy() && !ps.Address.IsBuiltIn() {
+				candidates = append(candidates, ps.Address)
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return preferredProvider(candidates)
+	}
+
+	return tfaddr.NewDefaultProvider(name)
+}
+
+// preferredProvider picks the default (hashicorp) provider
+// if present, or otherwise the first one by address
+func preferredProvider(candidates []tfaddr.Provider) tfaddr.Provider {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LessThan(candidates[j])
+	})
+	for _, pAddr := range candidates {
+		if pAddr.IsDefault() {
+			return pAddr
+		}
+	}
+	return candidates[0]
+}
+
+// pessimisticConstraint returns a constraint allowing
+// newer minor versions of the given version (e.g. ~> 3.74)
+func pessimisticConstraint(v *version.Version) version.Constraints {
+	segments := v.Segments()
+	c, err := version.NewConstraint(fmt.Sprintf("~> %d.%d", segments[0], segments[1]))
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+func anyProviderInRange(undeclared, inRange []string) bool {
+	for _, name := range inRange {
+		for _, undeclaredName := range undeclared {
+			if name == undeclaredName {
+				return true
+			}
+		}
+	}
+	return false
+}
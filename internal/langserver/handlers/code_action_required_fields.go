@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// requiredFieldsActions returns actions filling in required attributes
+// and blocks which are missing in blocks within the requested range.
+//
+// Actions attached to diagnostics reported by the client are quick fixes,
+// others are rewrites.
+func (svc *service) requiredFieldsActions(ctx context.Context, params lsp.CodeActionParams, doc filesystem.Document) ([]lsp.CodeAction, error) {
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := decoder.BlocksMissingRequiredFields(mod, svc.schemaStore, svc.modStore, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]lsp.CodeAction, 0)
+	for _, block := range blocks {
+		diags := diagnosticsInRange(params.Context.Diagnostics, ilsp.HCLRangeToLSP(block.DefRange))
+
+		kind := lsp.RefactorRewrite
+		if len(diags) > 0 {
+			kind = lsp.QuickFix
+		}
+
+		actions = append(actions, lsp.CodeAction{
+			Title:       fmt.Sprintf("Fill in required fields of %s", block.Address),
+			Kind:        kind,
+			Diagnostics: diags,
+			Edit: ilsp.WorkspaceEdit(map[string][]lang.TextEdit{
+				filepath.Join(mod.Path, doc.Filename()): {block.Edit},
+			}),
+		})
+	}
+
+	return actions, nil
+}
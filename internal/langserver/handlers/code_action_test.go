@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
 	"github.com/hashicorp/terraform-ls/internal/langserver/session"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/stretchr/testify/mock"
@@ -336,3 +340,778 @@ func TestLangServer_codeAction_no_code_action_requested(t *testing.T) {
 		})
 	}
 }
+
+func TestLangServer_codeAction_declareVariable(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "variables.tf"),
+		[]byte("variable \"existing\" {}"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "terraform.tfvars"),
+		[]byte("zones = [\"a\", \"b\"]\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"zones\" {\n  value = var.zones\n}\n\noutput \"existing\" {\n  value = var.existing\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 6, "character": 0 }
+			},
+			"context": {
+				"diagnostics": [
+					{
+						"range": {
+							"start": { "line": 1, "character": 10 },
+							"end": { "line": 1, "character": 19 }
+						},
+						"severity": 1,
+						"source": "reference validation",
+						"message": "Reference to undeclared input variable: An input variable with the name \"zones\" has not been declared."
+					}
+				]
+			}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Declare variable \"zones\"",
+					"kind": "quickfix",
+					"diagnostics": [
+						{
+							"range": {
+								"start": { "line": 1, "character": 10 },
+								"end": { "line": 1, "character": 19 }
+							},
+							"severity": 1,
+							"source": "reference validation",
+							"message": "Reference to undeclared input variable: An input variable with the name \"zones\" has not been declared."
+						}
+					],
+					"isPreferred": true,
+					"edit": {
+						"changes": {
+							"%s/variables.tf": [
+								{
+									"range": {
+										"start": { "line": 0, "character": 22 },
+										"end": { "line": 0, "character": 22 }
+									},
+									"newText": "\n\nvariable \"zones\" {\n  type = list(string)\n}\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_declareVariableCreateFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"workspace": {
+				"workspaceEdit": {
+					"documentChanges": true,
+					"resourceOperations": ["create"]
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"zones\" {\n  value = var.zones\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 3, "character": 0 }
+			},
+			"context": { "diagnostics": [], "only": ["quickfix"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Declare variable \"zones\"",
+					"kind": "quickfix",
+					"isPreferred": true,
+					"edit": {
+						"documentChanges": [
+							{
+								"kind": "create",
+								"uri": "%s/variables.tf",
+								"options": {}
+							},
+							{
+								"textDocument": {
+									"version": null,
+									"uri": "%s/variables.tf"
+								},
+								"edits": [
+									{
+										"range": {
+											"start": { "line": 0, "character": 0 },
+											"end": { "line": 0, "character": 0 }
+										},
+										"newText": "variable \"zones\" {}\n"
+									}
+								]
+							}
+						]
+					}
+				}
+			]
+		}`, tmpDir.URI(), tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_declareVariableOpenFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "variables.tf"),
+		[]byte("variable \"existing\" {}"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	// unsaved content of the variables file
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"existing\" {}\n\nvariable \"another\" {}\n",
+			"uri": "%s/variables.tf"
+		}
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"zones\" {\n  value = var.zones\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 3, "character": 0 }
+			},
+			"context": { "diagnostics": [], "only": ["quickfix"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": [
+				{
+					"title": "Declare variable \"zones\"",
+					"kind": "quickfix",
+					"isPreferred": true,
+					"edit": {
+						"changes": {
+							"%s/variables.tf": [
+								{
+									"range": {
+										"start": { "line": 3, "character": 0 },
+										"end": { "line": 3, "character": 0 }
+									},
+									"newText": "\nvariable \"zones\" {}\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_requiredProviders(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "versions.tf"),
+		[]byte("terraform {\n  required_version = \">= 1.0\"\n}\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"name\" {}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 0, "character": 5 }
+			},
+			"context": { "diagnostics": [], "only": ["quickfix"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Add \"random\" to required_providers",
+					"kind": "quickfix",
+					"edit": {
+						"changes": {
+							"%s/versions.tf": [
+								{
+									"range": {
+										"start": { "line": 2, "character": 0 },
+										"end": { "line": 2, "character": 0 }
+									},
+									"newText": "  required_providers {\n    random = {\n      source = \"hashicorp/random\"\n    }\n  }\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_requiredFields(t *testing.T) {
+	tmpDir := TempDir(t, "child")
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir():                         validTfMockCalls(),
+				filepath.Join(tmpDir.Dir(), "child"): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/child/variables.tf"
+		}
+	}`, `variable "name" {}
+
+variable "size" {
+  type = number
+}
+
+variable "optional" {
+  default = 1
+}
+`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "module \"child\" {\n  source = \"./child\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+	// TODO remove once we support synchronous dependent tasks
+	// See https://github.com/hashicorp/terraform-ls/issues/719
+	time.Sleep(2 * time.Second)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 0, "character": 0 }
+			},
+			"context": { "diagnostics": [] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": [
+				{
+					"title": "Fill in required fields of module.child",
+					"kind": "refactor.rewrite",
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 2, "character": 0 },
+										"end": { "line": 2, "character": 0 }
+									},
+									"newText": "  name = null\n  size = 0\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_extractLocal(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, `resource "aws_instance" "a" {
+  ami = "ami-${var.region}"
+}
+
+resource "aws_instance" "b" {
+  ami = "ami-${var.region}"
+}
+`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 5, "character": 8 },
+				"end": { "line": 5, "character": 27 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.extract"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Extract to local value \"ami\"",
+					"kind": "refactor.extract",
+					"edit": {},
+					"command": {
+						"title": "Extract to local value",
+						"command": %q,
+						"arguments": [
+							"uri=%s/main.tf",
+							"start_line=5",
+							"start_character=8",
+							"end_line=5",
+							"end_character=27",
+							"name=ami"
+						]
+					}
+				}
+			]
+		}`, cmd.Name("module.extractLocal"), tmpDir.URI()))
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+			"command": %q,
+			"arguments": [
+				"uri=%s/main.tf",
+				"start_line=5",
+				"start_character=8",
+				"end_line=5",
+				"end_character=27",
+				"name=ami"
+			]
+		}`, cmd.Name("module.extractLocal"), tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": {
+				"v": 0,
+				"name": "ami",
+				"applied": false,
+				"edit": {
+					"changes": {
+						"%s/main.tf": [
+							{
+								"range": {
+									"start": { "line": 1, "character": 8 },
+									"end": { "line": 1, "character": 27 }
+								},
+								"newText": "local.ami"
+							},
+							{
+								"range": {
+									"start": { "line": 4, "character": 0 },
+									"end": { "line": 4, "character": 0 }
+								},
+								"newText": "locals {\n  ami = \"ami-${var.region}\"\n}\n\n"
+							},
+							{
+								"range": {
+									"start": { "line": 5, "character": 8 },
+									"end": { "line": 5, "character": 27 }
+								},
+								"newText": "local.ami"
+							}
+						]
+					}
+				}
+			}
+		}`, tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_extractLocalExistingBlock(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "locals.tf"),
+		[]byte("locals {\n  ami = \"ami-default\"\n}\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, `resource "aws_instance" "a" {
+  ami = lookup(var.amis, var.region)
+  count = length(var.zones)
+}
+`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 1, "character": 7 },
+				"end": { "line": 1, "character": 36 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.extract"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Extract to local value \"ami_2\"",
+					"kind": "refactor.extract",
+					"edit": {},
+					"command": {
+						"title": "Extract to local value",
+						"command": %q,
+						"arguments": [
+							"uri=%s/main.tf",
+							"start_line=1",
+							"start_character=8",
+							"end_line=1",
+							"end_character=36",
+							"name=ami_2"
+						]
+					}
+				}
+			]
+		}`, cmd.Name("module.extractLocal"), tmpDir.URI()))
+
+	// name provided by the user
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+			"command": %q,
+			"arguments": [
+				"uri=%s/main.tf",
+				"start_line=1",
+				"start_character=8",
+				"end_line=1",
+				"end_character=36",
+				"name=regional_ami"
+			]
+		}`, cmd.Name("module.extractLocal"), tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": {
+				"v": 0,
+				"name": "regional_ami",
+				"applied": false,
+				"edit": {
+					"changes": {
+						"%s/locals.tf": [
+							{
+								"range": {
+									"start": { "line": 2, "character": 0 },
+									"end": { "line": 2, "character": 0 }
+								},
+								"newText": "  regional_ami = lookup(var.amis, var.region)\n"
+							}
+						],
+						"%s/main.tf": [
+							{
+								"range": {
+									"start": { "line": 1, "character": 8 },
+									"end": { "line": 1, "character": 36 }
+								},
+								"newText": "local.regional_ami"
+							}
+						]
+					}
+				}
+			}
+		}`, tmpDir.URI(), tmpDir.URI()))
+}
+
+func TestLangServer_codeAction_extractModule(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, `resource "aws_vpc" "main" {
+}
+
+resource "aws_subnet" "main" {
+  vpc_id = aws_vpc.main.id
+}
+`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 5, "character": 1 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.extract"] }
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"title": "Extract to module \"main\"",
+					"kind": "refactor.extract",
+					"edit": {},
+					"command": {
+						"title": "Extract to module",
+						"command": %q,
+						"arguments": [
+							"uri=%s",
+							"addresses=aws_vpc.main,aws_subnet.main",
+							"name=main"
+						]
+					}
+				}
+			]
+		}`, cmd.Name("module.extract"), tmpDir.URI()))
+}
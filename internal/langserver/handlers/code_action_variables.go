@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"unicode/utf16"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const defaultVariablesFileName = "variables.tf"
+
+// undeclaredVariableActions returns quick fixes declaring input variables
+// which are referenced within the requested range, but not declared
+//
+// Declarations go into the variables file, which is created
+// if it doesn't exist and the client supports file creation
+// via workspace edits. Otherwise they go into the current file.
+func undeclaredVariableActions(ctx context.Context, params lsp.CodeActionParams, doc filesystem.Document) ([]interface{}, error) {
+	mf, err := lsctx.ModuleFinder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mod, err := mf.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := decoder.UndeclaredVariables(mod, rng)
+	if len(vars) == 0 {
+		return nil, nil
+	}
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetFile, ok := lsctx.VariablesFileName(ctx)
+	if !ok {
+		targetFile = defaultVariablesFileName
+	}
+	targetPath := filepath.Join(mod.Path, targetFile)
+
+	createFile := false
+	src, exists, err := variablesFileText(ctx, mod, targetPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if ilsp.ClientSupportsFileCreation(cc) {
+			createFile = true
+		} else {
+			targetPath = doc.FullPath()
+			src, err = doc.Text()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	prefix := ""
+	if len(src) > 0 {
+		prefix = "\n"
+		if !bytes.HasSuffix(src, []byte("\n")) {
+			prefix = "\n\n"
+		}
+	}
+	eofPos := endOfFilePos(src)
+	targetURI := uri.FromPath(targetPath)
+
+	actions := make([]interface{}, 0)
+	declared := make(map[string]bool, 0)
+	for _, v := range vars {
+		if declared[v.Name] {
+			continue
+		}
+		declared[v.Name] = true
+
+		action := lsp.CodeAction{
+			Title:       fmt.Sprintf("Declare variable %q", v.Name),
+			Kind:        lsp.QuickFix,
+			Diagnostics: diagnosticsInRange(params.Context.Diagnostics, ilsp.HCLRangeToLSP(v.Range)),
+			IsPreferred: true,
+		}
+		declaration := prefix + decoder.VariableDeclaration(v.Name, v.Type)
+
+		if createFile {
+			actions = append(actions, lsp.ResourceOpsCodeAction{
+				CodeAction: action,
+				Edit: ilsp.WorkspaceEditWithNewFiles(map[string][]byte{
+					targetPath: []byte(declaration),
+				}, nil),
+			})
+			continue
+		}
+
+		action.Edit = lsp.WorkspaceEdit{
+			Changes: map[string][]lsp.TextEdit{
+				targetURI: {
+					{
+						Range: lsp.Range{
+							Start: eofPos,
+							End:   eofPos,
+						},
+						NewText: declaration,
+					},
+				},
+			},
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// variablesFileText returns the text of the given variables file,
+// preferring the (possibly unsaved) content of an open document
+func variablesFileText(ctx context.Context, mod *state.Module, path string) ([]byte, bool, error) {
+	ds, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	doc, err := ds.GetDocument(ilsp.FileHandlerFromPath(path))
+	if err == nil {
+		src, err := doc.Text()
+		if err != nil {
+			return nil, false, err
+		}
+		return src, true, nil
+	}
+
+	if f, ok := mod.ParsedModuleFiles[ast.ModFilename(filepath.Base(path))]; ok {
+		return f.Bytes, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// diagnosticsInRange returns diagnostics sent by the client
+// which were reported for the given range
+func diagnosticsInRange(diags []lsp.Diagnostic, rng lsp.Range) []lsp.Diagnostic {
+	matching := make([]lsp.Diagnostic, 0)
+	for _, diag := range diags {
+		if diag.Range == rng {
+			matching = append(matching, diag)
+		}
+	}
+	return matching
+}
+
+func endOfFilePos(src []byte) lsp.Position {
+	line := bytes.Count(src, []byte("\n"))
+	lastLine := src[bytes.LastIndexByte(src, '\n')+1:]
+
+	return lsp.Position{
+		Line:      uint32(line),
+		Character: uint32(len(utf16.Encode([]rune(string(lastLine))))),
+	}
+}
@@ -0,0 +1,254 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/code"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/refactor"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const moduleExtractVersion = 0
+
+type moduleExtractResponse struct {
+	FormatVersion int         `json:"v"`
+	ModuleURI     string      `json:"module_uri"`
+	Applied       bool        `json:"applied"`
+	Edit          interface{} `json:"edit"`
+}
+
+func ModuleExtractHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := moduleExtractResponse{
+		FormatVersion: moduleExtractVersion,
+	}
+
+	modUri, ok := args.GetString("uri")
+	if !ok || modUri == "" {
+		return response, fmt.Errorf("%w: expected module uri argument to be set", code.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(modUri) {
+		return response, fmt.Errorf("URI %q is not valid", modUri)
+	}
+
+	modPath, err := uri.PathFromURI(modUri)
+	if err != nil {
+		return response, err
+	}
+
+	rawAddrs, ok := args.GetString("addresses")
+	if !ok || rawAddrs == "" {
+		return response, fmt.Errorf("%w: expected addresses argument to be set", code.InvalidParams.Err())
+	}
+	addrs, err := parseAddresses(rawAddrs)
+	if err != nil {
+		return response, fmt.Errorf("%w: %s", code.InvalidParams.Err(), err)
+	}
+
+	name, ok := args.GetString("name")
+	if !ok || name == "" {
+		name = addrs[0][len(addrs[0])-1].String()
+		name = strings.TrimPrefix(name, ".")
+	}
+
+	modMgr, err := lsctx.ModuleManager(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	mod, err := modMgr.ModuleByPath(modPath)
+	if err != nil {
+		return response, err
+	}
+
+	extraction, err := refactor.ExtractModule((*state.Module)(mod), addrs, name)
+	if err != nil {
+		return response, err
+	}
+
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	_, err = fs.Stat(extraction.ModulePath)
+	if err == nil {
+		return response, fmt.Errorf("%q already exists", extraction.ModulePath)
+	}
+	if !os.IsNotExist(err) {
+		return response, err
+	}
+
+	response.ModuleURI = uri.FromPath(extraction.ModulePath)
+	label := fmt.Sprintf("Extract module %q", name)
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	if ilsp.ClientSupportsFileCreation(cc) {
+		// The client creates the new module as part of the same edit,
+		// so nothing is left behind if the edit is not applied.
+		edit := ilsp.WorkspaceEditWithNewFiles(extraction.Files, extraction.Edits)
+		response.Edit = edit
+
+		if !cc.Workspace.ApplyEdit {
+			// the client is expected to apply the returned edit
+			return response, nil
+		}
+
+		response.Applied, err = applyEdit(ctx, lsp.ResourceOpsApplyWorkspaceEditParams{
+			Label: label,
+			Edit:  edit,
+		})
+		return response, err
+	}
+
+	if !cc.Workspace.ApplyEdit {
+		return response, fmt.Errorf("%w: client supports neither creating files via workspace edits, nor workspace/applyEdit",
+			code.InvalidRequest.Err())
+	}
+
+	// The client cannot create files, so we write them ourselves
+	// and remove them again if the client doesn't apply the edit.
+	createdDir, err := writeExtractedModule(fs, extraction)
+	if err != nil {
+		return response, err
+	}
+
+	err = loadExtractedModule(modMgr, extraction.ModulePath)
+	if err != nil {
+		return response, removeExtractedModule(fs, modMgr, extraction.ModulePath, createdDir, err)
+	}
+
+	edit := ilsp.WorkspaceEdit(extraction.Edits)
+	response.Edit = edit
+
+	response.Applied, err = applyEdit(ctx, lsp.ApplyWorkspaceEditParams{
+		Label: label,
+		Edit:  edit,
+	})
+	if err != nil {
+		return response, removeExtractedModule(fs, modMgr, extraction.ModulePath, createdDir, err)
+	}
+	if !response.Applied {
+		return response, removeExtractedModule(fs, modMgr, extraction.ModulePath, createdDir, nil)
+	}
+
+	return response, nil
+}
+
+func applyEdit(ctx context.Context, params interface{}) (bool, error) {
+	rsp, err := jrpc2.ServerFromContext(ctx).Callback(ctx, "workspace/applyEdit", params)
+	if err != nil {
+		return false, err
+	}
+	var applyRsp lsp.ApplyWorkspaceEditResponse
+	err = rsp.UnmarshalResult(&applyRsp)
+	if err != nil {
+		return false, err
+	}
+	return applyRsp.Applied, nil
+}
+
+// writeExtractedModule writes files of the new module and returns
+// the top-most directory which had to be created for it
+func writeExtractedModule(fs filesystem.Filesystem, extraction *refactor.ModuleExtraction) (string, error) {
+	createdDir, err := fs.CreateDirAll(extraction.ModulePath, 0755)
+	if err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("%q already exists", extraction.ModulePath)
+		}
+		return "", err
+	}
+
+	for path, content := range extraction.Files {
+		err = fs.WriteFile(path, content, 0644)
+		if err != nil {
+			fs.RemoveAll(createdDir)
+			return "", err
+		}
+	}
+
+	return createdDir, nil
+}
+
+// removeExtractedModule removes the module written by writeExtractedModule
+// and returns the original error (if any) or error from the removal
+func removeExtractedModule(fs filesystem.Filesystem, modMgr module.ModuleManager, modPath, createdDir string, origErr error) error {
+	var result *multierror.Error
+	if origErr != nil {
+		result = multierror.Append(result, origErr)
+	}
+
+	err := fs.RemoveAll(createdDir)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	err = modMgr.RemoveModule(modPath)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// parseAddresses parses comma-separated addresses
+// of resources and data sources, e.g. aws_instance.web,data.aws_ami.ubuntu
+func parseAddresses(rawAddrs string) ([]lang.Address, error) {
+	addrs := make([]lang.Address, 0)
+	for _, rawAddr := range strings.Split(rawAddrs, ",") {
+		rawAddr = strings.TrimSpace(rawAddr)
+		traversal, diags := hclsyntax.ParseTraversalAbs([]byte(rawAddr), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid address %q: %s", rawAddr, diags)
+		}
+		addr, err := lang.TraversalToAddress(traversal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %s", rawAddr, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func loadExtractedModule(modMgr module.ModuleManager, modPath string) error {
+	_, err := modMgr.AddModule(modPath)
+	if err != nil {
+		return err
+	}
+
+	opTypes := []op.OpType{
+		op.OpTypeParseModuleConfiguration,
+		op.OpTypeParseVariables,
+		op.OpTypeLoadModuleMetadata,
+		op.OpTypeDecodeReferenceTargets,
+		op.OpTypeDecodeReferenceOrigins,
+	}
+	for _, opType := range opTypes {
+		err = modMgr.EnqueueModuleOp(modPath, opType, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
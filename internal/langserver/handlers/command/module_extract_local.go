@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/creachadair/jrpc2/code"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/refactor"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const moduleExtractLocalVersion = 0
+
+type moduleExtractLocalResponse struct {
+	FormatVersion int               `json:"v"`
+	Name          string            `json:"name"`
+	Applied       bool              `json:"applied"`
+	Edit          lsp.WorkspaceEdit `json:"edit"`
+}
+
+func ModuleExtractLocalHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := moduleExtractLocalResponse{
+		FormatVersion: moduleExtractLocalVersion,
+	}
+
+	docUri, ok := args.GetString("uri")
+	if !ok || docUri == "" {
+		return response, fmt.Errorf("%w: expected document uri argument to be set", code.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(docUri) {
+		return response, fmt.Errorf("URI %q is not valid", docUri)
+	}
+
+	lspRange, err := rangeFromArgs(args)
+	if err != nil {
+		return response, fmt.Errorf("%w: %s", code.InvalidParams.Err(), err)
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(lsp.DocumentURI(docUri)))
+	if err != nil {
+		return response, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(lspRange, doc)
+	if err != nil {
+		return response, err
+	}
+
+	mf, err := lsctx.ModuleFinder(ctx)
+	if err != nil {
+		return response, err
+	}
+	mr := &moduleReader{mf}
+
+	expr, err := refactor.ExtractableExpressionAtRange(mr, doc.Dir(), rng)
+	if err != nil {
+		return response, err
+	}
+
+	name, ok := args.GetString("name")
+	if !ok || name == "" {
+		name = expr.Name
+	}
+	response.Name = name
+
+	edits, err := refactor.ExtractLocal(mr, expr, name)
+	if err != nil {
+		return response, err
+	}
+	response.Edit = ilsp.WorkspaceEdit(edits)
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return response, err
+	}
+	if !cc.Workspace.ApplyEdit {
+		// the client is expected to apply the returned edit
+		return response, nil
+	}
+
+	response.Applied, err = applyEdit(ctx, lsp.ApplyWorkspaceEditParams{
+		Label: fmt.Sprintf("Extract to local value %q", name),
+		Edit:  response.Edit,
+	})
+	return response, err
+}
+
+// rangeFromArgs returns the range of the expression passed
+// as start_line, start_character, end_line and end_character
+func rangeFromArgs(args cmd.CommandArgs) (lsp.Range, error) {
+	names := []string{"start_line", "start_character", "end_line", "end_character"}
+	values := make([]uint32, len(names))
+	for i, name := range names {
+		v, ok := args.GetNumber(name)
+		if !ok || v < 0 {
+			return lsp.Range{}, fmt.Errorf("expected %s argument to be set", name)
+		}
+		values[i] = uint32(v)
+	}
+
+	return lsp.Range{
+		Start: lsp.Position{Line: values[0], Character: values[1]},
+		End:   lsp.Position{Line: values[2], Character: values[3]},
+	}, nil
+}
+
+// moduleReader adapts module.ModuleFinder to refactor.ModuleReader
+type moduleReader struct {
+	mf module.ModuleFinder
+}
+
+func (mr *moduleReader) ModuleByPath(modPath string) (*state.Module, error) {
+	mod, err := mr.mf.ModuleByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+	return (*state.Module)(mod), nil
+}
+
+func (mr *moduleReader) CallersOfModule(modPath string) ([]*state.Module, error) {
+	callers, err := mr.mf.CallersOfModule(modPath)
+	if err != nil {
+		return nil, err
+	}
+	mods := make([]*state.Module, len(callers))
+	for i, caller := range callers {
+		mods[i] = (*state.Module)(caller)
+	}
+	return mods, nil
+}
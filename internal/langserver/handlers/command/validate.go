@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/creachadair/jrpc2/code"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
@@ -55,6 +57,15 @@ func TerraformValidateHandler(ctx context.Context, args cmd.CommandArgs) (interf
 		return nil, err
 	}
 
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isOpen := func(name ast.VarsFilename) bool {
+		_, err := fs.GetDocument(ilsp.FileHandlerFromPath(filepath.Join(mod.Path, name.String())))
+		return err == nil
+	}
+
 	progress.Begin(ctx, "Validating")
 	defer func() {
 		progress.End(ctx, "Finished")
@@ -70,7 +81,11 @@ func TerraformValidateHandler(ctx context.Context, args cmd.CommandArgs) (interf
 	diags.EmptyRootDiagnostic()
 	diags.Append("terraform validate", validateDiags)
 	diags.Append("HCL", mod.ModuleDiagnostics.AsMap())
-	diags.Append("HCL", mod.VarsDiagnostics.AutoloadedOnly().AsMap())
+	diags.Append("HCL", mod.VarsDiagnostics.AutoloadedOrOpen(isOpen).AsMap())
+	diags.Append("schema validation", mod.SchemaValidationDiagnostics.AsMap())
+	diags.Append("reference validation", mod.ReferenceValidationDiagnostics.AsMap())
+	diags.AppendUnnecessary("reference validation", mod.UnusedDeclarationDiagnostics.AsMap())
+	diags.Append("variables validation", mod.VarsValidationDiagnostics.AutoloadedOrOpen(isOpen).AsMap())
 
 	notifier.PublishHCLDiags(ctx, mod.Path, diags)
 
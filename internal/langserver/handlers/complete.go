@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"strings"
 
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/functions"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -46,5 +48,25 @@ func (svc *service) TextDocumentComplete(ctx context.Context, params lsp.Complet
 	svc.logger.Printf("Looking for candidates at %q -> %#v", doc.Filename(), fPos.Position())
 	candidates, err := d.CandidatesAtPos(doc.Filename(), fPos.Position())
 	svc.logger.Printf("received candidates: %#v", candidates)
-	return ilsp.ToCompletionList(candidates, cc.TextDocument), err
+	if err != nil {
+		return list, err
+	}
+
+	list = ilsp.ToCompletionList(candidates, cc.TextDocument)
+
+	text, err := doc.Text()
+	if err != nil {
+		return list, err
+	}
+	prefix, rng, ok := functions.NamePrefixAtPos(text, doc.Filename(), fPos.Position())
+	if ok && prefix != "" {
+		sigs := svc.functionsForDocument(doc)
+		for _, name := range functions.SortedNames(sigs) {
+			if strings.HasPrefix(name, prefix) {
+				list.Items = append(list.Items, ilsp.FunctionCompletionItem(sigs[name], rng, cc.TextDocument.Completion))
+			}
+		}
+	}
+
+	return list, nil
 }
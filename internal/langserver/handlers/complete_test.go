@@ -944,3 +944,72 @@ func writeContentToFile(t *testing.T, path string, content string) {
 		t.Fatal(err)
 	}
 }
+
+func TestCompletion_functions(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  subnet = cidrsub\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	// cidrsubnets is not available in Terraform 0.12.0
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/completion",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 18,
+				"line": 1
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"isIncomplete": false,
+				"items": [
+					{
+						"label": "cidrsubnet",
+						"labelDetails": {},
+						"kind": 3,
+						"detail": "cidrsubnet(prefix string, newbits number, netnum number) string",
+						"documentation": "Calculates a subnet address within given IP network address prefix.",
+						"insertTextFormat": 1,
+						"textEdit": {
+							"range": {"start":{"line":1,"character":11}, "end":{"line":1,"character":18}},
+							"newText": "cidrsubnet()"
+						}
+					}
+				]
+			}
+		}`)
+}
@@ -7,6 +7,7 @@ import (
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
 
@@ -59,30 +60,21 @@ func TextDocumentDidChange(ctx context.Context, params lsp.DidChangeTextDocument
 		return err
 	}
 
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeParseModuleConfiguration, nil)
-	if err != nil {
-		return err
-	}
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeParseVariables, nil)
-	if err != nil {
-		return err
-	}
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeLoadModuleMetadata, nil)
-	if err != nil {
-		return err
-	}
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeDecodeReferenceTargets, nil)
-	if err != nil {
-		return err
-	}
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeDecodeReferenceOrigins, nil)
-	if err != nil {
-		return err
-	}
-	err = modMgr.EnqueueModuleOp(mod.Path, op.OpTypeDecodeVarsReferences, nil)
-	if err != nil {
-		return err
-	}
+	return enqueueModuleDocumentOps(modMgr, mod.Path)
+}
 
-	return nil
+// enqueueModuleDocumentOps enqueues operations (re)loading the module
+// with open documents, where validation only runs once the module
+// is parsed and decoded
+func enqueueModuleDocumentOps(modMgr module.ModuleManager, modPath string) error {
+	return modMgr.EnqueueModuleOpsInSequence(modPath,
+		op.OpTypeParseModuleConfiguration,
+		op.OpTypeParseVariables,
+		op.OpTypeLoadModuleMetadata,
+		op.OpTypeDecodeReferenceTargets,
+		op.OpTypeDecodeReferenceOrigins,
+		op.OpTypeDecodeVarsReferences,
+		op.OpTypeValidateReferences,
+		op.OpTypeValidateVariables,
+		op.OpTypeValidateModuleSchema)
 }
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/creachadair/jrpc2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+// configurationSection represents the section of client's configuration
+// which contains the same options as initializationOptions
+const configurationSection = "terraform-ls"
+
+func (svc *service) DidChangeConfiguration(ctx context.Context, params lsp.DidChangeConfigurationParams) error {
+	rawOpts := settingsFromSection(params.Settings)
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if cc.Workspace.Configuration {
+		results, err := svc.pullConfiguration(ctx, []lsp.ConfigurationItem{
+			{Section: configurationSection},
+		})
+		if err != nil {
+			return err
+		}
+		rawOpts = nil
+		if len(results) > 0 {
+			rawOpts = results[0]
+		}
+	}
+
+	if rawOpts == nil {
+		svc.logger.Printf("no configuration received, keeping current options")
+		return nil
+	}
+
+	out, err := settings.DecodeOptions(rawOpts)
+	if err != nil {
+		return showConfigurationError(ctx, err)
+	}
+	err = out.Options.Validate()
+	if err != nil {
+		return showConfigurationError(ctx, err)
+	}
+	if len(out.UnusedKeys) > 0 {
+		jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+			Type:    lsp.Warning,
+			Message: fmt.Sprintf("Unknown configuration options: %q", out.UnusedKeys),
+		})
+	}
+	cfgOpts := out.Options
+
+	execOpts, err := svc.executorOpts(cfgOpts)
+	if err != nil {
+		return showConfigurationError(ctx, err)
+	}
+	// executor options are shared with the module manager
+	// via session context, so they're replaced as a whole
+	// while retaining paths set for workspace folders
+	svc.tfExecOpts.Update(func(opts *exec.ExecutorOpts) {
+		folderExecPaths := opts.FolderExecPaths
+		*opts = *execOpts
+		opts.FolderExecPaths = folderExecPaths
+	})
+
+	err = lsctx.SetExperimentalFeatures(ctx, cfgOpts.ExperimentalFeatures)
+	if err != nil {
+		return err
+	}
+
+	// Walk again to discover any modules which are no longer excluded,
+	// but only if the options affecting the walker have changed
+	rewalk := false
+	if prevOpts := svc.configOptions(); prevOpts != nil {
+		rewalk = !stringsEqual(cfgOpts.ExcludeModulePaths, prevOpts.ExcludeModulePaths) ||
+			!stringsEqual(cfgOpts.IgnoreDirectoryNames, prevOpts.IgnoreDirectoryNames)
+
+		if cfgOpts.CommandPrefix != prevOpts.CommandPrefix ||
+			!reflect.DeepEqual(cfgOpts.ModulePaths, prevOpts.ModulePaths) {
+			jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+				Type: lsp.Warning,
+				Message: "Changes of commandPrefix and rootModulePaths " +
+					"only take effect after the server is restarted",
+			})
+		}
+		cfgOpts.CommandPrefix = prevOpts.CommandPrefix
+		cfgOpts.ModulePaths = prevOpts.ModulePaths
+	}
+
+	svc.setConfigOptions(cfgOpts)
+	svc.walker.SetIgnoreDirectoryNames(cfgOpts.IgnoreDirectoryNames)
+
+	// Settings scoped to workspace folders may have changed too
+	prevFolderOpts := svc.folderOptions()
+	err = svc.refreshFolderSettings(ctx)
+	if err != nil {
+		return err
+	}
+	for path, opts := range svc.folderOptions() {
+		prevOpts, ok := prevFolderOpts[path]
+		if !ok || !stringsEqual(opts.ExcludeModulePaths, prevOpts.ExcludeModulePaths) {
+			rewalk = true
+		}
+	}
+	err = svc.applyFolderSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	if rewalk {
+		svc.walker.Rewalk()
+	}
+
+	return svc.ensureWalking()
+}
+
+// stringsEqual reports whether both slices contain the same strings,
+// treating nil and empty slices as equal
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureWalking starts the walker, unless already walking,
+// to consume any newly enqueued paths
+func (svc *service) ensureWalking() error {
+	if svc.walker.IsWalking() {
+		return nil
+	}
+
+	// Walker runs asynchronously so we're intentionally *not*
+	// passing the request context here
+	return svc.walker.StartWalking(context.Background())
+}
+
+// evictExcludedModules removes modules which became excluded,
+// except for those with any open documents
+func (svc *service) evictExcludedModules() error {
+	modules, err := svc.modMgr.ListModules()
+	if err != nil {
+		return err
+	}
+
+	for _, mod := range modules {
+		if !svc.walker.IsPathExcluded(mod.Path) {
+			continue
+		}
+
+		hasOpenFiles, err := svc.fs.HasOpenFiles(mod.Path)
+		if err != nil {
+			svc.logger.Printf("failed to check open files of %s: %s", mod.Path, err)
+			continue
+		}
+		if hasOpenFiles {
+			continue
+		}
+
+		svc.logger.Printf("evicting excluded module %s", mod.Path)
+		err = svc.watcher.RemoveModule(mod.Path)
+		if err != nil {
+			svc.logger.Printf("failed to remove module from watcher: %s", err)
+		}
+		err = svc.modMgr.RemoveModule(mod.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pullConfiguration obtains the given configuration items via
+// workspace/configuration request, which is preferred over
+// settings pushed via workspace/didChangeConfiguration
+func (svc *service) pullConfiguration(ctx context.Context, items []lsp.ConfigurationItem) ([]interface{}, error) {
+	rsp, err := svc.server.Callback(ctx, "workspace/configuration", lsp.ConfigurationParams{
+		Items: items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	err = rsp.UnmarshalResult(&results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// settingsFromSection returns pushed settings of the configuration
+// section if present, or otherwise the settings as they are
+func settingsFromSection(rawSettings interface{}) interface{} {
+	m, ok := rawSettings.(map[string]interface{})
+	if !ok {
+		return rawSettings
+	}
+	if section, ok := m[configurationSection]; ok {
+		return section
+	}
+	return rawSettings
+}
+
+func showConfigurationError(ctx context.Context, err error) error {
+	return jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+		Type:    lsp.Error,
+		Message: fmt.Sprintf("Invalid configuration, keeping previous options: %s", err),
+	})
+}
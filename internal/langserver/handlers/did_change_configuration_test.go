@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDidChangeConfiguration_excludeModulePaths(t *testing.T) {
+	tmpDir := TempDir(t, "first", "second")
+	firstDir := filepath.Join(tmpDir.Dir(), "first")
+	secondDir := filepath.Join(tmpDir.Dir(), "second")
+
+	InitPluginCache(t, firstDir)
+	InitPluginCache(t, secondDir)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				firstDir:  repeatableTfMockCalls(),
+				secondDir: repeatableTfMockCalls(),
+			},
+		},
+		StateStore: ss,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	expectModulePaths(t, ss, firstDir, secondDir)
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeConfiguration",
+		ReqParams: fmt.Sprintf(`{
+		"settings": {
+			"excludeModulePaths": [%q]
+		}
+	}`, secondDir)})
+
+	expectModulePaths(t, ss, firstDir)
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeConfiguration",
+		ReqParams: `{
+		"settings": {
+			"terraform-ls": {
+				"excludeModulePaths": []
+			}
+		}
+	}`})
+
+	expectModulePaths(t, ss, firstDir, secondDir)
+}
+
+func TestDidChangeConfiguration_invalid(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	// invalid options are reported to the user rather than failing
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeConfiguration",
+		ReqParams: `{
+		"settings": {
+			"ignoreDirectoryNames": [".terraform"]
+		}
+	}`})
+}
+
+func expectModulePaths(t *testing.T, ss *state.StateStore, expectedPaths ...string) {
+	t.Helper()
+
+	modules, err := ss.Modules.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make(map[string]bool, len(modules))
+	for _, mod := range modules {
+		paths[mod.Path] = true
+	}
+
+	if len(paths) != len(expectedPaths) {
+		t.Fatalf("expected %d modules (%q), %d given: %v",
+			len(expectedPaths), expectedPaths, len(paths), paths)
+	}
+	for _, path := range expectedPaths {
+		if !paths[path] {
+			t.Fatalf("expected module %q, given: %v", path, paths)
+		}
+	}
+}
+
+// repeatableTfMockCalls returns valid mock calls which
+// can be repeated, as modules get walked more than once
+func repeatableTfMockCalls() []*mock.Call {
+	calls := validTfMockCalls()
+	for _, call := range calls {
+		call.Repeatability = 0
+	}
+	return calls
+}
@@ -5,27 +5,11 @@ import (
 	"fmt"
 
 	"github.com/creachadair/jrpc2"
-	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
 
-func (lh *logHandler) DidChangeWorkspaceFolders(ctx context.Context, params lsp.DidChangeWorkspaceFoldersParams) error {
-	watcher, err := lsctx.Watcher(ctx)
-	if err != nil {
-		return err
-	}
-
-	walker, err := lsctx.ModuleWalker(ctx)
-	if err != nil {
-		return err
-	}
-
-	mm, err := lsctx.ModuleManager(ctx)
-	if err != nil {
-		return err
-	}
-
+func (svc *service) DidChangeWorkspaceFolders(ctx context.Context, params lsp.DidChangeWorkspaceFoldersParams) error {
 	for _, removed := range params.Event.Removed {
 		modPath, err := pathFromDocumentURI(removed.URI)
 		if err != nil {
@@ -36,24 +20,26 @@ func (lh *logHandler) DidChangeWorkspaceFolders(ctx context.Context, params lsp.
 			})
 			continue
 		}
-		walker.RemovePathFromQueue(modPath)
+		svc.removeWorkspaceFolder(modPath)
+		svc.walker.RemovePathFromQueue(modPath)
 
-		err = watcher.RemoveModule(modPath)
+		err = svc.watcher.RemoveModule(modPath)
 		if err != nil {
-			lh.logger.Printf("failed to remove module from watcher: %s", err)
+			svc.logger.Printf("failed to remove module from watcher: %s", err)
 			continue
 		}
 
-		callers, err := mm.CallersOfModule(modPath)
+		callers, err := svc.modMgr.CallersOfModule(modPath)
 		if err != nil {
-			lh.logger.Printf("failed to remove module from watcher: %s", err)
+			svc.logger.Printf("failed to remove module from watcher: %s", err)
 			continue
 		}
 		if len(callers) == 0 {
-			mm.RemoveModule(modPath)
+			svc.modMgr.RemoveModule(modPath)
 		}
 	}
 
+	addedPaths := make([]string, 0)
 	for _, added := range params.Event.Added {
 		modPath, err := pathFromDocumentURI(added.URI)
 		if err != nil {
@@ -64,16 +50,34 @@ func (lh *logHandler) DidChangeWorkspaceFolders(ctx context.Context, params lsp.
 			})
 			continue
 		}
-		err = watcher.AddModule(modPath)
+		svc.setWorkspaceFolder(modPath, added.URI)
+		addedPaths = append(addedPaths, modPath)
+	}
+
+	// Settings of new folders need to be known before walking
+	// them, so that their exclusions are respected
+	if len(addedPaths) > 0 {
+		err := svc.refreshFolderSettings(ctx, addedPaths...)
+		if err != nil {
+			return err
+		}
+	}
+	err := svc.applyFolderSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, modPath := range addedPaths {
+		err = svc.watcher.AddModule(modPath)
 		if err != nil {
-			lh.logger.Printf("failed to add module to watcher: %s", err)
+			svc.logger.Printf("failed to add module to watcher: %s", err)
 			continue
 		}
 
-		walker.EnqueuePath(modPath)
+		svc.walker.EnqueuePath(modPath)
 	}
 
-	return nil
+	return svc.ensureWalking()
 }
 
 func pathFromDocumentURI(docUri string) (string, error) {
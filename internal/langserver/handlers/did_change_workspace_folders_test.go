@@ -1,11 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/code"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/uri"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -51,3 +60,130 @@ func TestDidChangeWorkspaceFolders(t *testing.T) {
 		}
 	}`, rootDir.URI(), rootDir.URI())})
 }
+
+func TestDidChangeWorkspaceFolders_folderSettings(t *testing.T) {
+	rootDir := TempDir(t, "first/mod", "first/excluded", "second/excluded")
+	firstDir := filepath.Join(rootDir.Dir(), "first")
+	secondDir := filepath.Join(rootDir.Dir(), "second")
+	firstURI := uri.FromPath(firstDir)
+	secondURI := uri.FromPath(secondDir)
+
+	modPaths := []string{
+		filepath.Join(firstDir, "mod"),
+		filepath.Join(firstDir, "excluded"),
+		filepath.Join(secondDir, "excluded"),
+	}
+	tfCalls := make(map[string][]*mock.Call, 0)
+	for _, modPath := range modPaths {
+		InitPluginCache(t, modPath)
+		tfCalls[modPath] = repeatableTfMockCalls()
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: tfCalls,
+		},
+		StateStore: ss,
+	}))
+
+	var registrationsMu sync.Mutex
+	registrations := make([]lsp.Registration, 0)
+	ls.OnClientCallback(func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+		switch req.Method() {
+		case "workspace/configuration":
+			var params lsp.ConfigurationParams
+			err := req.UnmarshalParams(&params)
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, len(params.Items))
+			for i, item := range params.Items {
+				if item.ScopeURI == firstURI {
+					results[i] = map[string]interface{}{
+						"excludeModulePaths": []string{"excluded"},
+						"commandPrefix":      "first",
+					}
+					continue
+				}
+				results[i] = map[string]interface{}{}
+			}
+			return results, nil
+		case "client/registerCapability":
+			var params lsp.RegistrationParams
+			err := req.UnmarshalParams(&params)
+			if err != nil {
+				return nil, err
+			}
+			registrationsMu.Lock()
+			registrations = append(registrations, params.Registrations...)
+			registrationsMu.Unlock()
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected callback: %s", req.Method())
+	})
+
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"workspace": {
+				"configuration": true,
+				"workspaceFolders": true,
+				"executeCommand": {
+					"dynamicRegistration": true
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345,
+		"workspaceFolders": [
+			{
+				"uri": %q,
+				"name": "first"
+			}
+		]
+	}`, firstURI, firstURI)})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	expectModulePaths(t, ss, filepath.Join(firstDir, "mod"))
+
+	registrationsMu.Lock()
+	if len(registrations) != 1 || registrations[0].ID != "workspace/executeCommand/first" {
+		t.Fatalf("expected commands with folder prefix to be registered, given: %#v", registrations)
+	}
+	registrationsMu.Unlock()
+
+	// command with prefix of the folder is recognized, but lacks arguments
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q
+	}`, "first."+cmd.Name("rootmodules"))}, code.InvalidParams.Err())
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWorkspaceFolders",
+		ReqParams: fmt.Sprintf(`{
+		"event": {
+			"added": [
+				{"uri": %q, "name": "second"}
+			],
+			"removed": []
+		}
+	}`, secondURI)})
+
+	// exclusions of a folder are relative to that folder only
+	expectModulePaths(t, ss,
+		filepath.Join(firstDir, "mod"),
+		filepath.Join(secondDir, "excluded"))
+}
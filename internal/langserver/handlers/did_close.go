@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"context"
+	"path/filepath"
 
+	"github.com/hashicorp/hcl/v2"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 )
 
 func TextDocumentDidClose(ctx context.Context, params lsp.DidCloseTextDocumentParams) error {
@@ -20,5 +24,20 @@ func TextDocumentDidClose(ctx context.Context, params lsp.DidCloseTextDocumentPa
 		return err
 	}
 
+	// diagnostics of variable files which are not autoloaded
+	// are only published while the file is open
+	name, ok := ast.NewVarsFilename(filepath.Base(fh.FullPath()))
+	if ok && !name.IsAutoloaded() {
+		notifier, err := lsctx.DiagnosticsNotifier(ctx)
+		if err != nil {
+			return err
+		}
+		diags := diagnostics.NewDiagnostics()
+		diags.Append("HCL", map[string]hcl.Diagnostics{
+			name.String(): {},
+		})
+		notifier.PublishHCLDiags(ctx, fh.Dir(), diags)
+	}
+
 	return nil
 }
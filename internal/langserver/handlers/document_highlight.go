@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func (svc *service) TextDocumentHighlight(ctx context.Context, params lsp.DocumentHighlightParams) ([]lsp.DocumentHighlight, error) {
+	highlights := make([]lsp.DocumentHighlight, 0)
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return highlights, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return highlights, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return highlights, err
+	}
+
+	path := lang.Path{
+		Path:       doc.Dir(),
+		LanguageID: doc.LanguageID(),
+	}
+	pathReader := &idecoder.PathReader{
+		ModuleReader: svc.modStore,
+		SchemaReader: svc.schemaStore,
+	}
+
+	for _, h := range idecoder.ReferenceHighlights(pathReader, path, doc.Filename(), fPos.Position()) {
+		kind := lsp.Read
+		if h.Kind == idecoder.WriteHighlight {
+			kind = lsp.Write
+		}
+		highlights = append(highlights, lsp.DocumentHighlight{
+			Range: ilsp.HCLRangeToLSP(h.Range),
+			Kind:  kind,
+		})
+	}
+
+	return highlights, nil
+}
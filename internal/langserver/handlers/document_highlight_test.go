@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDocumentHighlight_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/documentHighlight",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 1
+			}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestDocumentHighlight_withValidData(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"name\" {}\nlocals {\n  first  = var.name\n  second = \"${var.name}-second\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/documentHighlight",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 16,
+				"line": 2
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 0, "character": 15}
+					},
+					"kind": 3
+				},
+				{
+					"range": {
+						"start": {"line": 2, "character": 11},
+						"end": {"line": 2, "character": 19}
+					},
+					"kind": 2
+				},
+				{
+					"range": {
+						"start": {"line": 3, "character": 14},
+						"end": {"line": 3, "character": 22}
+					},
+					"kind": 2
+				}
+			]
+		}`)
+
+	// the declaration itself
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/documentHighlight",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 12,
+				"line": 0
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": [
+				{
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 0, "character": 15}
+					},
+					"kind": 3
+				},
+				{
+					"range": {
+						"start": {"line": 2, "character": 11},
+						"end": {"line": 2, "character": 19}
+					},
+					"kind": 2
+				},
+				{
+					"range": {
+						"start": {"line": 3, "character": 14},
+						"end": {"line": 3, "character": 22}
+					},
+					"kind": 2
+				}
+			]
+		}`)
+}
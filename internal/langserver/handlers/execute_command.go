@@ -12,12 +12,14 @@ import (
 )
 
 var handlers = cmd.Handlers{
-	cmd.Name("rootmodules"):        command.ModulesHandler,
-	cmd.Name("module.callers"):     command.ModuleCallersHandler,
-	cmd.Name("terraform.init"):     command.TerraformInitHandler,
-	cmd.Name("terraform.validate"): command.TerraformValidateHandler,
-	cmd.Name("module.calls"):       command.ModuleCallsHandler,
-	cmd.Name("module.providers"):   command.ModuleProvidersHandler,
+	cmd.Name("rootmodules"):         command.ModulesHandler,
+	cmd.Name("module.callers"):      command.ModuleCallersHandler,
+	cmd.Name("terraform.init"):      command.TerraformInitHandler,
+	cmd.Name("terraform.validate"):  command.TerraformValidateHandler,
+	cmd.Name("module.calls"):        command.ModuleCallsHandler,
+	cmd.Name("module.providers"):    command.ModuleProvidersHandler,
+	cmd.Name("module.extract"):      command.ModuleExtractHandler,
+	cmd.Name("module.extractLocal"): command.ModuleExtractLocalHandler,
 }
 
 func (lh *logHandler) WorkspaceExecuteCommand(ctx context.Context, params lsp.ExecuteCommandParams) (interface{}, error) {
@@ -30,6 +32,14 @@ func (lh *logHandler) WorkspaceExecuteCommand(ctx context.Context, params lsp.Ex
 
 	commandPrefix, _ := lsctx.CommandPrefix(ctx)
 	handler, ok := handlers.Get(params.Command, commandPrefix)
+	if !ok {
+		for _, prefix := range lsctx.FolderCommandPrefixes(ctx) {
+			handler, ok = handlers.Get(params.Command, prefix)
+			if ok {
+				break
+			}
+		}
+	}
 	if !ok {
 		return nil, fmt.Errorf("%w: command handler not found for %q", code.MethodNotFound.Err(), params.Command)
 	}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/code"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_workspaceExecuteCommand_moduleExtract_argumentError(t *testing.T) {
+	rootDir := t.TempDir()
+	rootUri := uri.FromPath(rootDir)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootDir: validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, rootUri)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("module.extract"), rootUri)}, code.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_moduleExtract_basic(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"workspace": {
+				"workspaceEdit": {
+					"documentChanges": true,
+					"resourceOperations": ["create"]
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, `terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}
+
+variable "instance_count" {
+  type = number
+}
+
+resource "aws_instance" "web" {
+  count = var.instance_count
+}
+
+output "web" {
+  value = aws_instance.web
+}
+`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s", "addresses=aws_instance.web", "name=web"]
+	}`, cmd.Name("module.extract"), tmpDir.URI())}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": {
+			"v": 0,
+			"module_uri": "%[1]s/modules/web",
+			"applied": false,
+			"edit": {
+				"documentChanges": [
+					{
+						"kind": "create",
+						"uri": "%[1]s/modules/web/main.tf",
+						"options": {}
+					},
+					{
+						"textDocument": {
+							"version": null,
+							"uri": "%[1]s/modules/web/main.tf"
+						},
+						"edits": [
+							{
+								"range": {
+									"start": { "line": 0, "character": 0 },
+									"end": { "line": 0, "character": 0 }
+								},
+								"newText": "terraform {\n  required_providers {\n    aws = {\n      source = \"hashicorp/aws\"\n    }\n  }\n}\n\nresource \"aws_instance\" \"web\" {\n  count = var.instance_count\n}\n"
+							}
+						]
+					},
+					{
+						"kind": "create",
+						"uri": "%[1]s/modules/web/outputs.tf",
+						"options": {}
+					},
+					{
+						"textDocument": {
+							"version": null,
+							"uri": "%[1]s/modules/web/outputs.tf"
+						},
+						"edits": [
+							{
+								"range": {
+									"start": { "line": 0, "character": 0 },
+									"end": { "line": 0, "character": 0 }
+								},
+								"newText": "output \"aws_instance_web\" {\n  value = aws_instance.web\n}\n"
+							}
+						]
+					},
+					{
+						"kind": "create",
+						"uri": "%[1]s/modules/web/variables.tf",
+						"options": {}
+					},
+					{
+						"textDocument": {
+							"version": null,
+							"uri": "%[1]s/modules/web/variables.tf"
+						},
+						"edits": [
+							{
+								"range": {
+									"start": { "line": 0, "character": 0 },
+									"end": { "line": 0, "character": 0 }
+								},
+								"newText": "variable \"instance_count\" {\n  type = number\n}\n"
+							}
+						]
+					},
+					{
+						"textDocument": {
+							"version": null,
+							"uri": "%[1]s/main.tf"
+						},
+						"edits": [
+							{
+								"range": {
+									"start": { "line": 12, "character": 0 },
+									"end": { "line": 16, "character": 0 }
+								},
+								"newText": "module \"web\" {\n  source = \"./modules/web\"\n\n  instance_count = var.instance_count\n}\n\nmoved {\n  from = aws_instance.web\n  to   = module.web.aws_instance.web\n}\n\n"
+							},
+							{
+								"range": {
+									"start": { "line": 17, "character": 10 },
+									"end": { "line": 17, "character": 26 }
+								},
+								"newText": "module.web.aws_instance_web"
+							}
+						]
+					}
+				]
+			}
+		}
+	}`, tmpDir.URI()))
+
+	// files are created by the client as part of the edit
+	_, err := os.Stat(filepath.Join(tmpDir.Dir(), "modules"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected no files to be written, stat error: %v", err)
+	}
+}
+
+func TestLangServer_workspaceExecuteCommand_moduleExtract_writtenFiles(t *testing.T) {
+	testCases := []struct {
+		applied       bool
+		expectedFiles map[string]string
+	}{
+		{
+			applied: true,
+			expectedFiles: map[string]string{
+				"main.tf": `resource "aws_instance" "web" {
+  count = var.instance_count
+}
+`,
+				"variables.tf": `variable "instance_count" {
+  type = number
+}
+`,
+			},
+		},
+		{
+			// files are removed if the client doesn't apply the edit
+			applied:       false,
+			expectedFiles: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("applied=%t", tc.applied), func(t *testing.T) {
+			tmpDir := TempDir(t)
+			InitPluginCache(t, tmpDir.Dir())
+
+			ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+				TerraformCalls: &exec.TerraformMockCalls{
+					PerWorkDir: map[string][]*mock.Call{
+						tmpDir.Dir(): validTfMockCalls(),
+					},
+				},
+			}))
+			ls.OnClientCallback(func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+				if req.Method() != "workspace/applyEdit" {
+					return nil, fmt.Errorf("unexpected callback: %s", req.Method())
+				}
+				return lsp.ApplyWorkspaceEditResponse{Applied: tc.applied}, nil
+			})
+			stop := ls.Start(t)
+			defer stop()
+
+			ls.Call(t, &langserver.CallRequest{
+				Method: "initialize",
+				ReqParams: fmt.Sprintf(`{
+				"capabilities": {
+					"workspace": {
+						"applyEdit": true
+					}
+				},
+				"rootUri": %q,
+				"processId": 12345
+			}`, tmpDir.URI())})
+			ls.Call(t, &langserver.CallRequest{
+				Method:    "initialized",
+				ReqParams: "{}",
+			})
+			ls.Call(t, &langserver.CallRequest{
+				Method: "textDocument/didOpen",
+				ReqParams: fmt.Sprintf(`{
+				"textDocument": {
+					"version": 0,
+					"languageId": "terraform",
+					"text": %q,
+					"uri": "%s/main.tf"
+				}
+			}`, `variable "instance_count" {
+  type = number
+}
+
+resource "aws_instance" "web" {
+  count = var.instance_count
+}
+`, tmpDir.URI())})
+
+			ls.Call(t, &langserver.CallRequest{
+				Method: "workspace/executeCommand",
+				ReqParams: fmt.Sprintf(`{
+				"command": %q,
+				"arguments": ["uri=%s", "addresses=aws_instance.web", "name=web"]
+			}`, cmd.Name("module.extract"), tmpDir.URI())})
+
+			modulesDir := filepath.Join(tmpDir.Dir(), "modules")
+			if tc.expectedFiles == nil {
+				_, err := os.Stat(modulesDir)
+				if !os.IsNotExist(err) {
+					t.Fatalf("expected written files to be removed, stat error: %v", err)
+				}
+				return
+			}
+
+			for filename, expectedContent := range tc.expectedFiles {
+				content, err := ioutil.ReadFile(filepath.Join(modulesDir, "web", filename))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(expectedContent, string(content)); diff != "" {
+					t.Fatalf("unexpected content of %s: %s", filename, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+// workspaceFolder represents a workspace folder along with options
+// scoped to it, as obtained via workspace/configuration
+type workspaceFolder struct {
+	uri  string
+	opts *settings.Options
+}
+
+func (svc *service) setWorkspaceFolder(path, uri string) {
+	svc.foldersMu.Lock()
+	defer svc.foldersMu.Unlock()
+
+	if svc.folders == nil {
+		svc.folders = make(map[string]*workspaceFolder, 0)
+	}
+	if _, ok := svc.folders[path]; ok {
+		return
+	}
+	svc.folders[path] = &workspaceFolder{uri: uri}
+}
+
+func (svc *service) removeWorkspaceFolder(path string) {
+	svc.foldersMu.Lock()
+	defer svc.foldersMu.Unlock()
+
+	delete(svc.folders, path)
+}
+
+// folderOptions returns options of all workspace folders
+// which have any options set, keyed by folder path
+func (svc *service) folderOptions() map[string]*settings.Options {
+	svc.foldersMu.RLock()
+	defer svc.foldersMu.RUnlock()
+
+	opts := make(map[string]*settings.Options, 0)
+	for path, folder := range svc.folders {
+		if folder.opts != nil {
+			opts[path] = folder.opts
+		}
+	}
+	return opts
+}
+
+// folderCommandPrefixes returns command prefixes of all workspace folders
+// which differ from the command prefix set on initialization
+func (svc *service) folderCommandPrefixes() []string {
+	globalPrefix := ""
+	if cfgOpts := svc.configOptions(); cfgOpts != nil {
+		globalPrefix = cfgOpts.CommandPrefix
+	}
+
+	seen := make(map[string]bool, 0)
+	prefixes := make([]string, 0)
+	for _, opts := range svc.folderOptions() {
+		prefix := opts.CommandPrefix
+		if prefix == globalPrefix || seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	return prefixes
+}
+
+// refreshFolderSettings obtains options scoped to the given
+// workspace folders (or all folders if none are given)
+// if the client supports workspace/configuration requests
+func (svc *service) refreshFolderSettings(ctx context.Context, paths ...string) error {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if !cc.Workspace.Configuration {
+		return nil
+	}
+
+	svc.foldersMu.RLock()
+	if len(paths) == 0 {
+		for path := range svc.folders {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	items := make([]lsp.ConfigurationItem, 0, len(paths))
+	for _, path := range paths {
+		folder, ok := svc.folders[path]
+		if !ok {
+			continue
+		}
+		items = append(items, lsp.ConfigurationItem{
+			ScopeURI: folder.uri,
+			Section:  configurationSection,
+		})
+	}
+	svc.foldersMu.RUnlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	results, err := svc.pullConfiguration(ctx, items)
+	if err != nil {
+		return err
+	}
+
+	svc.foldersMu.Lock()
+	defer svc.foldersMu.Unlock()
+
+	for i, item := range items {
+		if i >= len(results) {
+			break
+		}
+
+		var opts *settings.Options
+		if results[i] != nil {
+			opts, err = decodeFolderOptions(results[i])
+			if err != nil {
+				jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+					Type:    lsp.Warning,
+					Message: fmt.Sprintf("Ignoring settings of workspace folder %s: %s", item.ScopeURI, err),
+				})
+			}
+		}
+
+		for _, folder := range svc.folders {
+			if folder.uri == item.ScopeURI {
+				folder.opts = opts
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeFolderOptions(rawOpts interface{}) (*settings.Options, error) {
+	out, err := settings.DecodeOptions(rawOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = out.Options.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return out.Options, nil
+}
+
+// applyFolderSettings reflects options of all workspace folders
+// in the executor options, walker, watcher and registered commands,
+// combined with options set on initialization
+func (svc *service) applyFolderSettings(ctx context.Context) error {
+	rootDir, _ := lsctx.RootDirectory(ctx)
+	_, cliExecPathSet := lsctx.TerraformExecPath(svc.srvCtx)
+
+	globalOpts := svc.configOptions()
+	if globalOpts == nil {
+		globalOpts = &settings.Options{}
+	}
+
+	var excludeModulePaths []string
+	for _, rawPath := range globalOpts.ExcludeModulePaths {
+		modPath, err := resolvePath(rootDir, rawPath)
+		if err != nil {
+			svc.logger.Printf("Ignoring excluded module path %s: %s", rawPath, err)
+			continue
+		}
+		excludeModulePaths = append(excludeModulePaths, modPath)
+	}
+
+	folderExecPaths := make(map[string]string, 0)
+	var modulePaths []string
+
+	for folderPath, opts := range svc.folderOptions() {
+		if opts.TerraformExecPath != "" {
+			if cliExecPathSet {
+				svc.logger.Printf("Ignoring Terraform exec path of workspace folder %s: "+
+					"already set via (-tf-exec) CLI flag", folderPath)
+			} else {
+				folderExecPaths[folderPath] = opts.TerraformExecPath
+			}
+		}
+
+		for _, rawPath := range opts.ExcludeModulePaths {
+			modPath, err := resolvePath(folderPath, rawPath)
+			if err != nil {
+				svc.logger.Printf("Ignoring excluded module path %s: %s", rawPath, err)
+				continue
+			}
+			excludeModulePaths = append(excludeModulePaths, modPath)
+		}
+
+		for _, rawPath := range opts.ModulePaths {
+			modPath, err := resolvePath(folderPath, rawPath)
+			if err != nil {
+				svc.logger.Printf("Ignoring module path %s: %s", rawPath, err)
+				continue
+			}
+			modulePaths = append(modulePaths, modPath)
+		}
+	}
+
+	svc.tfExecOpts.Update(func(opts *exec.ExecutorOpts) {
+		opts.FolderExecPaths = folderExecPaths
+	})
+
+	svc.walker.SetExcludeModulePaths(excludeModulePaths)
+	err := svc.evictExcludedModules()
+	if err != nil {
+		return err
+	}
+
+	for _, modPath := range modulePaths {
+		err := svc.watcher.AddModule(modPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return svc.registerFolderCommands(ctx)
+}
+
+// registerFolderCommands registers commands with any prefixes
+// of workspace folders not registered yet, if the client
+// supports dynamic registration of commands
+func (svc *service) registerFolderCommands(ctx context.Context) error {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if !cc.Workspace.ExecuteCommand.DynamicRegistration {
+		return nil
+	}
+
+	prefixes := svc.folderCommandPrefixes()
+
+	registrations := make([]lsp.Registration, 0)
+	svc.foldersMu.Lock()
+	if svc.registeredCmdPrefixes == nil {
+		svc.registeredCmdPrefixes = make(map[string]bool, 0)
+	}
+	for _, prefix := range prefixes {
+		if svc.registeredCmdPrefixes[prefix] {
+			continue
+		}
+		svc.registeredCmdPrefixes[prefix] = true
+		registrations = append(registrations, lsp.Registration{
+			ID:     fmt.Sprintf("workspace/executeCommand/%s", prefix),
+			Method: "workspace/executeCommand",
+			RegisterOptions: lsp.ExecuteCommandOptions{
+				Commands: handlers.Names(prefix),
+			},
+		})
+	}
+	svc.foldersMu.Unlock()
+
+	if len(registrations) == 0 {
+		return nil
+	}
+
+	_, err = svc.server.Callback(ctx, "client/registerCapability", lsp.RegistrationParams{
+		Registrations: registrations,
+	})
+	return err
+}
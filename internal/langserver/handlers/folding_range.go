@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/structure"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+func (svc *service) TextDocumentFoldingRange(ctx context.Context, params lsp.FoldingRangeParams) ([]lsp.FoldingRange, error) {
+	ranges := make([]lsp.FoldingRange, 0)
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return ranges, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return ranges, err
+	}
+
+	f, ok := svc.parsedFile(doc)
+	if !ok {
+		return ranges, nil
+	}
+
+	return ilsp.FoldingRanges(structure.FoldingRanges(f)), nil
+}
+
+// parsedFile returns the file parsed from the document
+// as part of its module, if it was parsed already
+func (svc *service) parsedFile(doc filesystem.Document) (*hcl.File, bool) {
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, false
+	}
+
+	var f *hcl.File
+	switch doc.LanguageID() {
+	case ilsp.Terraform.String():
+		f = mod.ParsedModuleFiles[ast.ModFilename(doc.Filename())]
+	case ilsp.Tfvars.String():
+		f = mod.ParsedVarsFiles[ast.VarsFilename(doc.Filename())]
+	}
+
+	return f, f != nil
+}
@@ -3,17 +3,20 @@ package handlers
 import (
 	"context"
 
+	hcllib "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/format"
 	"github.com/hashicorp/terraform-ls/internal/hcl"
 	"github.com/hashicorp/terraform-ls/internal/langserver/errors"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
-	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/settings"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 )
 
-func (h *logHandler) TextDocumentFormatting(ctx context.Context, params lsp.DocumentFormattingParams) ([]lsp.TextEdit, error) {
+func (svc *service) TextDocumentFormatting(ctx context.Context, params lsp.DocumentFormattingParams) ([]lsp.TextEdit, error) {
 	var edits []lsp.TextEdit
 
 	fs, err := lsctx.DocumentStorage(ctx)
@@ -23,11 +26,36 @@ func (h *logHandler) TextDocumentFormatting(ctx context.Context, params lsp.Docu
 
 	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
 
-	tfExec, err := module.TerraformExecutorForModule(ctx, fh.Dir())
+	file, err := fs.GetDocument(fh)
+	if err != nil {
+		return edits, err
+	}
+
+	original, err := file.Text()
+	if err != nil {
+		return edits, err
+	}
+
+	edits, err = svc.formatDocument(ctx, original, file)
 	if err != nil {
-		return edits, errors.EnrichTfExecError(err)
+		return edits, err
 	}
 
+	return edits, nil
+}
+
+// TextDocumentRangeFormatting formats the whole document
+// and returns only edits of lines within the requested range
+func (svc *service) TextDocumentRangeFormatting(ctx context.Context, params lsp.DocumentRangeFormattingParams) ([]lsp.TextEdit, error) {
+	var edits []lsp.TextEdit
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return edits, err
+	}
+
+	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
+
 	file, err := fs.GetDocument(fh)
 	if err != nil {
 		return edits, err
@@ -38,25 +66,149 @@ func (h *logHandler) TextDocumentFormatting(ctx context.Context, params lsp.Docu
 		return edits, err
 	}
 
-	h.logger.Printf("formatting document via %q", tfExec.GetExecPath())
-
-	edits, err = formatDocument(ctx, tfExec, original, file)
+	edits, err = svc.formatDocument(ctx, original, file)
 	if err != nil {
 		return edits, err
 	}
 
-	return edits, nil
+	return editsInLineRange(edits, params.Range.Start.Line, params.Range.End.Line), nil
 }
 
-func formatDocument(ctx context.Context, tfExec exec.TerraformExecutor, original []byte, file filesystem.Document) ([]lsp.TextEdit, error) {
+// TextDocumentOnTypeFormatting formats the block closed by a typed `}`,
+// or the line finished by a typed newline.
+//
+// The document is always formatted natively, as spawning
+// a process on each keystroke would be too expensive.
+func (svc *service) TextDocumentOnTypeFormatting(ctx context.Context, params lsp.DocumentOnTypeFormattingParams) ([]lsp.TextEdit, error) {
 	var edits []lsp.TextEdit
 
-	formatted, err := tfExec.Format(ctx, original)
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return edits, err
+	}
+
+	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
+
+	file, err := fs.GetDocument(fh)
+	if err != nil {
+		return edits, err
+	}
+
+	original, err := file.Text()
 	if err != nil {
 		return edits, err
 	}
 
+	formatted, err := format.Format(original, file.Filename())
+	if err != nil {
+		// incomplete configuration is common while typing
+		return edits, nil
+	}
+	edits = ilsp.TextEditsFromDocumentChanges(hcl.Diff(file, original, formatted))
+
+	switch params.Ch {
+	case "}":
+		pos, err := ilsp.FilePositionFromDocumentPosition(lsp.TextDocumentPositionParams{
+			TextDocument: params.TextDocument,
+			Position:     params.Position,
+		}, file)
+		if err != nil {
+			return edits, err
+		}
+		rng, ok := blockClosedAtPos(original, file.Filename(), pos.Position())
+		if !ok {
+			return editsInLineRange(edits, params.Position.Line, params.Position.Line), nil
+		}
+		return editsInLineRange(edits, uint32(rng.Start.Line-1), uint32(rng.End.Line-1)), nil
+	case "\n":
+		if params.Position.Line == 0 {
+			return nil, nil
+		}
+		// the new (current) line is left intact,
+		// to avoid removing the indentation the cursor is placed at
+		prevLine := params.Position.Line - 1
+		return editsInLineRange(edits, prevLine, prevLine), nil
+	}
+
+	return nil, nil
+}
+
+// formatDocument formats the document natively, or via terraform fmt
+// if configured so. Documents of JSON syntax are left intact.
+func (svc *service) formatDocument(ctx context.Context, original []byte, file filesystem.Document) ([]lsp.TextEdit, error) {
+	var edits []lsp.TextEdit
+
+	if format.IsJSON(file.Filename()) {
+		return edits, nil
+	}
+
+	var formatted []byte
+	if cfgOpts := svc.configOptions(); cfgOpts != nil && cfgOpts.Formatter == settings.FormatterTerraform {
+		tfExec, err := module.TerraformExecutorForModule(ctx, file.Dir())
+		if err != nil {
+			return edits, errors.EnrichTfExecError(err)
+		}
+
+		svc.logger.Printf("formatting document via %q", tfExec.GetExecPath())
+
+		formatted, err = tfExec.Format(ctx, original)
+		if err != nil {
+			return edits, err
+		}
+	} else {
+		var err error
+		formatted, err = format.Format(original, file.Filename())
+		if err != nil {
+			return edits, err
+		}
+	}
+
 	changes := hcl.Diff(file, original, formatted)
 
 	return ilsp.TextEditsFromDocumentChanges(changes), nil
 }
+
+// editsInLineRange returns edits which affect any lines
+// between startLine and endLine (inclusive)
+func editsInLineRange(edits []lsp.TextEdit, startLine, endLine uint32) []lsp.TextEdit {
+	filtered := make([]lsp.TextEdit, 0)
+	for _, edit := range edits {
+		firstLine, lastLine := edit.Range.Start.Line, edit.Range.End.Line
+		if edit.Range.End.Character == 0 && lastLine > firstLine {
+			// range ends at the beginning of the line following the last one
+			lastLine--
+		}
+		if firstLine <= endLine && lastLine >= startLine {
+			filtered = append(filtered, edit)
+		}
+	}
+	return filtered
+}
+
+// blockClosedAtPos returns range of the innermost block
+// whose closing brace ends at the given position
+func blockClosedAtPos(src []byte, filename string, pos hcllib.Pos) (hcllib.Range, bool) {
+	f, _ := hclsyntax.ParseConfig(src, filename, hcllib.InitialPos)
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcllib.Range{}, false
+	}
+
+	var rng hcllib.Range
+	found := false
+	for body != nil {
+		var next *hclsyntax.Body
+		for _, block := range body.Blocks {
+			blockRng := block.Range()
+			if blockRng.End.Byte == pos.Byte {
+				rng, found = blockRng, true
+			}
+			if blockRng.Start.Byte < pos.Byte && pos.Byte <= blockRng.End.Byte {
+				next = block.Body
+			}
+		}
+		body = next
+	}
+
+	return rng, found
+}
@@ -37,25 +37,36 @@ func initializeResponse(t *testing.T, commandPrefix string) string {
 					"completionItem":{}
 				},
 				"hoverProvider": true,
-				"signatureHelpProvider": {},
+				"signatureHelpProvider": {
+					"triggerCharacters": ["(", ","]
+				},
 				"declarationProvider": {},
 				"definitionProvider": true,
 				"referencesProvider": true,
+				"documentHighlightProvider": true,
 				"documentSymbolProvider": true,
 				"codeActionProvider": {
-					"codeActionKinds": ["source.formatAll.terraform"]
+					"codeActionKinds": ["quickfix", "refactor.extract", "refactor.rewrite", "source.formatAll.terraform"]
 				},
 				"codeLensProvider": {},
 				"documentLinkProvider": {},
 				"workspaceSymbolProvider": true,
 				"documentFormattingProvider": true,
+				"documentRangeFormattingProvider": true,
 				"documentOnTypeFormattingProvider": {
-					"firstTriggerCharacter": ""
+					"firstTriggerCharacter": "}",
+					"moreTriggerCharacter": ["\n"]
+				},
+				"renameProvider": {
+					"prepareProvider": true
 				},
+				"foldingRangeProvider": true,
+				"selectionRangeProvider": true,
 				"executeCommandProvider": {
 					"commands": %s,
 					"workDoneProgress":true
 				},
+				"callHierarchyProvider": true,
 				"semanticTokensProvider": {
 					"legend": {
 						"tokenTypes": [],
@@ -68,7 +79,8 @@ func initializeResponse(t *testing.T, commandPrefix string) string {
 						"supported": true,
 						"changeNotifications": "workspace/didChangeWorkspaceFolders"
 					}
-				}
+				},
+				"inlayHintProvider": {}
 			},
 			"serverInfo": {
 				"name": "terraform-ls"
@@ -3,11 +3,15 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-schema/backend"
 )
 
@@ -125,14 +129,20 @@ func moduleTelemetryData(oldMod, newMod *state.Module, store *state.StateStore)
 	return properties, true
 }
 
-func updateDiagnostics(ctx context.Context, notifier *diagnostics.Notifier) state.ModuleChangeHook {
+func updateDiagnostics(ctx context.Context, fs filesystem.DocumentStorage, notifier *diagnostics.Notifier) state.ModuleChangeHook {
 	return func(oldMod, newMod *state.Module) {
 		oldDiags, newDiags := 0, 0
 		if oldMod != nil {
-			oldDiags = oldMod.ModuleDiagnostics.Count() + oldMod.VarsDiagnostics.Count()
+			oldDiags = oldMod.ModuleDiagnostics.Count() + oldMod.VarsDiagnostics.Count() +
+				oldMod.SchemaValidationDiagnostics.Count() + oldMod.ReferenceValidationDiagnostics.Count() +
+				oldMod.UnusedDeclarationDiagnostics.Count() +
+				oldMod.VarsValidationDiagnostics.Count() + oldMod.TerraformVersionDiagnostics.Count()
 		}
 		if newMod != nil {
-			newDiags = newMod.ModuleDiagnostics.Count() + newMod.VarsDiagnostics.Count()
+			newDiags = newMod.ModuleDiagnostics.Count() + newMod.VarsDiagnostics.Count() +
+				newMod.SchemaValidationDiagnostics.Count() + newMod.ReferenceValidationDiagnostics.Count() +
+				newMod.UnusedDeclarationDiagnostics.Count() +
+				newMod.VarsValidationDiagnostics.Count() + newMod.TerraformVersionDiagnostics.Count()
 		}
 
 		if oldDiags == 0 && newDiags == 0 {
@@ -145,12 +155,28 @@ func updateDiagnostics(ctx context.Context, notifier *diagnostics.Notifier) stat
 		defer notifier.PublishHCLDiags(ctx, newMod.Path, diags)
 
 		if newMod != nil {
+			// diagnostics of other variable files are only relevant
+			// when the user explicitly opens them
+			isOpen := isVarsFileOpen(fs, newMod.Path)
+
 			diags.Append("HCL", newMod.ModuleDiagnostics.AsMap())
-			diags.Append("HCL", newMod.VarsDiagnostics.AutoloadedOnly().AsMap())
+			diags.Append("HCL", newMod.VarsDiagnostics.AutoloadedOrOpen(isOpen).AsMap())
+			diags.Append("schema validation", newMod.SchemaValidationDiagnostics.AsMap())
+			diags.Append("reference validation", newMod.ReferenceValidationDiagnostics.AsMap())
+			diags.AppendUnnecessary("reference validation", newMod.UnusedDeclarationDiagnostics.AsMap())
+			diags.Append("variables validation", newMod.VarsValidationDiagnostics.AutoloadedOrOpen(isOpen).AsMap())
+			diags.Append("version selection", newMod.TerraformVersionDiagnostics.AsMap())
 		}
 	}
 }
 
+func isVarsFileOpen(fs filesystem.DocumentStorage, modPath string) func(ast.VarsFilename) bool {
+	return func(name ast.VarsFilename) bool {
+		_, err := fs.GetDocument(ilsp.FileHandlerFromPath(filepath.Join(modPath, name.String())))
+		return err == nil
+	}
+}
+
 func refreshCodeLens(ctx context.Context, clientRequester session.ClientCaller) state.ModuleChangeHook {
 	return func(oldMod, newMod *state.Module) {
 		oldOrigins, oldTargets := 0, 0
@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/functions"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -34,6 +38,17 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 		return nil, err
 	}
 
+	text, err := doc.Text()
+	if err != nil {
+		return nil, err
+	}
+	name, rng, ok := functions.NameAtPos(text, doc.Filename(), fPos.Position())
+	if ok {
+		if sig, ok := svc.functionsForDocument(doc)[name]; ok {
+			return ilsp.HoverData(functionHoverData(sig, rng), cc.TextDocument), nil
+		}
+	}
+
 	svc.logger.Printf("Looking for hover data at %q -> %#v", doc.Filename(), fPos.Position())
 	hoverData, err := d.HoverAtPos(doc.Filename(), fPos.Position())
 	svc.logger.Printf("received hover data: %#v", hoverData)
@@ -43,3 +58,10 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 
 	return ilsp.HoverData(hoverData, cc.TextDocument), nil
 }
+
+func functionHoverData(sig *functions.Signature, rng hcl.Range) *lang.HoverData {
+	return &lang.HoverData{
+		Content: lang.Markdown(fmt.Sprintf("```terraform\n%s\n```\n\n%s", sig.Label(), sig.Description)),
+		Range:   rng,
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -14,7 +15,15 @@ import (
 	"github.com/mitchellh/go-homedir"
 )
 
-func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams) (lsp.InitializeResult, error) {
+func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams) (lsp.InitializeResult317, error) {
+	result, err := svc.initialize(ctx, params)
+	return lsp.InitializeResult317{
+		InitializeResult:  result,
+		InlayHintProvider: lsp.InlayHintOptions{},
+	}, err
+}
+
+func (svc *service) initialize(ctx context.Context, params lsp.InitializeParams) (lsp.InitializeResult, error) {
 	serverCaps := lsp.InitializeResult{
 		Capabilities: lsp.ServerCapabilities{
 			TextDocumentSync: lsp.TextDocumentSyncOptions{
@@ -29,14 +38,29 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 				CodeActionKinds: ilsp.SupportedCodeActions.AsSlice(),
 				ResolveProvider: false,
 			},
+			SignatureHelpProvider: lsp.SignatureHelpOptions{
+				TriggerCharacters: []string{"(", ","},
+			},
 			DeclarationProvider:        lsp.DeclarationOptions{},
 			DefinitionProvider:         true,
 			CodeLensProvider:           lsp.CodeLensOptions{},
 			ReferencesProvider:         true,
+			DocumentHighlightProvider:  true,
 			HoverProvider:              true,
 			DocumentFormattingProvider: true,
 			DocumentSymbolProvider:     true,
 			WorkspaceSymbolProvider:    true,
+			RenameProvider: lsp.RenameOptions{
+				PrepareProvider: true,
+			},
+			DocumentRangeFormattingProvider: true,
+			FoldingRangeProvider:            true,
+			SelectionRangeProvider:          true,
+			CallHierarchyProvider:           true,
+			DocumentOnTypeFormattingProvider: lsp.DocumentOnTypeFormattingOptions{
+				FirstTriggerCharacter: "}",
+				MoreTriggerCharacter:  []string{"\n"},
+			},
 			Workspace: lsp.Workspace5Gn{
 				WorkspaceFolders: lsp.WorkspaceFolders4Gn{
 					Supported:           true,
@@ -82,18 +106,34 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 	}
 	defer svc.telemetry.SendEvent(ctx, "initialize", properties)
 
-	fh := ilsp.FileHandlerFromDirURI(params.RootURI)
-	if fh.URI() == "" || !fh.IsDir() {
-		properties["root_uri"] = "file"
-		return serverCaps, fmt.Errorf("Editing a single file is not yet supported." +
-			" Please open a directory.")
-	}
-	if !fh.Valid() {
-		properties["root_uri"] = "invalid"
-		return serverCaps, fmt.Errorf("URI %q is not valid", params.RootURI)
+	// In single-file mode (i.e. no root directory) nothing is walked
+	// and modules are only added for directories of opened files.
+	var rootDir string
+	if params.RootURI == "" {
+		properties["root_uri"] = "none"
+	} else {
+		fh := ilsp.FileHandlerFromDirURI(params.RootURI)
+		if !fh.Valid() {
+			properties["root_uri"] = "invalid"
+			return serverCaps, fmt.Errorf("URI %q is not valid", params.RootURI)
+		}
+
+		fi, err := svc.fs.Stat(fh.FullPath())
+		switch {
+		case err != nil && os.IsNotExist(err):
+			properties["root_uri"] = "missing"
+			svc.logger.Printf("root URI %q does not exist, running in single-file mode",
+				params.RootURI)
+		case err != nil:
+			return serverCaps, fmt.Errorf("unable to read root URI %q: %w",
+				params.RootURI, err)
+		case !fi.IsDir():
+			properties["root_uri"] = "file"
+		default:
+			rootDir = fh.FullPath()
+		}
 	}
 
-	rootDir := fh.FullPath()
 	err := lsctx.SetRootDirectory(ctx, rootDir)
 	if err != nil {
 		return serverCaps, err
@@ -132,6 +172,8 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 	properties["options.terraformExecPath"] = len(out.Options.TerraformExecPath) > 0
 	properties["options.terraformExecTimeout"] = out.Options.TerraformExecTimeout
 	properties["options.terraformLogFilePath"] = len(out.Options.TerraformLogFilePath) > 0
+	properties["options.formatter"] = out.Options.Formatter
+	properties["options.launchProviderPlugins"] = out.Options.LaunchProviderPlugins
 
 	err = out.Options.Validate()
 	if err != nil {
@@ -142,6 +184,7 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 	if err != nil {
 		return serverCaps, err
 	}
+	svc.setConfigOptions(out.Options)
 
 	stCaps := clientCaps.TextDocument.SemanticTokens
 	caps := ilsp.SemanticTokensClientCapabilities{
@@ -152,7 +195,13 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 			TokenTypes:     ilsp.TokenTypesLegend(stCaps.TokenTypes).AsStrings(),
 			TokenModifiers: ilsp.TokenModifiersLegend(stCaps.TokenModifiers).AsStrings(),
 		},
-		Full: caps.FullRequest(),
+		Full:  caps.FullRequest(),
+		Range: caps.RangeRequest(),
+	}
+	if caps.FullDeltaRequest() {
+		semanticTokensOpts.Full = map[string]interface{}{
+			"delta": true,
+		}
 	}
 
 	serverCaps.Capabilities.SemanticTokensProvider = semanticTokensOpts
@@ -198,7 +247,9 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 
 	svc.walker.SetIgnoreDirectoryNames(cfgOpts.IgnoreDirectoryNames)
 	svc.walker.SetExcludeModulePaths(excludeModulePaths)
-	svc.walker.EnqueuePath(fh.Dir())
+	if rootDir != "" {
+		svc.walker.EnqueuePath(rootDir)
+	}
 
 	// Walker runs asynchronously so we're intentionally *not*
 	// passing the request context here
@@ -223,8 +274,13 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 				continue
 			}
 
+			svc.setWorkspaceFolder(modPath, folderPath.URI)
 			svc.walker.EnqueuePath(modPath)
 		}
+	} else if rootDir != "" {
+		// Clients without support for workspace folders
+		// may still provide settings scoped to the root
+		svc.setWorkspaceFolder(rootDir, string(params.RootURI))
 	}
 
 	// Static user-provided paths take precedence over dynamic discovery
@@ -259,6 +315,9 @@ func resolvePath(rootDir, rawPath string) (string, error) {
 	}
 
 	if !filepath.IsAbs(path) {
+		if rootDir == "" {
+			return "", fmt.Errorf("relative path cannot be resolved without a root directory")
+		}
 		path = filepath.Join(rootDir, rawPath)
 	}
 
@@ -6,6 +6,13 @@ import (
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
-func Initialized(ctx context.Context, params lsp.InitializedParams) error {
-	return nil
+func (svc *service) Initialized(ctx context.Context, params lsp.InitializedParams) error {
+	// Settings scoped to workspace folders can only be requested
+	// from the client after initialization
+	err := svc.refreshFolderSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	return svc.applyFolderSettings(ctx)
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func (svc *service) TextDocumentInlayHint(ctx context.Context, params lsp.InlayHintParams) ([]lsp.InlayHint, error) {
+	hints := make([]lsp.InlayHint, 0)
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return hints, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return hints, err
+	}
+
+	if doc.LanguageID() != ilsp.Terraform.String() {
+		return hints, nil
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return hints, err
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return hints, err
+	}
+
+	activeVarsFile := ""
+	if cfgOpts := svc.configOptions(); cfgOpts != nil {
+		activeVarsFile = cfgOpts.ActiveVariablesFile
+	}
+
+	for _, hint := range decoder.InlayHints(mod, rng, activeVarsFile) {
+		var kind lsp.InlayHintKind
+		if hint.Kind == decoder.TypeHint {
+			kind = lsp.InlayHintKindType
+		}
+		hints = append(hints, lsp.InlayHint{
+			Position:    ilsp.HCLPosToLSP(hint.Pos),
+			Label:       hint.Label,
+			Kind:        kind,
+			Tooltip:     hint.Tooltip,
+			PaddingLeft: true,
+		})
+	}
+
+	return hints, nil
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInlayHint_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/inlayHint",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"range": {
+				"start": {"line": 0, "character": 0},
+				"end": {"line": 1, "character": 0}
+			}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestInlayHint_withValidData(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "terraform.tfvars"),
+		[]byte("zones = [\"a\", \"b\"]\ninstance_type = \"t3.small\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "prod.tfvars"),
+		[]byte("instance_type = \"m5.large\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345,
+		"initializationOptions": {
+			"activeVariablesFile": "prod.tfvars"
+		}
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"instance_type\" {\n  default = \"t2.micro\"\n}\nvariable \"zones\" {\n  type = list(string)\n}\nvariable \"region\" {\n  default = \"us-east-1\"\n}\nlocals {\n  name  = \"web-${var.region}\"\n  count = 2\n}\noutput \"type\" {\n  value = var.instance_type\n}\noutput \"zones\" {\n  value = var.zones[0]\n}\noutput \"name\" {\n  value = local.name\n}\noutput \"count\" {\n  value = local.count\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/inlayHint",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"range": {
+				"start": {"line": 9, "character": 0},
+				"end": {"line": 25, "character": 0}
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"position": {"line": 10, "character": 27},
+					"label": "= \"us-east-1\"",
+					"tooltip": "Default value of var.region",
+					"paddingLeft": true
+				},
+				{
+					"position": {"line": 14, "character": 27},
+					"label": "= \"m5.large\"",
+					"tooltip": "Value of var.instance_type from prod.tfvars",
+					"paddingLeft": true
+				},
+				{
+					"position": {"line": 17, "character": 22},
+					"label": "= \"a\"",
+					"tooltip": "Value of var.zones from terraform.tfvars",
+					"paddingLeft": true
+				},
+				{
+					"position": {"line": 20, "character": 20},
+					"label": ": string",
+					"kind": 1,
+					"paddingLeft": true
+				},
+				{
+					"position": {"line": 23, "character": 21},
+					"label": ": number",
+					"kind": 1,
+					"paddingLeft": true
+				}
+			]
+		}`)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/refactor"
+)
+
+func (svc *service) PrepareRename(ctx context.Context, params lsp.PrepareRenameParams) (*lsp.Range, error) {
+	sym, err := svc.renameableSymbol(ctx, params.TextDocumentPositionParams)
+	if err != nil {
+		var noSymbolErr *refactor.NoSymbolFoundError
+		if errors.As(err, &noSymbolErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rng := ilsp.HCLRangeToLSP(sym.NameRange)
+	return &rng, nil
+}
+
+func (svc *service) Rename(ctx context.Context, params lsp.RenameParams) (*lsp.WorkspaceEdit, error) {
+	sym, err := svc.renameableSymbol(ctx, lsp.TextDocumentPositionParams{
+		TextDocument: params.TextDocument,
+		Position:     params.Position,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := refactor.Rename(svc.modStore, sym, params.NewName)
+	if err != nil {
+		return nil, err
+	}
+
+	wsEdit := ilsp.WorkspaceEdit(edits)
+	return &wsEdit, nil
+}
+
+func (svc *service) renameableSymbol(ctx context.Context, params lsp.TextDocumentPositionParams) (*refactor.Symbol, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return refactor.SymbolAtPos(svc.modStore, doc.Dir(), doc.Filename(), fPos.Position())
+}
@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRename_withinModule(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+		StateStore: ss,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": `+fmt.Sprintf("%q",
+			`variable "test" {
+}
+
+locals {
+  name = "${var.test}-foo"
+}
+
+output "foo" {
+  value = local.name
+}
+`)+`,
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-vars",
+			"text": "test = \"bar\"\n",
+			"uri": "%s/terraform.tfvars"
+		}
+	}`, tmpDir.URI())})
+	waitForReferenceDecoding(t, ss, tmpDir.Dir())
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/prepareRename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 8,
+				"character": 12
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": {
+				"start": {
+					"line": 8,
+					"character": 16
+				},
+				"end": {
+					"line": 8,
+					"character": 20
+				}
+			}
+		}`)
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 4,
+				"character": 17
+			},
+			"newName": "renamed"
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": {
+				"changes": {
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {
+									"line": 0,
+									"character": 10
+								},
+								"end": {
+									"line": 0,
+									"character": 14
+								}
+							},
+							"newText": "renamed"
+						},
+						{
+							"range": {
+								"start": {
+									"line": 4,
+									"character": 16
+								},
+								"end": {
+									"line": 4,
+									"character": 20
+								}
+							},
+							"newText": "renamed"
+						}
+					],
+					"%s/terraform.tfvars": [
+						{
+							"range": {
+								"start": {
+									"line": 0,
+									"character": 0
+								},
+								"end": {
+									"line": 0,
+									"character": 4
+								}
+							},
+							"newText": "renamed"
+						}
+					]
+				}
+			}
+		}`, tmpDir.URI(), tmpDir.URI()))
+
+	// renaming to the current name produces no edits
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 4,
+				"character": 17
+			},
+			"newName": "test"
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 6,
+			"result": {}
+		}`)
+}
+
+func TestRename_variableWithCallers(t *testing.T) {
+	rootModPath, err := filepath.Abs(filepath.Join("testdata", "single-submodule"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submodPath := filepath.Join(rootModPath, "application")
+
+	rootModUri := lsp.FileHandlerFromDirPath(rootModPath)
+	submodUri := lsp.FileHandlerFromDirPath(submodPath)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				submodPath: validTfMockCalls(),
+			},
+		},
+		StateStore: ss,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+			"capabilities": {},
+			"rootUri": %q,
+			"processId": 12345
+	}`, rootModUri.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": `+fmt.Sprintf("%q",
+			`variable "environment_name" {
+  type = string
+}
+
+variable "app_prefix" {
+  type = string
+}
+
+variable "instances" {
+  type = number
+}
+
+resource "random_pet" "application" {
+  count = var.instances
+  keepers = {
+    unique = "${var.environment_name}-${var.app_prefix}"
+  }
+}
+`)+`,
+			"uri": "%s/main.tf"
+		}
+	}`, submodUri.URI())})
+	waitForReferenceDecoding(t, ss, rootModPath, submodPath)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 8,
+				"character": 12
+			},
+			"newName": "instance_count"
+		}`, submodUri.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"changes": {
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {
+									"line": 8,
+									"character": 10
+								},
+								"end": {
+									"line": 8,
+									"character": 19
+								}
+							},
+							"newText": "instance_count"
+						},
+						{
+							"range": {
+								"start": {
+									"line": 13,
+									"character": 14
+								},
+								"end": {
+									"line": 13,
+									"character": 23
+								}
+							},
+							"newText": "instance_count"
+						}
+					],
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {
+									"line": 4,
+									"character": 2
+								},
+								"end": {
+									"line": 4,
+									"character": 11
+								}
+							},
+							"newText": "instance_count"
+						}
+					]
+				}
+			}
+		}`, submodUri.URI(), rootModUri.URI()))
+
+	// renaming from a reference produces the same edits
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 13,
+				"character": 16
+			},
+			"newName": "instance_count"
+		}`, submodUri.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": {
+				"changes": {
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {
+									"line": 8,
+									"character": 10
+								},
+								"end": {
+									"line": 8,
+									"character": 19
+								}
+							},
+							"newText": "instance_count"
+						},
+						{
+							"range": {
+								"start": {
+									"line": 13,
+									"character": 14
+								},
+								"end": {
+									"line": 13,
+									"character": 23
+								}
+							},
+							"newText": "instance_count"
+						}
+					],
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {
+									"line": 4,
+									"character": 2
+								},
+								"end": {
+									"line": 4,
+									"character": 11
+								}
+							},
+							"newText": "instance_count"
+						}
+					]
+				}
+			}
+		}`, submodUri.URI(), rootModUri.URI()))
+}
+
+// waitForReferenceDecoding waits until references of all given modules
+// are decoded, which may happen asynchronously after walking the workspace
+func waitForReferenceDecoding(t *testing.T, ss *state.StateStore, modPaths ...string) {
+	timeout := time.After(5 * time.Second)
+	for _, modPath := range modPaths {
+		for {
+			mod, err := ss.Modules.ModuleByPath(modPath)
+			if err == nil && mod.RefOriginsState == op.OpStateLoaded &&
+				mod.VarsRefOriginsState == op.OpStateLoaded {
+				break
+			}
+
+			select {
+			case <-timeout:
+				t.Fatalf("timed out waiting for references of %q to be decoded", modPath)
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/structure"
+)
+
+func (svc *service) TextDocumentSelectionRange(ctx context.Context, params lsp.SelectionRangeParams) ([]lsp.SelectionRange, error) {
+	ranges := make([]lsp.SelectionRange, 0)
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return ranges, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return ranges, err
+	}
+
+	f, ok := svc.parsedFile(doc)
+	if !ok {
+		return ranges, nil
+	}
+
+	for _, pos := range params.Positions {
+		fPos, err := ilsp.FilePositionFromDocumentPosition(lsp.TextDocumentPositionParams{
+			TextDocument: params.TextDocument,
+			Position:     pos,
+		}, doc)
+		if err != nil {
+			return ranges, err
+		}
+
+		rngs := structure.SelectionRanges(f, fPos.Position())
+		if len(rngs) == 0 {
+			// the position must be represented by a range
+			// which contains it, even if it is empty
+			rngs = append(rngs, hcl.Range{
+				Filename: doc.Filename(),
+				Start:    fPos.Position(),
+				End:      fPos.Position(),
+			})
+		}
+		ranges = append(ranges, ilsp.SelectionRange(rngs))
+	}
+
+	return ranges, nil
+}
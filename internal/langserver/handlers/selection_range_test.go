@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSelectionRange_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/selectionRange",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"positions": [
+				{"line": 0, "character": 0}
+			]
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestSelectionRange_withValidData(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Dir(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  name = var.name\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/selectionRange",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"positions": [
+				{"line": 1, "character": 14}
+			]
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"range": {
+						"start": {"line": 1, "character": 13},
+						"end": {"line": 1, "character": 17}
+					},
+					"parent": {
+						"range": {
+							"start": {"line": 1, "character": 9},
+							"end": {"line": 1, "character": 17}
+						},
+						"parent": {
+							"range": {
+								"start": {"line": 1, "character": 2},
+								"end": {"line": 1, "character": 17}
+							},
+							"parent": {
+								"range": {
+									"start": {"line": 0, "character": 0},
+									"end": {"line": 2, "character": 1}
+								},
+								"parent": {
+									"range": {
+										"start": {"line": 0, "character": 0},
+										"end": {"line": 3, "character": 0}
+									}
+								}
+							}
+						}
+					}
+				}
+			]
+		}`)
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 
 	"github.com/creachadair/jrpc2/code"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -39,22 +43,166 @@ func (svc *service) TextDocumentSemanticTokensFull(ctx context.Context, params l
 		return tks, err
 	}
 
-	d, err := svc.decoderForDocument(ctx, doc)
+	data, err := svc.encodeSemanticTokens(ctx, doc, nil)
 	if err != nil {
 		return tks, err
 	}
+	tks.Data = data
 
-	tokens, err := d.SemanticTokensInFile(doc.Filename())
+	if caps.FullDeltaRequest() {
+		// remember the tokens, so that the client
+		// can request just the changes next time
+		cached, err := ds.SetSemanticTokens(fh, data)
+		if err != nil {
+			return tks, err
+		}
+		tks.ResultID = cached.ResultID
+	}
+
+	return tks, nil
+}
+
+func (svc *service) TextDocumentSemanticTokensFullDelta(ctx context.Context, params lsp.SemanticTokensDeltaParams) (interface{}, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := ilsp.SemanticTokensClientCapabilities{
+		SemanticTokensClientCapabilities: cc.TextDocument.SemanticTokens,
+	}
+	if !caps.FullDeltaRequest() {
+		svc.logger.Printf("semantic tokens full/delta request support not announced by client")
+		return nil, code.MethodNotFound.Err()
+	}
+
+	ds, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
+	doc, err := ds.GetDocument(fh)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens are encoded again even if the document hasn't changed,
+	// as they also depend on other files and schemas of the module.
+	previous, ok := ds.SemanticTokens(fh, params.PreviousResultID)
+
+	data, err := svc.encodeSemanticTokens(ctx, doc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := ds.SetSemanticTokens(fh, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		// The previous result is unknown (e.g. it was superseded
+		// by another request), so we fall back to a full response.
+		return lsp.SemanticTokens{
+			ResultID: cached.ResultID,
+			Data:     data,
+		}, nil
+	}
+
+	return lsp.SemanticTokensDelta{
+		ResultID: cached.ResultID,
+		Edits:    ilsp.SemanticTokensEdits(previous.Data, data),
+	}, nil
+}
+
+func (svc *service) TextDocumentSemanticTokensRange(ctx context.Context, params lsp.SemanticTokensRangeParams) (lsp.SemanticTokens, error) {
+	tks := lsp.SemanticTokens{}
+
+	cc, err := ilsp.ClientCapabilities(ctx)
 	if err != nil {
 		return tks, err
 	}
 
-	te := &ilsp.TokenEncoder{
-		Lines:      doc.Lines(),
-		Tokens:     tokens,
-		ClientCaps: cc.TextDocument.SemanticTokens,
+	caps := ilsp.SemanticTokensClientCapabilities{
+		SemanticTokensClientCapabilities: cc.TextDocument.SemanticTokens,
+	}
+	if !caps.RangeRequest() {
+		svc.logger.Printf("semantic tokens range request support not announced by client")
+		return tks, code.MethodNotFound.Err()
+	}
+
+	ds, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return tks, err
+	}
+
+	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
+	doc, err := ds.GetDocument(fh)
+	if err != nil {
+		return tks, err
+	}
+
+	rng, err := ilsp.HCLRangeFromLspRange(params.Range, doc)
+	if err != nil {
+		return tks, err
 	}
-	tks.Data = te.Encode()
+
+	data, err := svc.encodeSemanticTokens(ctx, doc, &rng)
+	if err != nil {
+		return tks, err
+	}
+	tks.Data = data
 
 	return tks, nil
 }
+
+// encodeSemanticTokens returns encoded tokens of the document,
+// limited to tokens overlapping with the range, if one is given
+func (svc *service) encodeSemanticTokens(ctx context.Context, doc filesystem.Document, rng *hcl.Range) ([]uint32, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []lang.SemanticToken
+	if rng != nil {
+		// only decode blocks within the range, rather than the whole file
+		pathReader := &decoder.PathReader{
+			ModuleReader: svc.modStore,
+			SchemaReader: svc.schemaStore,
+		}
+		tokens, err = decoder.SemanticTokensInRange(ctx, pathReader, lang.Path{
+			Path:       doc.Dir(),
+			LanguageID: doc.LanguageID(),
+		}, *rng)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		d, err := svc.decoderForDocument(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, err = d.SemanticTokensInFile(doc.Filename())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+	extraTokens := decoder.SemanticTokens(mod, svc.schemaStore, svc.modStore, doc.Filename(), rng)
+
+	te := &ilsp.TokenEncoder{
+		Lines:       doc.Lines(),
+		Tokens:      tokens,
+		ExtraTokens: extraTokens,
+		Range:       rng,
+		ClientCaps:  cc.TextDocument.SemanticTokens,
+	}
+	return te.Encode(), nil
+}
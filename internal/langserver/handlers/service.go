@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/creachadair/jrpc2"
@@ -48,12 +49,19 @@ type service struct {
 	newWalker        module.WalkerFactory
 	tfDiscoFunc      discovery.DiscoveryFunc
 	tfExecFactory    exec.ExecutorFactory
-	tfExecOpts       *exec.ExecutorOpts
+	tfExecOpts       *exec.SharedExecutorOpts
+	cfgOptsMu        sync.RWMutex
+	cfgOpts          *settings.Options
 	telemetry        telemetry.Sender
 	decoder          *decoder.Decoder
 	stateStore       *state.StateStore
 	server           session.Server
 	diagsNotifier    *diagnostics.Notifier
+	schemaCache      *schemas.SchemaCache
+
+	folders               map[string]*workspaceFolder
+	registeredCmdPrefixes map[string]bool
+	foldersMu             sync.RWMutex
 
 	additionalHandlers map[string]rpch.Func
 }
@@ -64,6 +72,13 @@ func NewSession(srvCtx context.Context) session.Session {
 	fs := filesystem.NewFilesystem()
 	d := &discovery.Discovery{}
 
+	// Schemas are still obtained via Terraform if the cache is unavailable
+	var schemaCache *schemas.SchemaCache
+	cacheDir, err := schemas.DefaultSchemaCacheDir()
+	if err == nil {
+		schemaCache = schemas.NewSchemaCache(fs, cacheDir)
+	}
+
 	sessCtx, stopSession := context.WithCancel(srvCtx)
 	return &service{
 		logger:           discardLogs,
@@ -77,6 +92,7 @@ func NewSession(srvCtx context.Context) session.Session {
 		tfDiscoFunc:      d.LookPath,
 		tfExecFactory:    exec.NewExecutor,
 		telemetry:        &telemetry.NoopSender{},
+		schemaCache:      schemaCache,
 	}
 }
 
@@ -133,7 +149,10 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 
-			return handle(ctx, req, Initialized)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithRootDirectory(ctx, &rootDir)
+
+			return handle(ctx, req, svc.Initialized)
 		},
 		"textDocument/didChange": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -160,6 +179,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
 			return handle(ctx, req, TextDocumentDidClose)
 		},
 		"textDocument/documentSymbol": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
@@ -219,6 +239,82 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			return handle(ctx, req, svc.TextDocumentComplete)
 		},
+		"textDocument/signatureHelp": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentSignatureHelp)
+		},
+		"textDocument/documentHighlight": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentHighlight)
+		},
+		"textDocument/foldingRange": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentFoldingRange)
+		},
+		"textDocument/selectionRange": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentSelectionRange)
+		},
+		"textDocument/prepareCallHierarchy": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentPrepareCallHierarchy)
+		},
+		"callHierarchy/incomingCalls": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.CallHierarchyIncomingCalls)
+		},
+		"callHierarchy/outgoingCalls": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.CallHierarchyOutgoingCalls)
+		},
+		"textDocument/inlayHint": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentInlayHint)
+		},
 		"textDocument/hover": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
@@ -239,10 +335,15 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
-			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
+			ctx = lsctx.WithModuleFinder(ctx, svc.modMgr)
+			ctx = lsctx.WithCommandPrefix(ctx, &commandPrefix)
+			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts.Get())
 			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
+			if cfgOpts := svc.configOptions(); cfgOpts != nil && cfgOpts.VariablesFileName != "" {
+				ctx = lsctx.WithVariablesFileName(ctx, cfgOpts.VariablesFileName)
+			}
 
-			return handle(ctx, req, lh.TextDocumentCodeAction)
+			return handle(ctx, req, svc.TextDocumentCodeAction)
 		},
 		"textDocument/codeLens": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -262,10 +363,32 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			}
 
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
-			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
+			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts.Get())
 			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
 
-			return handle(ctx, req, lh.TextDocumentFormatting)
+			return handle(ctx, req, svc.TextDocumentFormatting)
+		},
+		"textDocument/rangeFormatting": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts.Get())
+			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
+
+			return handle(ctx, req, svc.TextDocumentRangeFormatting)
+		},
+		"textDocument/onTypeFormatting": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentOnTypeFormatting)
 		},
 		"textDocument/semanticTokens/full": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -278,6 +401,28 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			return handle(ctx, req, svc.TextDocumentSemanticTokensFull)
 		},
+		"textDocument/semanticTokens/full/delta": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.TextDocumentSemanticTokensFullDelta)
+		},
+		"textDocument/semanticTokens/range": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.TextDocumentSemanticTokensRange)
+		},
 		"textDocument/didSave": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
@@ -287,7 +432,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
 			ctx = lsctx.WithExperimentalFeatures(ctx, &expFeatures)
 			ctx = lsctx.WithModuleFinder(ctx, svc.modMgr)
-			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
+			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts.Get())
 
 			return handle(ctx, req, lh.TextDocumentDidSave)
 		},
@@ -297,11 +442,22 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 
-			ctx = lsctx.WithModuleWalker(ctx, svc.walker)
-			ctx = lsctx.WithModuleManager(ctx, svc.modMgr)
-			ctx = lsctx.WithWatcher(ctx, svc.watcher)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithRootDirectory(ctx, &rootDir)
 
-			return handle(ctx, req, lh.DidChangeWorkspaceFolders)
+			return handle(ctx, req, svc.DidChangeWorkspaceFolders)
+		},
+		"workspace/didChangeConfiguration": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithRootDirectory(ctx, &rootDir)
+			ctx = lsctx.WithExperimentalFeatures(ctx, &expFeatures)
+
+			return handle(ctx, req, svc.DidChangeConfiguration)
 		},
 		"textDocument/references": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -313,21 +469,46 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			return handle(ctx, req, svc.References)
 		},
+		"textDocument/prepareRename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.PrepareRename)
+		},
+		"textDocument/rename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.Rename)
+		},
 		"workspace/executeCommand": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
 				return nil, err
 			}
 
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithFilesystem(ctx, svc.fs)
 			ctx = lsctx.WithCommandPrefix(ctx, &commandPrefix)
+			ctx = lsctx.WithFolderCommandPrefixes(ctx, svc.folderCommandPrefixes())
 			ctx = lsctx.WithModuleManager(ctx, svc.modMgr)
 			ctx = lsctx.WithModuleFinder(ctx, svc.modMgr)
 			ctx = lsctx.WithModuleWalker(ctx, svc.walker)
 			ctx = lsctx.WithWatcher(ctx, svc.watcher)
 			ctx = lsctx.WithRootDirectory(ctx, &rootDir)
 			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
-			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
+			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts.Get())
 			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
+			ctx = module.WithVersionResolver(ctx, module.NewVersionResolver(svc.modStore))
 
 			return handle(ctx, req, lh.WorkspaceExecuteCommand)
 		},
@@ -382,56 +563,18 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 }
 
 func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *settings.Options) error {
-	// The following is set via CLI flags, hence available in the server context
-	execOpts := &exec.ExecutorOpts{}
-	cliExecPath, ok := lsctx.TerraformExecPath(svc.srvCtx)
-	if ok {
-		if len(cfgOpts.TerraformExecPath) > 0 {
-			return fmt.Errorf("Terraform exec path can either be set via (-tf-exec) CLI flag " +
-				"or (terraformExecPath) LSP config option, not both")
-		}
-		execOpts.ExecPath = cliExecPath
-	} else if len(cfgOpts.TerraformExecPath) > 0 {
-		execOpts.ExecPath = cfgOpts.TerraformExecPath
-	} else {
-		path, err := svc.tfDiscoFunc()
-		if err == nil {
-			execOpts.ExecPath = path
-		}
-	}
-	svc.srvCtx = lsctx.WithTerraformExecPath(svc.srvCtx, execOpts.ExecPath)
-
-	path, ok := lsctx.TerraformExecLogPath(svc.srvCtx)
-	if ok {
-		if len(cfgOpts.TerraformLogFilePath) > 0 {
-			return fmt.Errorf("Terraform log file path can either be set via (-tf-log-file) CLI flag " +
-				"or (terraformLogFilePath) LSP config option, not both")
-		}
-		execOpts.ExecLogPath = path
-	} else if len(cfgOpts.TerraformLogFilePath) > 0 {
-		execOpts.ExecLogPath = cfgOpts.TerraformLogFilePath
-	}
-
-	timeout, ok := lsctx.TerraformExecTimeout(svc.srvCtx)
-	if ok {
-		if len(cfgOpts.TerraformExecTimeout) > 0 {
-			return fmt.Errorf("Terraform exec timeout can either be set via (-tf-exec-timeout) CLI flag " +
-				"or (terraformExecTimeout) LSP config option, not both")
-		}
-		execOpts.Timeout = timeout
-	} else if len(cfgOpts.TerraformExecTimeout) > 0 {
-		d, err := time.ParseDuration(cfgOpts.TerraformExecTimeout)
-		if err != nil {
-			return fmt.Errorf("Failed to parse terraformExecTimeout LSP config option: %s", err)
-		}
-		execOpts.Timeout = d
+	execOpts, err := svc.executorOpts(cfgOpts)
+	if err != nil {
+		return err
 	}
 
 	svc.diagsNotifier = diagnostics.NewNotifier(svc.server, svc.logger)
 
-	svc.tfExecOpts = execOpts
+	// executor options may be replaced when settings change,
+	// so module operations always read the current snapshot
+	svc.tfExecOpts = exec.NewSharedExecutorOpts(execOpts)
 
-	svc.sessCtx = exec.WithExecutorOpts(svc.sessCtx, execOpts)
+	svc.sessCtx = exec.WithSharedExecutorOpts(svc.sessCtx, svc.tfExecOpts)
 	svc.sessCtx = exec.WithExecutorFactory(svc.sessCtx, svc.tfExecFactory)
 
 	if svc.stateStore == nil {
@@ -444,13 +587,13 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 
 	svc.stateStore.SetLogger(svc.logger)
 	svc.stateStore.Modules.ChangeHooks = state.ModuleChangeHooks{
-		updateDiagnostics(svc.sessCtx, svc.diagsNotifier),
+		updateDiagnostics(svc.sessCtx, svc.fs, svc.diagsNotifier),
 		sendModuleTelemetry(svc.sessCtx, svc.stateStore, svc.telemetry),
 	}
 
 	cc, err := ilsp.ClientCapabilities(ctx)
 	if err == nil {
-		if _, ok = lsp.ExperimentalClientCapabilities(cc.Experimental).ShowReferencesCommandId(); ok {
+		if _, ok := lsp.ExperimentalClientCapabilities(cc.Experimental).ShowReferencesCommandId(); ok {
 			svc.stateStore.Modules.ChangeHooks = append(svc.stateStore.Modules.ChangeHooks,
 				refreshCodeLens(svc.sessCtx, svc.server))
 		}
@@ -459,6 +602,8 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	svc.modStore = svc.stateStore.Modules
 	svc.schemaStore = svc.stateStore.ProviderSchemas
 
+	svc.sessCtx = module.WithVersionResolver(svc.sessCtx, module.NewVersionResolver(svc.modStore))
+
 	svc.decoder = idecoder.NewDecoder(ctx, &idecoder.PathReader{
 		ModuleReader: svc.modStore,
 		SchemaReader: svc.schemaStore,
@@ -469,6 +614,14 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		return err
 	}
 
+	if svc.schemaCache != nil {
+		err = svc.schemaCache.LoadToStore(svc.stateStore.ProviderSchemas)
+		if err != nil {
+			svc.logger.Printf("failed to load cached schemas: %s", err)
+		}
+		svc.sessCtx = schemas.WithSchemaCache(svc.sessCtx, svc.schemaCache)
+	}
+
 	svc.modMgr = svc.newModuleManager(svc.sessCtx, svc.fs, svc.stateStore.Modules, svc.stateStore.ProviderSchemas)
 	svc.modMgr.SetLogger(svc.logger)
 
@@ -489,6 +642,78 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	return nil
 }
 
+// configOptions returns options set on initialization or updated
+// via workspace/didChangeConfiguration, which must not be modified
+func (svc *service) configOptions() *settings.Options {
+	svc.cfgOptsMu.RLock()
+	defer svc.cfgOptsMu.RUnlock()
+	return svc.cfgOpts
+}
+
+// setConfigOptions replaces options as a whole,
+// leaving any previously obtained options unchanged
+func (svc *service) setConfigOptions(opts *settings.Options) {
+	svc.cfgOptsMu.Lock()
+	defer svc.cfgOptsMu.Unlock()
+	svc.cfgOpts = opts
+}
+
+// executorOpts combines executor options set via CLI flags
+// with those from LSP config options, which may only set
+// options not already set via CLI flags
+func (svc *service) executorOpts(cfgOpts *settings.Options) (*exec.ExecutorOpts, error) {
+	// The following is set via CLI flags, hence available in the server context
+	execOpts := &exec.ExecutorOpts{}
+	cliExecPath, ok := lsctx.TerraformExecPath(svc.srvCtx)
+	if ok {
+		if len(cfgOpts.TerraformExecPath) > 0 {
+			return nil, fmt.Errorf("Terraform exec path can either be set via (-tf-exec) CLI flag " +
+				"or (terraformExecPath) LSP config option, not both")
+		}
+		execOpts.ExecPath = cliExecPath
+		execOpts.ExplicitExecPath = true
+	} else if len(cfgOpts.TerraformExecPath) > 0 {
+		execOpts.ExecPath = cfgOpts.TerraformExecPath
+		execOpts.ExplicitExecPath = true
+	} else {
+		path, err := svc.tfDiscoFunc()
+		if err == nil {
+			execOpts.ExecPath = path
+		}
+	}
+
+	path, ok := lsctx.TerraformExecLogPath(svc.srvCtx)
+	if ok {
+		if len(cfgOpts.TerraformLogFilePath) > 0 {
+			return nil, fmt.Errorf("Terraform log file path can either be set via (-tf-log-file) CLI flag " +
+				"or (terraformLogFilePath) LSP config option, not both")
+		}
+		execOpts.ExecLogPath = path
+	} else if len(cfgOpts.TerraformLogFilePath) > 0 {
+		execOpts.ExecLogPath = cfgOpts.TerraformLogFilePath
+	}
+
+	execOpts.VersionsDir = cfgOpts.TerraformVersionsDir
+	execOpts.LaunchProviderPlugins = cfgOpts.LaunchProviderPlugins
+
+	timeout, ok := lsctx.TerraformExecTimeout(svc.srvCtx)
+	if ok {
+		if len(cfgOpts.TerraformExecTimeout) > 0 {
+			return nil, fmt.Errorf("Terraform exec timeout can either be set via (-tf-exec-timeout) CLI flag " +
+				"or (terraformExecTimeout) LSP config option, not both")
+		}
+		execOpts.Timeout = timeout
+	} else if len(cfgOpts.TerraformExecTimeout) > 0 {
+		d, err := time.ParseDuration(cfgOpts.TerraformExecTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse terraformExecTimeout LSP config option: %s", err)
+		}
+		execOpts.Timeout = d
+	}
+
+	return execOpts, nil
+}
+
 func (svc *service) setupTelemetry(version int, notifier session.ClientNotifier) error {
 	t, err := telemetry.NewSender(version, notifier)
 	if err != nil {
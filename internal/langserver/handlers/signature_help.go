@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func (svc *service) TextDocumentSignatureHelp(ctx context.Context, params lsp.SignatureHelpParams) (*lsp.SignatureHelp, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := doc.Text()
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	call, ok := functions.CallAtPos(text, doc.Filename(), fPos.Position())
+	if !ok {
+		return nil, nil
+	}
+
+	sig, ok := svc.functionsForDocument(doc)[call.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	return ilsp.SignatureHelp(sig, call.ArgIndex), nil
+}
+
+// functionsForDocument returns signatures of functions available
+// in the Terraform version used by the module of the document
+func (svc *service) functionsForDocument(doc filesystem.Document) map[string]*functions.Signature {
+	if doc.LanguageID() != ilsp.Terraform.String() {
+		// function calls are not allowed in variable files
+		return map[string]*functions.Signature{}
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir())
+	if err != nil {
+		return functions.UniversalFunctions()
+	}
+	return functions.Functions(mod.TerraformVersion)
+}
@@ -31,11 +31,15 @@ type langServerMock struct {
 	srvStdin  io.Reader
 	srvStdout io.WriteCloser
 
-	client       *jrpc2.Client
-	clientStdin  io.Reader
-	clientStdout io.WriteCloser
+	client         *jrpc2.Client
+	clientStdin    io.Reader
+	clientStdout   io.WriteCloser
+	clientCallback ClientCallbackFunc
 }
 
+// ClientCallbackFunc handles requests sent from the server to the client
+type ClientCallbackFunc func(ctx context.Context, req *jrpc2.Request) (interface{}, error)
+
 func NewLangServerMock(t *testing.T, sf session.SessionFactory) *langServerMock {
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
@@ -94,7 +98,9 @@ func (lsm *langServerMock) Start(t *testing.T) context.CancelFunc {
 	}()
 
 	clientCh := channel.LSP(lsm.clientStdin, lsm.clientStdout)
-	opts := &jrpc2.ClientOptions{}
+	opts := &jrpc2.ClientOptions{
+		OnCallback: lsm.clientCallback,
+	}
 	if testing.Verbose() {
 		opts.Logger = jrpc2.StdLogger(testLogger(os.Stdout, "[CLIENT] "))
 	}
@@ -103,6 +109,13 @@ func (lsm *langServerMock) Start(t *testing.T) context.CancelFunc {
 	return lsm.Stop
 }
 
+// OnClientCallback sets the handler for requests sent from the server
+// to the client, such as workspace/configuration.
+// It has to be called before Start.
+func (lsm *langServerMock) OnClientCallback(fn ClientCallbackFunc) {
+	lsm.clientCallback = fn
+}
+
 func (lsm *langServerMock) CloseClientStdout(t *testing.T) {
 	err := lsm.clientStdout.Close()
 	if err != nil {
@@ -30,8 +30,20 @@ var (
 	// We do not register this for terraform to allow fine grained selection of actions.
 	// A user should be able to set `source.formatAll` to true, and source.formatAll.terraform to false to allow all
 	// files to be formatted, but not terraform files (or vice versa).
+
+	// `quickfix`: Quick fixes are attached to diagnostics they fix,
+	// such as declaring an input variable which is referenced but not declared.
+
+	// `refactor.extract`: Extracts code into a new declaration,
+	// such as extracting an expression into a local value.
+
+	// `refactor.rewrite`: Rewrites change code without fixing a particular diagnostic,
+	// such as filling in required fields of a block.
 	SupportedCodeActions = CodeActions{
 		SourceFormatAllTerraform: true,
+		lsp.QuickFix:             true,
+		lsp.RefactorExtract:      true,
+		lsp.RefactorRewrite:      true,
 	}
 )
 
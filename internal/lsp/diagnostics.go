@@ -40,3 +40,15 @@ func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
 	}
 	return diags
 }
+
+// UnnecessaryHCLDiagsToLSP converts diagnostics about unused
+// or otherwise unnecessary configuration, which are reported
+// to the client as hints with the Unnecessary tag.
+func UnnecessaryHCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
+	diags := HCLDiagsToLSP(hclDiags, source)
+	for i := range diags {
+		diags[i].Severity = lsp.SeverityHint
+		diags[i].Tags = []lsp.DiagnosticTag{lsp.Unnecessary}
+	}
+	return diags
+}
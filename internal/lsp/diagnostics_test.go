@@ -3,7 +3,9 @@ package lsp
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
 func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
@@ -26,3 +28,24 @@ func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
 		t.Fatal("diags should not be nil")
 	}
 }
+
+func TestUnnecessaryHCLDiagsToLSP(t *testing.T) {
+	diags := UnnecessaryHCLDiagsToLSP(hcl.Diagnostics{
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "Unused input variable",
+		},
+	}, "source")
+
+	expectedDiags := []lsp.Diagnostic{
+		{
+			Severity: lsp.SeverityHint,
+			Tags:     []lsp.DiagnosticTag{lsp.Unnecessary},
+			Source:   "source",
+			Message:  "Unused input variable",
+		},
+	}
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
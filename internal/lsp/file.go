@@ -62,10 +62,11 @@ func (f *file) Version() int {
 }
 
 func FileFromDocumentItem(doc lsp.TextDocumentItem) *file {
+	fh := FileHandlerFromDocumentURI(doc.URI)
 	return &file{
-		fh:         FileHandlerFromDocumentURI(doc.URI),
+		fh:         fh,
 		text:       []byte(doc.Text),
 		version:    int(doc.Version),
-		languageID: doc.LanguageID,
+		languageID: languageIdForFile(doc.LanguageID, fh.Filename()),
 	}
 }
@@ -0,0 +1,66 @@
+package lsp
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/functions"
+	"github.com/hashicorp/terraform-ls/internal/mdplain"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// SignatureHelp returns signature help for the given function
+// with the parameter corresponding to argIdx marked as active
+func SignatureHelp(sig *functions.Signature, argIdx int) *lsp.SignatureHelp {
+	labels := sig.ParamLabels()
+	params := make([]lsp.ParameterInformation, 0, len(labels))
+	for i, p := range sig.AllParams() {
+		params = append(params, lsp.ParameterInformation{
+			Label:         labels[i],
+			Documentation: p.Description,
+		})
+	}
+
+	// an index out of range means no parameter is active,
+	// e.g. when there are more arguments than parameters
+	activeParam := uint32(len(params))
+	if idx, ok := sig.ParamIndex(argIdx); ok {
+		activeParam = uint32(idx)
+	}
+
+	return &lsp.SignatureHelp{
+		Signatures: []lsp.SignatureInformation{
+			{
+				Label:           sig.Label(),
+				Documentation:   sig.Description,
+				Parameters:      params,
+				ActiveParameter: activeParam,
+			},
+		},
+		ActiveSignature: 0,
+		ActiveParameter: activeParam,
+	}
+}
+
+// FunctionCompletionItem returns a completion item for the given function
+// which replaces the given range (typically the already typed prefix)
+func FunctionCompletionItem(sig *functions.Signature, rng hcl.Range, caps lsp.CompletionClientCapabilities) lsp.CompletionItem {
+	snippetSupport := caps.CompletionItem.SnippetSupport
+
+	newText := fmt.Sprintf("%s()", sig.Name)
+	if snippetSupport {
+		newText = fmt.Sprintf("%s(${1})", sig.Name)
+	}
+
+	return lsp.CompletionItem{
+		Label:            sig.Name,
+		Kind:             lsp.FunctionCompletion,
+		InsertTextFormat: insertTextFormat(snippetSupport),
+		Detail:           sig.Label(),
+		Documentation:    mdplain.Clean(sig.Description),
+		TextEdit: &lsp.TextEdit{
+			NewText: newText,
+			Range:   HCLRangeToLSP(rng),
+		},
+	}
+}
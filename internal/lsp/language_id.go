@@ -1,5 +1,7 @@
 package lsp
 
+import "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+
 // LanguageID represents the coding language
 // of a file
 type LanguageID string
@@ -12,3 +14,21 @@ const (
 func (l LanguageID) String() string {
 	return string(l)
 }
+
+// languageIdForFile returns language ID to use for a file
+// which clients typically open as plain JSON
+// (e.g. generated *.tf.json or *.tfvars.json files)
+func languageIdForFile(languageId, filename string) string {
+	if languageId != "json" {
+		return languageId
+	}
+
+	if ast.IsModuleFilename(filename) {
+		return Terraform.String()
+	}
+	if ast.IsVarsFilename(filename) {
+		return Tfvars.String()
+	}
+
+	return languageId
+}
@@ -53,3 +53,28 @@ func HCLPosToLSP(pos hcl.Pos) lsp.Position {
 		Character: uint32(pos.Column - 1),
 	}
 }
+
+func HCLRangeFromLspRange(rng lsp.Range, f File) (hcl.Range, error) {
+	startByte, err := filesystem.ByteOffsetForPos(f.Lines(), lspPosToFsPos(rng.Start))
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	endByte, err := filesystem.ByteOffsetForPos(f.Lines(), lspPosToFsPos(rng.End))
+	if err != nil {
+		return hcl.Range{}, err
+	}
+
+	return hcl.Range{
+		Filename: f.Filename(),
+		Start: hcl.Pos{
+			Line:   int(rng.Start.Line) + 1,
+			Column: int(rng.Start.Character) + 1,
+			Byte:   startByte,
+		},
+		End: hcl.Pos{
+			Line:   int(rng.End.Line) + 1,
+			Column: int(rng.End.Character) + 1,
+			Byte:   endByte,
+		},
+	}, nil
+}
@@ -17,3 +17,46 @@ func (c SemanticTokensClientCapabilities) FullRequest() bool {
 	}
 	return false
 }
+
+func (c SemanticTokensClientCapabilities) FullDeltaRequest() bool {
+	full, ok := c.Requests.Full.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	delta, ok := full["delta"].(bool)
+	return ok && delta
+}
+
+func (c SemanticTokensClientCapabilities) RangeRequest() bool {
+	return c.Requests.Range
+}
+
+// SemanticTokensEdits returns edits which transform previously
+// encoded tokens into the current ones. Any difference is represented
+// by a single edit replacing everything in between the common prefix
+// and suffix, which is typically small as a result of typing.
+func SemanticTokensEdits(previous, current []uint32) []lsp.SemanticTokensEdit {
+	prefix := 0
+	for prefix < len(previous) && prefix < len(current) &&
+		previous[prefix] == current[prefix] {
+		prefix++
+	}
+
+	if prefix == len(previous) && prefix == len(current) {
+		return []lsp.SemanticTokensEdit{}
+	}
+
+	suffix := 0
+	for suffix < len(previous)-prefix && suffix < len(current)-prefix &&
+		previous[len(previous)-1-suffix] == current[len(current)-1-suffix] {
+		suffix++
+	}
+
+	return []lsp.SemanticTokensEdit{
+		{
+			Start:       uint32(prefix),
+			DeleteCount: uint32(len(previous) - prefix - suffix),
+			Data:        current[prefix : len(current)-suffix],
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func TestSemanticTokensEdits(t *testing.T) {
+	testCases := []struct {
+		name          string
+		previous      []uint32
+		current       []uint32
+		expectedEdits []lsp.SemanticTokensEdit
+	}{
+		{
+			"no change",
+			[]uint32{0, 0, 8, 0, 0, 0, 9, 6, 1, 2},
+			[]uint32{0, 0, 8, 0, 0, 0, 9, 6, 1, 2},
+			[]lsp.SemanticTokensEdit{},
+		},
+		{
+			"changed token",
+			[]uint32{0, 0, 8, 0, 0, 0, 9, 6, 1, 2, 1, 2, 3, 1, 0},
+			[]uint32{0, 0, 8, 0, 0, 0, 9, 7, 1, 2, 1, 2, 3, 1, 0},
+			[]lsp.SemanticTokensEdit{
+				{Start: 7, DeleteCount: 1, Data: []uint32{7}},
+			},
+		},
+		{
+			"appended token",
+			[]uint32{0, 0, 8, 0, 0},
+			[]uint32{0, 0, 8, 0, 0, 2, 0, 4, 1, 0},
+			[]lsp.SemanticTokensEdit{
+				{Start: 5, DeleteCount: 0, Data: []uint32{2, 0, 4, 1, 0}},
+			},
+		},
+		{
+			"removed token",
+			[]uint32{0, 0, 8, 0, 0, 2, 0, 4, 1, 0, 1, 0, 3, 1, 0},
+			[]uint32{0, 0, 8, 0, 0, 1, 0, 3, 1, 0},
+			[]lsp.SemanticTokensEdit{
+				{Start: 5, DeleteCount: 5, Data: []uint32{}},
+			},
+		},
+		{
+			"all tokens removed",
+			[]uint32{0, 0, 8, 0, 0},
+			[]uint32{},
+			[]lsp.SemanticTokensEdit{
+				{Start: 0, DeleteCount: 5, Data: []uint32{}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			edits := SemanticTokensEdits(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.expectedEdits, edits); diff != "" {
+				t.Fatalf("unexpected edits: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/structure"
+)
+
+func FoldingRanges(ranges []structure.FoldingRange) []lsp.FoldingRange {
+	result := make([]lsp.FoldingRange, 0, len(ranges))
+	for _, rng := range ranges {
+		kind := ""
+		if rng.Kind == structure.FoldingComment {
+			kind = string(lsp.Comment)
+		}
+		result = append(result, lsp.FoldingRange{
+			StartLine: uint32(rng.StartLine - 1),
+			EndLine:   uint32(rng.EndLine - 1),
+			Kind:      kind,
+		})
+	}
+	return result
+}
+
+// SelectionRange returns the given ranges (innermost first)
+// as a selection range linked to its parents
+func SelectionRange(ranges []hcl.Range) lsp.SelectionRange {
+	var parent *lsp.SelectionRange
+	for i := len(ranges) - 1; i > 0; i-- {
+		parent = &lsp.SelectionRange{
+			Range:  HCLRangeToLSP(ranges[i]),
+			Parent: parent,
+		}
+	}
+
+	if len(ranges) == 0 {
+		return lsp.SelectionRange{}
+	}
+	return lsp.SelectionRange{
+		Range:  HCLRangeToLSP(ranges[0]),
+		Parent: parent,
+	}
+}
@@ -2,33 +2,131 @@ package lsp
 
 import (
 	"bytes"
+	"sort"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/source"
 )
 
 type TokenEncoder struct {
-	Lines      source.Lines
-	Tokens     []lang.SemanticToken
+	Lines  source.Lines
+	Tokens []lang.SemanticToken
+	// ExtraTokens are tokens classified by the language server itself.
+	// They take precedence over generic traversal steps in Tokens
+	// at the same range and are ignored if they overlap other Tokens.
+	ExtraTokens []SemanticToken
+	// Range limits encoded tokens to those overlapping with it, if set
+	Range      *hcl.Range
 	ClientCaps lsp.SemanticTokensClientCapabilities
 }
 
+// SemanticToken represents a token classified
+// using LSP token types and modifiers
+type SemanticToken struct {
+	Type      TokenType
+	Modifiers TokenModifiers
+	Range     hcl.Range
+}
+
+// tokenTypeFallbacks maps token types to more generic ones,
+// in order of preference, for clients which don't support them
+var tokenTypeFallbacks = map[TokenType][]TokenType{
+	TokenTypeClass:     {TokenTypeStruct, TokenTypeVariable},
+	TokenTypeStruct:    {TokenTypeClass, TokenTypeVariable},
+	TokenTypeNamespace: {TokenTypeVariable},
+	TokenTypeParameter: {TokenTypeVariable},
+}
+
 func (te *TokenEncoder) Encode() []uint32 {
 	data := make([]uint32, 0)
 
-	for i := range te.Tokens {
-		data = append(data, te.encodeTokenOfIndex(i)...)
+	tokens := te.supportedTokens()
+	for i := range tokens {
+		data = append(data, te.encodeTokenOfIndex(tokens, i)...)
 	}
 
 	return data
 }
 
-func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
-	token := te.Tokens[i]
+// supportedTokens returns all tokens sorted by their position,
+// with types and modifiers supported by the client
+func (te *TokenEncoder) supportedTokens() []SemanticToken {
+	extraRanges := make(map[hcl.Range]bool, len(te.ExtraTokens))
+	for _, token := range te.ExtraTokens {
+		extraRanges[token.Range] = true
+	}
 
+	candidates := make([]SemanticToken, 0, len(te.Tokens)+len(te.ExtraTokens))
+	isExtra := make([]bool, 0, cap(candidates))
+	for _, token := range te.Tokens {
+		if token.Type == lang.TokenTraversalStep && extraRanges[token.Range] {
+			continue
+		}
+		candidates = append(candidates, semanticTokenFromLang(token))
+		isExtra = append(isExtra, false)
+	}
+	for _, token := range te.ExtraTokens {
+		candidates = append(candidates, token)
+		isExtra = append(isExtra, true)
+	}
+
+	idx := make([]int, len(candidates))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return candidates[idx[i]].Range.Start.Byte < candidates[idx[j]].Range.Start.Byte
+	})
+
+	// overlapping tokens are not allowed, so we prefer tokens
+	// reported by hcl-lang, including those not supported below
+	merged := make([]SemanticToken, 0, len(candidates))
+	lastIsExtra := false
+	for _, i := range idx {
+		token := candidates[i]
+		overlaps := len(merged) > 0 && merged[len(merged)-1].Range.End.Byte > token.Range.Start.Byte
+		if overlaps && (lastIsExtra || isExtra[i]) {
+			if lastIsExtra && !isExtra[i] {
+				merged[len(merged)-1] = token
+				lastIsExtra = false
+			}
+			continue
+		}
+		merged = append(merged, token)
+		lastIsExtra = isExtra[i]
+	}
+
+	if te.Range != nil {
+		merged = tokensInRange(merged, *te.Range)
+	}
+
+	tokens := make([]SemanticToken, 0, len(merged))
+	for _, token := range merged {
+		token, ok := te.supportedToken(token)
+		if !ok {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+func tokensInRange(tokens []SemanticToken, rng hcl.Range) []SemanticToken {
+	inRange := make([]SemanticToken, 0)
+	for _, token := range tokens {
+		if token.Range.End.Byte <= rng.Start.Byte || token.Range.Start.Byte >= rng.End.Byte {
+			continue
+		}
+		inRange = append(inRange, token)
+	}
+	return inRange
+}
+
+func semanticTokenFromLang(token lang.SemanticToken) SemanticToken {
 	var tokenType TokenType
-	modifiers := make([]TokenModifier, 0)
 
 	switch token.Type {
 	case lang.TokenBlockType:
@@ -51,33 +149,63 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 		tokenType = TokenTypeVariable
 	case lang.TokenTraversalStep:
 		tokenType = TokenTypeVariable
-
-	default:
-		return []uint32{}
-	}
-
-	if !te.tokenTypeSupported(tokenType) {
-		return []uint32{}
 	}
 
-	tokenTypeIdx := TokenTypesLegend(te.ClientCaps.TokenTypes).Index(tokenType)
-
+	modifiers := make(TokenModifiers, 0)
 	for _, m := range token.Modifiers {
 		switch m {
 		case lang.TokenModifierDependent:
-			if !te.tokenModifierSupported(TokenModifierModification) {
-				continue
-			}
 			modifiers = append(modifiers, TokenModifierModification)
 		case lang.TokenModifierDeprecated:
-			if !te.tokenModifierSupported(TokenModifierDeprecated) {
-				continue
-			}
 			modifiers = append(modifiers, TokenModifierDeprecated)
 		}
 	}
 
-	modifierBitMask := TokenModifiersLegend(te.ClientCaps.TokenModifiers).BitMask(modifiers)
+	return SemanticToken{
+		Type:      tokenType,
+		Modifiers: modifiers,
+		Range:     token.Range,
+	}
+}
+
+// supportedToken returns the token with a type supported by the client
+// (falling back to a more generic type if necessary) and without
+// any unsupported modifiers
+func (te *TokenEncoder) supportedToken(token SemanticToken) (SemanticToken, bool) {
+	if token.Type == "" {
+		return token, false
+	}
+
+	if !te.tokenTypeSupported(token.Type) {
+		supported := false
+		for _, fallback := range tokenTypeFallbacks[token.Type] {
+			if te.tokenTypeSupported(fallback) {
+				token.Type = fallback
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return token, false
+		}
+	}
+
+	modifiers := make(TokenModifiers, 0)
+	for _, m := range token.Modifiers {
+		if te.tokenModifierSupported(m) {
+			modifiers = append(modifiers, m)
+		}
+	}
+	token.Modifiers = modifiers
+
+	return token, true
+}
+
+func (te *TokenEncoder) encodeTokenOfIndex(tokens []SemanticToken, i int) []uint32 {
+	token := tokens[i]
+
+	tokenTypeIdx := TokenTypesLegend(te.ClientCaps.TokenTypes).Index(token.Type)
+	modifierBitMask := TokenModifiersLegend(te.ClientCaps.TokenModifiers).BitMask(token.Modifiers)
 
 	data := make([]uint32, 0)
 
@@ -92,10 +220,10 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 	previousLine := 0
 	previousStartChar := 0
 	if i > 0 {
-		previousLine = te.Tokens[i-1].Range.End.Line - 1
-		currentLine := te.Tokens[i].Range.End.Line - 1
+		previousLine = tokens[i-1].Range.End.Line - 1
+		currentLine := tokens[i].Range.End.Line - 1
 		if currentLine == previousLine {
-			previousStartChar = te.Tokens[i-1].Range.Start.Column - 1
+			previousStartChar = tokens[i-1].Range.Start.Column - 1
 		}
 	}
 
@@ -336,3 +336,164 @@ func TestTokenEncoder_unsupported(t *testing.T) {
 			expectedData, data)
 	}
 }
+
+func TestTokenEncoder_extraTokens(t *testing.T) {
+	bytes := []byte(`myblock "mytype" {
+  str_attr = var.foo # note
+}`)
+	te := &TokenEncoder{
+		Lines: source.MakeSourceLines("test.tf", bytes),
+		Tokens: []lang.SemanticToken{
+			{
+				Type: lang.TokenBlockType,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				},
+			},
+			{
+				Type: lang.TokenBlockLabel,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+				},
+			},
+			{
+				Type: lang.TokenAttrName,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 21},
+					End:      hcl.Pos{Line: 2, Column: 11, Byte: 29},
+				},
+			},
+			{
+				Type: lang.TokenTraversalStep,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 14, Byte: 32},
+					End:      hcl.Pos{Line: 2, Column: 17, Byte: 35},
+				},
+			},
+			{
+				Type: lang.TokenTraversalStep,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 18, Byte: 36},
+					End:      hcl.Pos{Line: 2, Column: 21, Byte: 39},
+				},
+			},
+		},
+		ExtraTokens: []SemanticToken{
+			{
+				// overlaps with the block label and is ignored
+				Type: TokenTypeFunction,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+					End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+				},
+			},
+			{
+				Type: TokenTypeParameter,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 14, Byte: 32},
+					End:      hcl.Pos{Line: 2, Column: 17, Byte: 35},
+				},
+			},
+			{
+				Type:      TokenTypeParameter,
+				Modifiers: TokenModifiers{TokenModifierReadonly},
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 18, Byte: 36},
+					End:      hcl.Pos{Line: 2, Column: 21, Byte: 39},
+				},
+			},
+			{
+				Type: TokenTypeComment,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 22, Byte: 40},
+					End:      hcl.Pos{Line: 2, Column: 28, Byte: 46},
+				},
+			},
+		},
+		ClientCaps: protocol.SemanticTokensClientCapabilities{
+			// parameter is not supported, so variable is used instead
+			TokenTypes:     []string{"type", "string", "property", "variable", "function", "comment"},
+			TokenModifiers: []string{"readonly"},
+		},
+	}
+	data := te.Encode()
+	expectedData := []uint32{
+		0, 0, 7, 0, 0,
+		0, 8, 8, 1, 0,
+		1, 2, 8, 2, 0,
+		0, 11, 3, 3, 0,
+		0, 4, 3, 3, 1,
+		0, 4, 6, 5, 0,
+	}
+
+	if diff := cmp.Diff(expectedData, data); diff != "" {
+		t.Fatalf("unexpected encoded data.\nexpected: %#v\ngiven:    %#v",
+			expectedData, data)
+	}
+}
+
+func TestTokenEncoder_range(t *testing.T) {
+	bytes := []byte(`myblock "mytype" {
+  str_attr = "foo" # note
+}`)
+	te := &TokenEncoder{
+		Lines: source.MakeSourceLines("test.tf", bytes),
+		Tokens: []lang.SemanticToken{
+			{
+				Type: lang.TokenBlockType,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				},
+			},
+			{
+				Type: lang.TokenAttrName,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 21},
+					End:      hcl.Pos{Line: 2, Column: 11, Byte: 29},
+				},
+			},
+		},
+		ExtraTokens: []SemanticToken{
+			{
+				Type: TokenTypeComment,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 20, Byte: 38},
+					End:      hcl.Pos{Line: 2, Column: 26, Byte: 44},
+				},
+			},
+		},
+		Range: &hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2, Column: 1, Byte: 19},
+			End:      hcl.Pos{Line: 3, Column: 1, Byte: 45},
+		},
+		ClientCaps: protocol.SemanticTokensClientCapabilities{
+			TokenTypes: []string{"type", "property", "comment"},
+		},
+	}
+	data := te.Encode()
+	expectedData := []uint32{
+		1, 2, 8, 1, 0,
+		0, 17, 6, 2, 0,
+	}
+
+	if diff := cmp.Diff(expectedData, data); diff != "" {
+		t.Fatalf("unexpected encoded data.\nexpected: %#v\ngiven:    %#v",
+			expectedData, data)
+	}
+}
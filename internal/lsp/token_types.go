@@ -109,10 +109,17 @@ var (
 		TokenTypeNumber,
 		TokenTypeParameter,
 		TokenTypeVariable,
+		TokenTypeClass,
+		TokenTypeStruct,
+		TokenTypeNamespace,
+		TokenTypeFunction,
+		TokenTypeOperator,
+		TokenTypeComment,
 	}
 	serverTokenModifiers = TokenModifiers{
 		TokenModifierDeprecated,
 		TokenModifierModification,
+		TokenModifierReadonly,
 	}
 )
 
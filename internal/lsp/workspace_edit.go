@@ -0,0 +1,85 @@
+package lsp
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+func WorkspaceEdit(fileEdits map[string][]lang.TextEdit) lsp.WorkspaceEdit {
+	changes := make(map[string][]lsp.TextEdit, len(fileEdits))
+
+	for path, edits := range fileEdits {
+		changes[uri.FromPath(path)] = textEdits(edits, false)
+	}
+
+	return lsp.WorkspaceEdit{
+		Changes: changes,
+	}
+}
+
+// ClientSupportsFileCreation reports whether the client
+// can create files via resource operations of a workspace edit
+func ClientSupportsFileCreation(cc lsp.ClientCapabilities) bool {
+	wec := cc.Workspace.WorkspaceEdit
+	if wec == nil || !wec.DocumentChanges {
+		return false
+	}
+	for _, kind := range wec.ResourceOperations {
+		if kind == lsp.Create {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkspaceEditWithNewFiles returns a workspace edit creating new files
+// with the given content (keyed by absolute path), along with edits
+// of existing files. Existing files are not overwritten by the client.
+func WorkspaceEditWithNewFiles(newFiles map[string][]byte, fileEdits map[string][]lang.TextEdit) lsp.ResourceOpsWorkspaceEdit {
+	changes := make([]interface{}, 0)
+
+	newPaths := make([]string, 0, len(newFiles))
+	for path := range newFiles {
+		newPaths = append(newPaths, path)
+	}
+	sort.Strings(newPaths)
+	for _, path := range newPaths {
+		fileURI := lsp.DocumentURI(uri.FromPath(path))
+		changes = append(changes, lsp.CreateFile{
+			Kind: string(lsp.Create),
+			URI:  fileURI,
+		}, lsp.UnversionedTextDocumentEdit{
+			TextDocument: lsp.UnversionedTextDocumentIdentifier{
+				TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: fileURI},
+			},
+			Edits: []lsp.TextEdit{
+				{
+					NewText: string(newFiles[path]),
+				},
+			},
+		})
+	}
+
+	paths := make([]string, 0, len(fileEdits))
+	for path := range fileEdits {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		changes = append(changes, lsp.UnversionedTextDocumentEdit{
+			TextDocument: lsp.UnversionedTextDocumentIdentifier{
+				TextDocumentIdentifier: lsp.TextDocumentIdentifier{
+					URI: lsp.DocumentURI(uri.FromPath(path)),
+				},
+			},
+			Edits: textEdits(fileEdits[path], false),
+		})
+	}
+
+	return lsp.ResourceOpsWorkspaceEdit{
+		DocumentChanges: changes,
+	}
+}
@@ -0,0 +1,121 @@
+package protocol
+
+import "encoding/json"
+
+// Inlay hints were introduced in LSP 3.17, which the generated
+// protocol.go (based on gopls v0.7.0) predates.
+
+/**
+ * Inlay hint kinds.
+ *
+ * @since 3.17.0
+ */
+type InlayHintKind float64
+
+const (
+	/**
+	 * An inlay hint that for a type annotation.
+	 */
+	InlayHintKindType InlayHintKind = 1
+	/**
+	 * An inlay hint that is for a parameter.
+	 */
+	InlayHintKindParameter InlayHintKind = 2
+)
+
+/**
+ * A parameter literal used in inlay hint requests.
+ *
+ * @since 3.17.0
+ */
+type InlayHintParams struct {
+	WorkDoneProgressParams
+	/**
+	 * The text document.
+	 */
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	/**
+	 * The document range for which inlay hints should be computed.
+	 */
+	Range Range `json:"range"`
+}
+
+/**
+ * Inlay hint information.
+ *
+ * @since 3.17.0
+ */
+type InlayHint struct {
+	/**
+	 * The position of this hint.
+	 */
+	Position Position `json:"position"`
+	/**
+	 * The label of this hint.
+	 */
+	Label string `json:"label"`
+	/**
+	 * The kind of this hint. Can be omitted in which case the client
+	 * should fall back to a reasonable default.
+	 */
+	Kind InlayHintKind `json:"kind,omitempty"`
+	/**
+	 * The tooltip text when you hover over this item.
+	 */
+	Tooltip string `json:"tooltip,omitempty"`
+	/**
+	 * Render padding before the hint.
+	 */
+	PaddingLeft bool `json:"paddingLeft,omitempty"`
+	/**
+	 * Render padding after the hint.
+	 */
+	PaddingRight bool `json:"paddingRight,omitempty"`
+}
+
+/**
+ * Inlay hint options used during static registration.
+ *
+ * @since 3.17.0
+ */
+type InlayHintOptions struct {
+	WorkDoneProgressOptions
+	/**
+	 * The server provides support to resolve additional
+	 * information for an inlay hint item.
+	 */
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// ServerCapabilities317 extends the generated server capabilities
+// with capabilities introduced in LSP 3.17
+type ServerCapabilities317 struct {
+	ServerCapabilities
+	/**
+	 * The server provides inlay hints.
+	 *
+	 * @since 3.17.0
+	 */
+	InlayHintProvider interface{}/*boolean | InlayHintOptions*/ `json:"inlayHintProvider,omitempty"`
+}
+
+// InitializeResult317 is the result of the initialize request
+// including capabilities introduced in LSP 3.17, which are
+// serialized as part of the generated capabilities
+type InitializeResult317 struct {
+	InitializeResult
+	InlayHintProvider interface{}/*boolean | InlayHintOptions*/ `json:"-"`
+}
+
+func (r InitializeResult317) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Capabilities ServerCapabilities317 `json:"capabilities"`
+		InitializeResult
+	}{
+		Capabilities: ServerCapabilities317{
+			ServerCapabilities: r.Capabilities,
+			InlayHintProvider:  r.InlayHintProvider,
+		},
+		InitializeResult: r.InitializeResult,
+	})
+}
@@ -0,0 +1,53 @@
+package protocol
+
+// The generated WorkspaceEdit (based on gopls v0.7.0) only allows
+// for TextDocumentEdit within document changes, so edits which also
+// create, rename or delete files are represented by the types below.
+
+/**
+ * A workspace edit whose document changes can contain
+ * resource operations, i.e. create, rename and delete file.
+ *
+ * Whether a client supports these is expressed via
+ * `workspace.workspaceEdit.resourceOperations` client capability.
+ */
+type ResourceOpsWorkspaceEdit struct {
+	DocumentChanges []interface{} /*TextDocumentEdit | UnversionedTextDocumentEdit | CreateFile | RenameFile | DeleteFile*/ `json:"documentChanges"`
+}
+
+/**
+ * Describes textual changes on a text document whose version
+ * is unknown, i.e. the content on disk is the truth.
+ *
+ * This is typically a document created via CreateFile
+ * within the same workspace edit.
+ */
+type UnversionedTextDocumentEdit struct {
+	TextDocument UnversionedTextDocumentIdentifier `json:"textDocument"`
+	Edits        []TextEdit                        `json:"edits"`
+}
+
+/**
+ * A text document identifier with `null` version.
+ */
+type UnversionedTextDocumentIdentifier struct {
+	Version *int32/*null*/ `json:"version"`
+	TextDocumentIdentifier
+}
+
+/**
+ * A code action whose edit can contain resource operations.
+ */
+type ResourceOpsCodeAction struct {
+	CodeAction
+	Edit ResourceOpsWorkspaceEdit `json:"edit"`
+}
+
+/**
+ * The parameters passed via a apply workspace edit request,
+ * where the edit can contain resource operations.
+ */
+type ResourceOpsApplyWorkspaceEditParams struct {
+	Label string                   `json:"label,omitempty"`
+	Edit  ResourceOpsWorkspaceEdit `json:"edit"`
+}
@@ -0,0 +1,13 @@
+package refactor
+
+type NoSymbolFoundError struct{}
+
+func (e *NoSymbolFoundError) Error() string {
+	return "no renameable symbol found"
+}
+
+type NoExpressionFoundError struct{}
+
+func (e *NoExpressionFoundError) Error() string {
+	return "no extractable expression found"
+}
@@ -0,0 +1,494 @@
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+const defaultLocalName = "extracted"
+
+// ExtractableExpression represents an expression
+// which can be extracted into a local value
+type ExtractableExpression struct {
+	// ModulePath is the path of the module containing the expression
+	ModulePath string
+
+	// Range is the range of the expression
+	Range hcl.Range
+
+	// Name is a name for the local value derived from the expression,
+	// e.g. from the attribute it is assigned to, which is not declared yet
+	Name string
+}
+
+// ExtractableExpressionAtRange returns the expression within a module file
+// which spans the given range (ignoring any surrounding whitespace),
+// if it can be extracted into a local value.
+//
+// Expressions which refer to values scoped to a block (such as each.key,
+// count.index or iterators of for expressions), or which are placed
+// where local values cannot be referenced (such as variable defaults)
+// are not extractable.
+func ExtractableExpressionAtRange(mr ModuleReader, modPath string, rng hcl.Range) (*ExtractableExpression, error) {
+	mod, err := mr.ModuleByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, ok := extractableExpressionAtRange(mod, rng)
+	if !ok {
+		return nil, &NoExpressionFoundError{}
+	}
+
+	return &ExtractableExpression{
+		ModulePath: mod.Path,
+		Range:      expr.expr.Range(),
+		Name:       uniqueLocalName(mod, expr.derivedName()),
+	}, nil
+}
+
+// ExtractLocal produces edits which declare the given expression
+// as a local value of the given name and replace every structurally
+// identical expression within the module with a reference to it.
+//
+// The local value is added into an existing locals block, preferably
+// within the same file, or into a new locals block placed above
+// the block containing the expression.
+func ExtractLocal(mr ModuleReader, extractable *ExtractableExpression, name string) (FileEdits, error) {
+	if !hclsyntax.ValidIdentifier(name) {
+		return nil, fmt.Errorf("%q is not a valid name", name)
+	}
+
+	mod, err := mr.ModuleByPath(extractable.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+	if localNames(mod)[name] {
+		return nil, fmt.Errorf("local.%s is already declared", name)
+	}
+
+	expr, ok := extractableExpressionAtRange(mod, extractable.Range)
+	if !ok {
+		return nil, &NoExpressionFoundError{}
+	}
+	f := mod.ParsedModuleFiles[ast.ModFilename(extractable.Range.Filename)]
+	exprSrc := extractable.Range.SliceBytes(f.Bytes)
+	exprTokens := significantTokens(exprSrc, extractable.Range)
+
+	// Occurrences within definitions of local values referenced
+	// by the expression are left intact to avoid creating a cycle
+	referencedLocals := make(map[string]bool, 0)
+	for _, traversal := range expr.expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			referencedLocals[attr.Name] = true
+		}
+	}
+
+	edits := make(FileEdits, 0)
+
+	declFilename, declRng, declText, err := localDeclaration(mod, extractable.Range, name, exprSrc)
+	if err != nil {
+		return nil, err
+	}
+	edits.add(filepath.Join(mod.Path, declFilename), declRng, declText)
+
+	for _, filename := range moduleFilenames(mod, "") {
+		f := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, candidate := range extractableExpressions(body) {
+			if referencedLocals[candidate.localName] {
+				continue
+			}
+			rng := candidate.expr.Range()
+			if !tokensEqual(significantTokens(rng.SliceBytes(f.Bytes), rng), exprTokens) {
+				continue
+			}
+			edits.add(filepath.Join(mod.Path, filename), rng, "local."+name)
+		}
+	}
+
+	edits.sort()
+
+	return edits, nil
+}
+
+// localDeclaration returns the file name, range and text
+// of an insertion declaring the local value
+func localDeclaration(mod *state.Module, exprRng hcl.Range, name string, exprSrc []byte) (string, hcl.Range, string, error) {
+	exprTokens, err := hclwriteTokens(exprSrc)
+	if err != nil {
+		return "", hcl.Range{}, "", err
+	}
+
+	wf := hclwrite.NewEmptyFile()
+	wf.Body().AppendNewBlock("locals", nil).Body().SetAttributeRaw(name, exprTokens)
+	blockLines := strings.Split(string(hclwrite.Format(wf.Bytes())), "\n")
+
+	for _, filename := range moduleFilenames(mod, exprRng.Filename) {
+		f := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+
+			// only take the attribute from the formatted block,
+			// which is already indented by one level
+			indent := strings.Repeat(" ", block.TypeRange.Start.Column-1)
+			var text strings.Builder
+			for _, line := range blockLines[1 : len(blockLines)-2] {
+				text.WriteString(indent + line + "\n")
+			}
+
+			rng, newText := insertionBeforeClosingBrace(f.Bytes, block, indent, text.String())
+			return filename, rng, newText, nil
+		}
+	}
+
+	f := mod.ParsedModuleFiles[ast.ModFilename(exprRng.Filename)]
+	body := f.Body.(*hclsyntax.Body)
+	for _, block := range body.Blocks {
+		if !block.Range().Overlaps(exprRng) {
+			continue
+		}
+		lineStart := bytes.LastIndexByte(f.Bytes[:block.Range().Start.Byte], '\n') + 1
+		pos := hcl.Pos{
+			Line:   block.Range().Start.Line,
+			Column: 1,
+			Byte:   lineStart,
+		}
+		return exprRng.Filename, hcl.Range{
+			Filename: exprRng.Filename,
+			Start:    pos,
+			End:      pos,
+		}, strings.Join(blockLines, "\n") + "\n", nil
+	}
+
+	return "", hcl.Range{}, "", fmt.Errorf("no block found for expression at %s", exprRng)
+}
+
+// insertionBeforeClosingBrace returns the range and text
+// to insert lines of text before the closing brace of the block
+func insertionBeforeClosingBrace(src []byte, block *hclsyntax.Block, indent, text string) (hcl.Range, string) {
+	closeBrace := block.CloseBraceRange
+	lineStart := bytes.LastIndexByte(src[:closeBrace.Start.Byte], '\n') + 1
+
+	if len(bytes.TrimSpace(src[lineStart:closeBrace.Start.Byte])) == 0 {
+		// closing brace is on its own line
+		pos := hcl.Pos{
+			Line:   closeBrace.Start.Line,
+			Column: 1,
+			Byte:   lineStart,
+		}
+		return hcl.Range{
+			Filename: closeBrace.Filename,
+			Start:    pos,
+			End:      pos,
+		}, text
+	}
+
+	return hcl.Range{
+		Filename: closeBrace.Filename,
+		Start:    closeBrace.Start,
+		End:      closeBrace.Start,
+	}, "\n" + text + indent
+}
+
+// hclwriteTokens returns tokens of the given expression
+// as used for generating configuration via hclwrite
+func hclwriteTokens(exprSrc []byte) (hclwrite.Tokens, error) {
+	src := make([]byte, 0, len(exprSrc)+5)
+	src = append(src, "v = "...)
+	src = append(src, exprSrc...)
+	src = append(src, '\n')
+
+	wf, diags := hclwrite.ParseConfig(src, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return wf.Body().GetAttribute("v").Expr().BuildTokens(nil), nil
+}
+
+// significantTokens returns tokens of the expression
+// excluding comments and newlines, which do not affect its structure
+func significantTokens(src []byte, rng hcl.Range) hclsyntax.Tokens {
+	tokens, _ := hclsyntax.LexExpression(src, rng.Filename, rng.Start)
+
+	significant := make(hclsyntax.Tokens, 0, len(tokens))
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenComment, hclsyntax.TokenNewline, hclsyntax.TokenEOF:
+			continue
+		}
+		significant = append(significant, token)
+	}
+	return significant
+}
+
+func tokensEqual(a, b hclsyntax.Tokens) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || !bytes.Equal(a[i].Bytes, b[i].Bytes) {
+			return false
+		}
+	}
+	return true
+}
+
+func extractableExpressionAtRange(mod *state.Module, rng hcl.Range) (extractableExpression, bool) {
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(rng.Filename)]
+	if !ok || rng.End.Byte > len(f.Bytes) {
+		return extractableExpression{}, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return extractableExpression{}, false
+	}
+
+	// ignore any whitespace around the selected expression
+	src := f.Bytes[rng.Start.Byte:rng.End.Byte]
+	startByte := rng.Start.Byte + len(src) - len(bytes.TrimLeft(src, " \t\r\n"))
+	endByte := rng.End.Byte - len(src) + len(bytes.TrimRight(src, " \t\r\n"))
+	if startByte >= endByte {
+		return extractableExpression{}, false
+	}
+
+	for _, expr := range extractableExpressions(body) {
+		exprRng := expr.expr.Range()
+		if exprRng.Start.Byte == startByte && exprRng.End.Byte == endByte {
+			return expr, true
+		}
+	}
+
+	return extractableExpression{}, false
+}
+
+// moduleFilenames returns names of all parsed files of the module
+// sorted by name, optionally with the given file first
+func moduleFilenames(mod *state.Module, first string) []string {
+	names := make([]string, 0, len(mod.ParsedModuleFiles))
+	for name := range mod.ParsedModuleFiles {
+		names = append(names, name.String())
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if names[i] == first || names[j] == first {
+			return names[i] == first
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func localNames(mod *state.Module) map[string]bool {
+	names := make(map[string]bool, 0)
+	for _, decl := range declarations(mod) {
+		if decoder.StepName(decl.addr[0]) == "local" {
+			names[decoder.StepName(decl.addr[1])] = true
+		}
+	}
+	return names
+}
+
+// uniqueLocalName returns the given name, or the name with
+// a numeric suffix, if a local value of that name already exists
+func uniqueLocalName(mod *state.Module, name string) string {
+	declared := localNames(mod)
+	if !declared[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		suffixed := fmt.Sprintf("%s_%d", name, i)
+		if !declared[suffixed] {
+			return suffixed
+		}
+	}
+}
+
+type extractableExpression struct {
+	expr hclsyntax.Expression
+
+	// attrName is the name of the attribute
+	// the expression is directly assigned to, if any
+	attrName string
+
+	// localName is the name of the local value
+	// whose definition contains the expression, if any
+	localName string
+}
+
+func (e extractableExpression) derivedName() string {
+	if e.attrName != "" {
+		return e.attrName
+	}
+	if traversal, ok := e.expr.(*hclsyntax.ScopeTraversalExpr); ok {
+		for i := len(traversal.Traversal) - 1; i >= 0; i-- {
+			switch step := traversal.Traversal[i].(type) {
+			case hcl.TraverseAttr:
+				return step.Name
+			case hcl.TraverseRoot:
+				return step.Name
+			}
+		}
+	}
+	return defaultLocalName
+}
+
+// extractableExpressions returns all expressions within the body
+// which can be extracted into a local value
+func extractableExpressions(body *hclsyntax.Body) []extractableExpression {
+	w := &extractableExprWalker{
+		exprs: make([]extractableExpression, 0),
+	}
+	hclsyntax.Walk(body, w)
+	return w.exprs
+}
+
+// Blocks and attributes where local values cannot be referenced,
+// or where expressions are interpreted statically
+var (
+	nonExtractableBlockTypes = map[string]bool{
+		"variable":  true,
+		"terraform": true,
+		"lifecycle": true,
+		"moved":     true,
+	}
+	nonExtractableAttributes = map[string]bool{
+		"depends_on": true,
+		"provider":   true,
+		"providers":  true,
+	}
+	nonExtractableModuleAttributes = map[string]bool{
+		"source":  true,
+		"version": true,
+	}
+)
+
+type extractableExprWalker struct {
+	stack []hclsyntax.Node
+	exprs []extractableExpression
+}
+
+func (w *extractableExprWalker) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	w.stack = append(w.stack, node)
+
+	if expr, ok := node.(hclsyntax.Expression); ok {
+		if extractable, ok := w.extractable(expr); ok {
+			w.exprs = append(w.exprs, extractable)
+		}
+	}
+
+	return nil
+}
+
+func (w *extractableExprWalker) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	w.stack = w.stack[:len(w.stack)-1]
+	return nil
+}
+
+func (w *extractableExprWalker) extractable(expr hclsyntax.Expression) (extractableExpression, bool) {
+	e := extractableExpression{expr: expr}
+
+	switch expr.(type) {
+	case *hclsyntax.ObjectConsKeyExpr, *hclsyntax.AnonSymbolExpr:
+		return e, false
+	}
+
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "count", "each", "self":
+			return e, false
+		}
+	}
+
+	// the expression itself is on top of the stack
+	parentIdx := len(w.stack) - 2
+	if parentIdx < 0 {
+		return e, false
+	}
+
+	for i := parentIdx; i >= 0; i-- {
+		var child hclsyntax.Node
+		if i < len(w.stack)-1 {
+			child = w.stack[i+1]
+		}
+
+		switch n := w.stack[i].(type) {
+		case *hclsyntax.Attribute:
+			block, ok := w.enclosingBlock(i)
+			if !ok {
+				return e, false
+			}
+			if nonExtractableAttributes[n.Name] ||
+				(block.Type == "module" && nonExtractableModuleAttributes[n.Name]) {
+				return e, false
+			}
+			if i == parentIdx {
+				e.attrName = n.Name
+			}
+			if block.Type == "locals" {
+				if i == parentIdx {
+					// whole value of a local is already extracted
+					return e, false
+				}
+				e.localName = n.Name
+			}
+		case *hclsyntax.Block:
+			if nonExtractableBlockTypes[n.Type] {
+				return e, false
+			}
+			if parent, ok := w.enclosingBlock(i); ok && n.Type == "content" && parent.Type == "dynamic" {
+				// content of dynamic blocks may refer to the iterator
+				return e, false
+			}
+		case *hclsyntax.ForExpr:
+			// only the collection is evaluated outside of the for expression
+			if child != n.CollExpr {
+				return e, false
+			}
+		case *hclsyntax.SplatExpr:
+			if child != n.Source {
+				return e, false
+			}
+		case *hclsyntax.TemplateExpr:
+			if _, ok := expr.(*hclsyntax.LiteralValueExpr); ok && i == parentIdx {
+				// literal parts of a template are not expressions on their own
+				return e, false
+			}
+		}
+	}
+
+	return e, true
+}
+
+// enclosingBlock returns the nearest block enclosing
+// the node at the given index of the stack
+func (w *extractableExprWalker) enclosingBlock(idx int) (*hclsyntax.Block, bool) {
+	for i := idx - 1; i >= 0; i-- {
+		if block, ok := w.stack[i].(*hclsyntax.Block); ok {
+			return block, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,512 @@
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExtractedModulesDir is the directory (relative to the module)
+// where extracted modules are placed
+const ExtractedModulesDir = "modules"
+
+// ModuleExtraction represents changes which move blocks
+// of a module into a new child module
+type ModuleExtraction struct {
+	// ModulePath is the absolute path of the new module
+	ModulePath string
+
+	// Files represents content of files of the new module,
+	// keyed by absolute file path
+	Files map[string][]byte
+
+	// Edits represents edits of the original module, which remove
+	// the moved blocks, add the module call along with moved blocks
+	// and update references to the moved blocks
+	Edits FileEdits
+}
+
+// ExtractableBlocksInRange returns addresses of resource and data blocks
+// whose headers overlap with the given range, if there are no other blocks
+// with headers overlapping with the range
+func ExtractableBlocksInRange(mod *state.Module, rng hcl.Range) []lang.Address {
+	addrs := make([]lang.Address, 0)
+
+	for _, mb := range extractableBlocks(mod) {
+		if mb.filename != rng.Filename || !mb.block.DefRange().Overlaps(rng) {
+			continue
+		}
+		addrs = append(addrs, mb.addr)
+	}
+
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(rng.Filename)]
+	if !ok {
+		return addrs
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return addrs
+	}
+	for _, block := range body.Blocks {
+		if block.DefRange().Overlaps(rng) && block.Type != "resource" && block.Type != "data" {
+			return []lang.Address{}
+		}
+	}
+
+	return addrs
+}
+
+// ExtractModule produces changes which move the given resource
+// and data blocks into a new module of the given name, placed
+// in the ExtractedModulesDir directory.
+//
+// References from the moved blocks to the rest of the module
+// become variables of the new module, and references to the moved blocks
+// from the rest of the module become outputs. Moved blocks are generated
+// for all moved resources, so that the state does not need to be modified.
+func ExtractModule(mod *state.Module, addrs []lang.Address, name string) (*ModuleExtraction, error) {
+	moved, err := blocksToExtract(mod, addrs, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(moved, func(i, j int) bool {
+		if moved[i].filename != moved[j].filename {
+			return moved[i].filename < moved[j].filename
+		}
+		return moved[i].block.Range().Start.Byte < moved[j].block.Range().Start.Byte
+	})
+
+	isMoved := func(addr lang.Address) bool {
+		for _, mb := range moved {
+			if mb.addr.Equals(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	movedBlockOf := func(rng hcl.Range) (int, bool) {
+		for i, mb := range moved {
+			blockRng := mb.block.Range()
+			if mb.filename == rng.Filename &&
+				blockRng.Start.Byte <= rng.Start.Byte && rng.End.Byte <= blockRng.End.Byte {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	modPath := filepath.Join(mod.Path, ExtractedModulesDir, name)
+	files := mod.ParsedModuleFiles.AsMap()
+	inputs := newBoundaryNames()
+	outputs := newBoundaryNames()
+	blockEdits := make([][]lang.TextEdit, len(moved))
+	edits := make(FileEdits, 0)
+
+	for _, origin := range mod.RefOrigins {
+		lo, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+		sym, ok := renameableAddress(lo.Addr)
+		if !ok {
+			continue
+		}
+		stepRng, ok := addressStepRange(files, lo.Range, len(sym)-1)
+		if !ok {
+			continue
+		}
+		symRng := hcl.Range{
+			Filename: lo.Range.Filename,
+			Start:    lo.Range.Start,
+			End:      stepRng.End,
+		}
+
+		idx, inside := movedBlockOf(lo.Range)
+		if inside && !isMoved(sym) {
+			varName := inputs.nameFor(sym)
+			blockEdits[idx] = append(blockEdits[idx], lang.TextEdit{
+				Range:   symRng,
+				NewText: "var." + varName,
+			})
+		}
+		if !inside && isMoved(sym) {
+			outputName := outputs.nameFor(sym)
+			edits.add(filepath.Join(mod.Path, symRng.Filename), symRng,
+				fmt.Sprintf("module.%s.%s", name, outputName))
+		}
+	}
+
+	movedTexts := make([]string, len(moved))
+	for i, mb := range moved {
+		src := files[mb.filename].Bytes
+		movedTexts[i] = string(applyEdits(src, mb.block.Range(), blockEdits[i]))
+	}
+
+	mainText := strings.Join(movedTexts, "\n\n") + "\n"
+	if reqText := requiredProvidersText(mod, moved); reqText != "" {
+		mainText = reqText + "\n" + mainText
+	}
+
+	newFiles := make(map[string][]byte, 0)
+	newFiles[filepath.Join(modPath, "main.tf")] = []byte(mainText)
+	if len(inputs.names) > 0 {
+		newFiles[filepath.Join(modPath, "variables.tf")] = variablesFile(mod, inputs)
+	}
+	if len(outputs.names) > 0 {
+		newFiles[filepath.Join(modPath, "outputs.tf")] = outputsFile(outputs)
+	}
+
+	callText, err := moduleCallText(name, inputs, moved)
+	if err != nil {
+		return nil, err
+	}
+	for i, mb := range moved {
+		src := files[mb.filename].Bytes
+		rng, hasBlankLine := wholeLinesRange(src, mb.block.Range())
+		newText := ""
+		if i == 0 {
+			newText = callText
+			if hasBlankLine {
+				newText += "\n"
+			}
+		}
+		edits.add(filepath.Join(mod.Path, mb.filename), rng, newText)
+	}
+	edits.sort()
+
+	return &ModuleExtraction{
+		ModulePath: modPath,
+		Files:      newFiles,
+		Edits:      edits,
+	}, nil
+}
+
+// CanExtractModule checks whether the given resource and data blocks
+// can be extracted into a new module of the given name, without
+// producing any changes, which is cheaper than ExtractModule
+func CanExtractModule(mod *state.Module, addrs []lang.Address, name string) error {
+	_, err := blocksToExtract(mod, addrs, name)
+	return err
+}
+
+// blocksToExtract returns blocks found for the given addresses,
+// if these can be extracted into a new module of the given name
+func blocksToExtract(mod *state.Module, addrs []lang.Address, name string) ([]extractableBlock, error) {
+	if !hclsyntax.ValidIdentifier(name) {
+		return nil, fmt.Errorf("%q is not a valid module name", name)
+	}
+	for _, decl := range declarations(mod) {
+		if decl.addr.Equals(lang.Address{lang.RootStep{Name: "module"}, lang.AttrStep{Name: name}}) {
+			return nil, fmt.Errorf("module.%s is already declared", name)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no blocks to extract")
+	}
+
+	moved := make([]extractableBlock, 0)
+	for _, addr := range addrs {
+		found := false
+		for _, eb := range extractableBlocks(mod) {
+			if !eb.addr.Equals(addr) {
+				continue
+			}
+			if _, ok := eb.block.Body.Attributes["provider"]; ok {
+				return nil, fmt.Errorf("%s: blocks with provider argument cannot be extracted yet", addr)
+			}
+			moved = append(moved, eb)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no resource or data block found for %s", addr)
+		}
+	}
+
+	return moved, nil
+}
+
+type extractableBlock struct {
+	filename string
+	block    *hclsyntax.Block
+	addr     lang.Address
+}
+
+// extractableBlocks returns all resource and data blocks of the module
+func extractableBlocks(mod *state.Module) []extractableBlock {
+	blocks := make([]extractableBlock, 0)
+	for _, filename := range moduleFilenames(mod, "") {
+		body, ok := mod.ParsedModuleFiles[ast.ModFilename(filename)].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if len(block.Labels) != 2 {
+				continue
+			}
+			var addr lang.Address
+			switch block.Type {
+			case "resource":
+				addr = lang.Address{
+					lang.RootStep{Name: block.Labels[0]},
+					lang.AttrStep{Name: block.Labels[1]},
+				}
+			case "data":
+				addr = lang.Address{
+					lang.RootStep{Name: "data"},
+					lang.AttrStep{Name: block.Labels[0]},
+					lang.AttrStep{Name: block.Labels[1]},
+				}
+			default:
+				continue
+			}
+			blocks = append(blocks, extractableBlock{
+				filename: filename,
+				block:    block,
+				addr:     addr,
+			})
+		}
+	}
+	return blocks
+}
+
+// boundaryNames keeps track of names of variables or outputs
+// generated for addresses referenced across the module boundary
+type boundaryNames struct {
+	names map[string]lang.Address
+}
+
+func newBoundaryNames() *boundaryNames {
+	return &boundaryNames{
+		names: make(map[string]lang.Address, 0),
+	}
+}
+
+// nameFor returns a unique name for the address, e.g. vpc_id for var.vpc_id
+// or aws_vpc_main for aws_vpc.main
+func (bn *boundaryNames) nameFor(addr lang.Address) string {
+	for name, a := range bn.names {
+		if a.Equals(addr) {
+			return name
+		}
+	}
+
+	var name string
+	switch decoder.StepName(addr[0]) {
+	case "var", "local":
+		name = decoder.StepName(addr[1])
+	default:
+		steps := make([]string, len(addr))
+		for i, step := range addr {
+			steps[i] = decoder.StepName(step)
+		}
+		name = strings.Join(steps, "_")
+	}
+
+	uniqueName := name
+	for i := 2; ; i++ {
+		if _, exists := bn.names[uniqueName]; !exists {
+			break
+		}
+		uniqueName = fmt.Sprintf("%s_%d", name, i)
+	}
+	bn.names[uniqueName] = addr
+
+	return uniqueName
+}
+
+func (bn *boundaryNames) sortedNames() []string {
+	names := make([]string, 0, len(bn.names))
+	for name := range bn.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// requiredProvidersText returns the terraform block declaring
+// providers of the moved blocks, if declared in the original module
+func requiredProvidersText(mod *state.Module, moved []extractableBlock) string {
+	providers := make(map[string]tfaddr.Provider, 0)
+	for _, mb := range moved {
+		localName := strings.SplitN(mb.block.Labels[0], "_", 2)[0]
+		pAddr, ok := mod.Meta.ProviderReferences[tfmod.ProviderRef{LocalName: localName}]
+		if !ok || pAddr.IsLegacy() {
+			continue
+		}
+		if _, declared := mod.Meta.ProviderRequirements[pAddr]; !declared {
+			continue
+		}
+		providers[localName] = pAddr
+	}
+	if len(providers) == 0 {
+		return ""
+	}
+
+	localNames := make([]string, 0, len(providers))
+	for localName := range providers {
+		localNames = append(localNames, localName)
+	}
+	sort.Strings(localNames)
+
+	wf := hclwrite.NewEmptyFile()
+	body := wf.Body().AppendNewBlock("terraform", nil).Body().
+		AppendNewBlock("required_providers", nil).Body()
+	for _, localName := range localNames {
+		body.SetAttributeValue(localName, cty.ObjectVal(map[string]cty.Value{
+			"source": cty.StringVal(providers[localName].ForDisplay()),
+		}))
+	}
+	return string(hclwrite.Format(wf.Bytes()))
+}
+
+func variablesFile(mod *state.Module, inputs *boundaryNames) []byte {
+	wf := hclwrite.NewEmptyFile()
+	for i, name := range inputs.sortedNames() {
+		if i > 0 {
+			wf.Body().AppendNewline()
+		}
+		body := wf.Body().AppendNewBlock("variable", []string{name}).Body()
+
+		// input variables keep their type and description
+		addr := inputs.names[name]
+		if decoder.StepName(addr[0]) != "var" {
+			continue
+		}
+		v, ok := mod.Meta.Variables[decoder.StepName(addr[1])]
+		if !ok {
+			continue
+		}
+		if v.Type != cty.NilType && v.Type != cty.DynamicPseudoType {
+			tokens, err := hclwriteTokens([]byte(typeexpr.TypeString(v.Type)))
+			if err == nil {
+				body.SetAttributeRaw("type", tokens)
+			}
+		}
+		if v.Description != "" {
+			body.SetAttributeValue("description", cty.StringVal(v.Description))
+		}
+	}
+	return hclwrite.Format(wf.Bytes())
+}
+
+func outputsFile(outputs *boundaryNames) []byte {
+	wf := hclwrite.NewEmptyFile()
+	for i, name := range outputs.sortedNames() {
+		if i > 0 {
+			wf.Body().AppendNewline()
+		}
+		tokens, err := hclwriteTokens([]byte(outputs.names[name].String()))
+		if err != nil {
+			continue
+		}
+		wf.Body().AppendNewBlock("output", []string{name}).Body().SetAttributeRaw("value", tokens)
+	}
+	return hclwrite.Format(wf.Bytes())
+}
+
+// moduleCallText returns the module block calling the new module
+// followed by moved blocks for all moved resources
+func moduleCallText(name string, inputs *boundaryNames, moved []extractableBlock) (string, error) {
+	wf := hclwrite.NewEmptyFile()
+
+	body := wf.Body().AppendNewBlock("module", []string{name}).Body()
+	body.SetAttributeValue("source", cty.StringVal("./"+path.Join(ExtractedModulesDir, name)))
+	if len(inputs.names) > 0 {
+		body.AppendNewline()
+	}
+	for _, varName := range inputs.sortedNames() {
+		tokens, err := hclwriteTokens([]byte(inputs.names[varName].String()))
+		if err != nil {
+			return "", err
+		}
+		body.SetAttributeRaw(varName, tokens)
+	}
+
+	for _, mb := range moved {
+		if mb.block.Type != "resource" {
+			continue
+		}
+		from, err := hclwriteTokens([]byte(mb.addr.String()))
+		if err != nil {
+			return "", err
+		}
+		to, err := hclwriteTokens([]byte(fmt.Sprintf("module.%s.%s", name, mb.addr.String())))
+		if err != nil {
+			return "", err
+		}
+
+		wf.Body().AppendNewline()
+		movedBody := wf.Body().AppendNewBlock("moved", nil).Body()
+		movedBody.SetAttributeRaw("from", from)
+		movedBody.SetAttributeRaw("to", to)
+	}
+
+	return string(hclwrite.Format(wf.Bytes())), nil
+}
+
+// applyEdits returns the source within the given range
+// with edits (all within the range) applied
+func applyEdits(src []byte, rng hcl.Range, edits []lang.TextEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Range.Start.Byte < edits[j].Range.Start.Byte
+	})
+
+	var buf bytes.Buffer
+	offset := rng.Start.Byte
+	for _, edit := range edits {
+		if edit.Range.Start.Byte < offset {
+			// overlapping edit
+			continue
+		}
+		buf.Write(src[offset:edit.Range.Start.Byte])
+		buf.WriteString(edit.NewText)
+		offset = edit.Range.End.Byte
+	}
+	buf.Write(src[offset:rng.End.Byte])
+
+	return buf.Bytes()
+}
+
+// wholeLinesRange extends the range of a block to whole lines,
+// including a single blank line which follows the block, if any
+func wholeLinesRange(src []byte, rng hcl.Range) (hcl.Range, bool) {
+	startByte := bytes.LastIndexByte(src[:rng.Start.Byte], '\n') + 1
+	start := hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: 1,
+		Byte:   startByte,
+	}
+
+	end := rng.End
+	if end.Byte < len(src) && src[end.Byte] == '\n' {
+		end = hcl.Pos{Line: end.Line + 1, Column: 1, Byte: end.Byte + 1}
+	}
+	hasBlankLine := false
+	if end.Byte < len(src) && src[end.Byte] == '\n' {
+		end = hcl.Pos{Line: end.Line + 1, Column: 1, Byte: end.Byte + 1}
+		hasBlankLine = true
+	}
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    start,
+		End:      end,
+	}, hasBlankLine
+}
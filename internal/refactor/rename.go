@@ -0,0 +1,525 @@
+package refactor
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/pathcmp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+type ModuleReader interface {
+	ModuleByPath(modPath string) (*state.Module, error)
+	CallersOfModule(modPath string) ([]*state.Module, error)
+}
+
+// FileEdits represents text edits to apply, keyed by absolute file path
+type FileEdits map[string][]lang.TextEdit
+
+func (fe FileEdits) add(path string, rng hcl.Range, newText string) {
+	for _, edit := range fe[path] {
+		if edit.Range == rng {
+			// avoid duplicate edits, e.g. when an origin
+			// is reachable via multiple targets
+			return
+		}
+	}
+	fe[path] = append(fe[path], lang.TextEdit{
+		Range:   rng,
+		NewText: newText,
+		Snippet: newText,
+	})
+}
+
+func (fe FileEdits) sort() {
+	for _, edits := range fe {
+		sort.SliceStable(edits, func(i, j int) bool {
+			return edits[i].Range.Start.Byte < edits[j].Range.Start.Byte
+		})
+	}
+}
+
+// Symbol represents a renameable named symbol
+// (variable, local value, output, resource, data source or module call)
+type Symbol struct {
+	// ModulePath is the path of the module where the symbol is declared
+	ModulePath string
+
+	// Addr is the address of the symbol, e.g. var.foo or aws_instance.foo.
+	// Outputs (which are not referenceable within their own module)
+	// are addressed as output.<name>.
+	Addr lang.Address
+
+	// NameRange represents range of the name of the symbol
+	// at the position the symbol was found
+	NameRange hcl.Range
+}
+
+// Name returns the current name of the symbol
+func (s *Symbol) Name() string {
+	return decoder.StepName(s.Addr[len(s.Addr)-1])
+}
+
+// SymbolAtPos returns the renameable symbol at the given position,
+// whether the position points to its declaration or to a reference
+func SymbolAtPos(mr ModuleReader, modPath, filename string, pos hcl.Pos) (*Symbol, error) {
+	mod, err := mr.ModuleByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if ast.IsModuleFilename(filename) {
+		origins, _ := mod.RefOrigins.AtPos(filename, pos)
+		for _, origin := range origins {
+			// module input pointing to a variable of the called module
+			o, ok := origin.(reference.PathOrigin)
+			if !ok || len(o.TargetAddr) != 2 || decoder.StepName(o.TargetAddr[0]) != "var" {
+				continue
+			}
+			return &Symbol{
+				ModulePath: o.TargetPath.Path,
+				Addr:       o.TargetAddr,
+				NameRange:  o.Range,
+			}, nil
+		}
+
+		decls := declarations(mod)
+		for _, origin := range localOrigins(mod) {
+			if origin.Range.Filename != filename || !origin.Range.ContainsPos(pos) {
+				continue
+			}
+			addr, ok := renameableAddress(origin.Addr)
+			if !ok || !isDeclared(decls, addr) {
+				continue
+			}
+			rng, ok := addressStepRange(mod.ParsedModuleFiles.AsMap(), origin.Range, len(addr)-1)
+			if !ok {
+				continue
+			}
+			return &Symbol{
+				ModulePath: mod.Path,
+				Addr:       addr,
+				NameRange:  rng,
+			}, nil
+		}
+
+		for _, decl := range decls {
+			if decl.nameRange.Filename == filename && decl.nameRange.ContainsPos(pos) {
+				return &Symbol{
+					ModulePath: mod.Path,
+					Addr:       decl.addr,
+					NameRange:  decl.nameRange,
+				}, nil
+			}
+		}
+	}
+
+	if ast.IsVarsFilename(filename) {
+		for _, origin := range mod.VarsRefOrigins {
+			rng := origin.OriginRange()
+			if rng.Filename != filename || !rng.ContainsPos(pos) {
+				continue
+			}
+			return &Symbol{
+				ModulePath: mod.Path,
+				Addr:       origin.Address(),
+				NameRange:  unquotedRange(varsFileBytes(mod, filename), rng),
+			}, nil
+		}
+	}
+
+	return nil, &NoSymbolFoundError{}
+}
+
+// Rename produces edits which rename the given symbol to newName
+// across its declaration, all references within the module,
+// any assignments in *.tfvars files and any callers of the module
+func Rename(mr ModuleReader, sym *Symbol, newName string) (FileEdits, error) {
+	if !hclsyntax.ValidIdentifier(newName) {
+		return nil, fmt.Errorf("%q is not a valid name", newName)
+	}
+
+	mod, err := mr.ModuleByPath(sym.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if newName == sym.Name() {
+		// renaming to the current name is a no-op,
+		// rather than a conflict with its own declaration
+		return make(FileEdits, 0), nil
+	}
+
+	newAddr := sym.Addr.Copy()
+	newAddr[len(newAddr)-1] = lang.AttrStep{Name: newName}
+
+	for _, decl := range declarations(mod) {
+		if decl.addr.Equals(newAddr) {
+			return nil, fmt.Errorf("%s is already declared", newAddr.String())
+		}
+	}
+
+	edits := make(FileEdits, 0)
+	declFound := false
+	for _, decl := range declarations(mod) {
+		if decl.addr.Equals(sym.Addr) {
+			declFound = true
+			edits.add(filepath.Join(mod.Path, decl.nameRange.Filename), decl.nameRange, newName)
+		}
+	}
+	if !declFound {
+		return nil, fmt.Errorf("no declaration found for %s", sym.Addr.String())
+	}
+
+	if decoder.StepName(sym.Addr[0]) != "output" {
+		stepIdx := len(sym.Addr) - 1
+		files := mod.ParsedModuleFiles.AsMap()
+		for _, origin := range localOrigins(mod) {
+			if !addressHasPrefix(origin.Addr, sym.Addr) {
+				continue
+			}
+			rng, ok := addressStepRange(files, origin.Range, stepIdx)
+			if !ok {
+				continue
+			}
+			edits.add(filepath.Join(mod.Path, rng.Filename), rng, newName)
+		}
+	}
+
+	if decoder.StepName(sym.Addr[0]) == "var" {
+		for _, origin := range mod.VarsRefOrigins {
+			if !origin.Address().Equals(sym.Addr) {
+				continue
+			}
+			rng := origin.OriginRange()
+			rng = unquotedRange(varsFileBytes(mod, rng.Filename), rng)
+			edits.add(filepath.Join(mod.Path, rng.Filename), rng, newName)
+		}
+	}
+
+	callers, err := mr.CallersOfModule(mod.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, caller := range callers {
+		switch decoder.StepName(sym.Addr[0]) {
+		case "var":
+			for _, origin := range caller.RefOrigins {
+				po, ok := origin.(reference.PathOrigin)
+				if !ok || !pathcmp.PathEquals(po.TargetPath.Path, mod.Path) {
+					continue
+				}
+				if !po.TargetAddr.Equals(sym.Addr) {
+					continue
+				}
+				edits.add(filepath.Join(caller.Path, po.Range.Filename), po.Range, newName)
+			}
+			for _, rng := range moduleInputRanges(caller, mod.Path, sym.Name()) {
+				edits.add(filepath.Join(caller.Path, rng.Filename), rng, newName)
+			}
+		case "output":
+			files := caller.ParsedModuleFiles.AsMap()
+			for _, callName := range moduleCallNames(caller, mod.Path) {
+				outputAddr := lang.Address{
+					lang.RootStep{Name: "module"},
+					lang.AttrStep{Name: callName},
+					sym.Addr[1],
+				}
+				for _, origin := range localOrigins(caller) {
+					if !addressHasPrefix(origin.Addr, outputAddr) {
+						continue
+					}
+					rng, ok := addressStepRange(files, origin.Range, len(outputAddr)-1)
+					if !ok {
+						continue
+					}
+					edits.add(filepath.Join(caller.Path, rng.Filename), rng, newName)
+				}
+			}
+		}
+	}
+
+	edits.sort()
+
+	return edits, nil
+}
+
+type declaration struct {
+	addr      lang.Address
+	nameRange hcl.Range
+}
+
+// declarations returns all renameable declarations within the module
+func declarations(mod *state.Module) []declaration {
+	decls := make([]declaration, 0)
+
+	for _, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "variable", "output", "module":
+				if len(block.Labels) != 1 {
+					continue
+				}
+				root := block.Type
+				if root == "variable" {
+					root = "var"
+				}
+				decls = append(decls, declaration{
+					addr: lang.Address{
+						lang.RootStep{Name: root},
+						lang.AttrStep{Name: block.Labels[0]},
+					},
+					nameRange: unquotedRange(f.Bytes, block.LabelRanges[0]),
+				})
+			case "resource":
+				if len(block.Labels) != 2 {
+					continue
+				}
+				decls = append(decls, declaration{
+					addr: lang.Address{
+						lang.RootStep{Name: block.Labels[0]},
+						lang.AttrStep{Name: block.Labels[1]},
+					},
+					nameRange: unquotedRange(f.Bytes, block.LabelRanges[1]),
+				})
+			case "data":
+				if len(block.Labels) != 2 {
+					continue
+				}
+				decls = append(decls, declaration{
+					addr: lang.Address{
+						lang.RootStep{Name: "data"},
+						lang.AttrStep{Name: block.Labels[0]},
+						lang.AttrStep{Name: block.Labels[1]},
+					},
+					nameRange: unquotedRange(f.Bytes, block.LabelRanges[1]),
+				})
+			case "locals":
+				for _, attr := range block.Body.Attributes {
+					decls = append(decls, declaration{
+						addr: lang.Address{
+							lang.RootStep{Name: "local"},
+							lang.AttrStep{Name: attr.Name},
+						},
+						nameRange: attr.NameRange,
+					})
+				}
+			}
+		}
+	}
+
+	return decls
+}
+
+func isDeclared(decls []declaration, addr lang.Address) bool {
+	for _, decl := range decls {
+		if decl.addr.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// localOrigins returns origins of all references within the module.
+//
+// Decoded origins depend on the schema, which may be incomplete
+// (e.g. before the Terraform version is known) or out of date,
+// so all traversals found in the configuration are included too,
+// to avoid leaving any reference behind when renaming.
+func localOrigins(mod *state.Module) []reference.LocalOrigin {
+	origins := make([]reference.LocalOrigin, 0)
+
+	for _, origin := range mod.RefOrigins {
+		if lo, ok := origin.(reference.LocalOrigin); ok {
+			origins = append(origins, lo)
+		}
+	}
+
+	for _, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+			if !ok {
+				return nil
+			}
+			origin, err := reference.TraversalToLocalOrigin(expr.Traversal, schema.TraversalExprs{})
+			if err == nil {
+				origins = append(origins, origin)
+			}
+			return nil
+		})
+	}
+
+	return origins
+}
+
+// renameableAddress returns the part of an origin address
+// which identifies the renameable symbol, e.g. var.foo for var.foo.bar
+func renameableAddress(addr lang.Address) (lang.Address, bool) {
+	if len(addr) < 2 {
+		return nil, false
+	}
+
+	length := 2
+	switch decoder.StepName(addr[0]) {
+	case "var", "local", "module":
+	case "data":
+		length = 3
+	case "path", "terraform", "count", "each", "self":
+		return nil, false
+	}
+
+	if len(addr) < length {
+		return nil, false
+	}
+	for _, step := range addr[1:length] {
+		if _, ok := step.(lang.AttrStep); !ok {
+			return nil, false
+		}
+	}
+
+	return addr.FirstSteps(uint(length)), true
+}
+
+// addressStepRange returns the range of a name of the traversal step
+// with the given index, where the traversal is located at rng
+func addressStepRange(files map[string]*hcl.File, rng hcl.Range, stepIdx int) (hcl.Range, bool) {
+	f, ok := files[rng.Filename]
+	if !ok || rng.End.Byte > len(f.Bytes) {
+		return hcl.Range{}, false
+	}
+
+	src := f.Bytes[rng.Start.Byte:rng.End.Byte]
+	traversal, diags := hclsyntax.ParseTraversalAbs(src, rng.Filename, rng.Start)
+	if diags.HasErrors() || len(traversal) <= stepIdx {
+		return hcl.Range{}, false
+	}
+
+	var name string
+	var stepRng hcl.Range
+	switch step := traversal[stepIdx].(type) {
+	case hcl.TraverseRoot:
+		name, stepRng = step.Name, step.SrcRange
+	case hcl.TraverseAttr:
+		name, stepRng = step.Name, step.SrcRange
+	default:
+		return hcl.Range{}, false
+	}
+
+	// attribute steps include the leading dot
+	// so we only take the trailing name
+	return hcl.Range{
+		Filename: stepRng.Filename,
+		Start: hcl.Pos{
+			Line:   stepRng.End.Line,
+			Column: stepRng.End.Column - utf8.RuneCountInString(name),
+			Byte:   stepRng.End.Byte - len(name),
+		},
+		End: stepRng.End,
+	}, true
+}
+
+// moduleCallNames returns names of module calls within the caller
+// which point to the module at modPath
+func moduleCallNames(caller *state.Module, modPath string) []string {
+	names := make([]string, 0)
+	if caller.ModManifest == nil {
+		return names
+	}
+
+	for _, record := range caller.ModManifest.Records {
+		if record.IsRoot() || record.IsExternal() {
+			continue
+		}
+		absPath := filepath.Join(caller.ModManifest.RootDir(), record.Dir)
+		if pathcmp.PathEquals(absPath, modPath) {
+			names = append(names, record.Key)
+		}
+	}
+
+	return names
+}
+
+// moduleInputRanges returns name ranges of the input attribute
+// in all module blocks of the caller pointing to the module at modPath
+func moduleInputRanges(caller *state.Module, modPath, name string) []hcl.Range {
+	ranges := make([]hcl.Range, 0)
+
+	callNames := make(map[string]bool, 0)
+	for _, callName := range moduleCallNames(caller, modPath) {
+		callNames[callName] = true
+	}
+
+	for _, f := range caller.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "module" || len(block.Labels) != 1 || !callNames[block.Labels[0]] {
+				continue
+			}
+			if attr, ok := block.Body.Attributes[name]; ok {
+				ranges = append(ranges, attr.NameRange)
+			}
+		}
+	}
+
+	return ranges
+}
+
+func varsFileBytes(mod *state.Module, filename string) []byte {
+	f, ok := mod.ParsedVarsFiles[ast.VarsFilename(filename)]
+	if !ok {
+		return nil
+	}
+	return f.Bytes
+}
+
+// unquotedRange strips surrounding quotes from the range, if there are any
+func unquotedRange(src []byte, rng hcl.Range) hcl.Range {
+	if rng.End.Byte > len(src) || rng.End.Byte-rng.Start.Byte < 2 {
+		return rng
+	}
+	if src[rng.Start.Byte] != '"' || src[rng.End.Byte-1] != '"' {
+		return rng
+	}
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start: hcl.Pos{
+			Line:   rng.Start.Line,
+			Column: rng.Start.Column + 1,
+			Byte:   rng.Start.Byte + 1,
+		},
+		End: hcl.Pos{
+			Line:   rng.End.Line,
+			Column: rng.End.Column - 1,
+			Byte:   rng.End.Byte - 1,
+		},
+	}
+}
+
+func addressHasPrefix(addr, prefix lang.Address) bool {
+	if len(addr) < len(prefix) {
+		return false
+	}
+	return addr.FirstSteps(uint(len(prefix))).Equals(prefix)
+}
@@ -0,0 +1,242 @@
+package schemas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+// cacheFormatVersion represents the version of the on-disk layout
+// and is bumped on any incompatible change, which leaves entries
+// of older formats ignored
+const cacheFormatVersion = "v1"
+
+// SchemaCache persists provider schemas obtained from Terraform
+// on disk, so that they are available in future sessions
+// without having to invoke Terraform again.
+//
+// Entries are keyed by provider address and version
+// and store the hashes of the provider package as recorded
+// in the dependency lock file, which makes it possible to
+// detect entries that no longer match the installed provider.
+type SchemaCache struct {
+	fs  filesystem.Filesystem
+	dir string
+}
+
+type CacheEntry struct {
+	FormatVersion string                 `json:"format_version"`
+	Provider      string                 `json:"provider"`
+	Version       string                 `json:"version"`
+	Hashes        []string               `json:"hashes"`
+	Schema        *tfjson.ProviderSchema `json:"schema"`
+}
+
+func NewSchemaCache(fs filesystem.Filesystem, dir string) *SchemaCache {
+	return &SchemaCache{
+		fs:  fs,
+		dir: dir,
+	}
+}
+
+// DefaultSchemaCacheDir returns the cache directory
+// within the user's cache directory
+func DefaultSchemaCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "terraform-ls", "schemas"), nil
+}
+
+func (sc *SchemaCache) entryPath(addr tfaddr.Provider, pv *version.Version) string {
+	return filepath.Join(sc.dir, cacheFormatVersion,
+		addr.Hostname.String(), addr.Namespace, addr.Type,
+		pv.String()+".json")
+}
+
+// Put persists the given schema of a provider in a particular version
+func (sc *SchemaCache) Put(addr tfaddr.Provider, pv *version.Version, hashes []string, ps *tfjson.ProviderSchema) error {
+	if pv == nil {
+		return fmt.Errorf("unable to cache schema of %s: unknown version", addr)
+	}
+
+	entry := CacheEntry{
+		FormatVersion: cacheFormatVersion,
+		Provider:      addr.String(),
+		Version:       pv.String(),
+		Hashes:        hashes,
+		Schema:        ps,
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := sc.entryPath(addr, pv)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first to avoid other sessions
+	// reading partially written entries
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Get returns a cached entry of a provider in a particular version
+// if it exists and matches at least one of the given hashes.
+// Any entry which does not match the hashes is considered stale
+// and is removed from the cache.
+func (sc *SchemaCache) Get(addr tfaddr.Provider, pv *version.Version, hashes []string) (*CacheEntry, bool, error) {
+	if pv == nil {
+		return nil, false, nil
+	}
+
+	path := sc.entryPath(addr, pv)
+	entry, err := sc.readCacheEntry(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if !entry.matchesHashes(hashes) {
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+
+	return entry, true, nil
+}
+
+func (ce *CacheEntry) matchesHashes(hashes []string) bool {
+	if len(ce.Hashes) == 0 && len(hashes) == 0 {
+		return true
+	}
+	for _, h := range hashes {
+		for _, cachedHash := range ce.Hashes {
+			if h == cachedHash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ProviderSchema returns the entry's schema converted
+// for use with the schema store
+func (ce *CacheEntry) ProviderSchema() (tfaddr.Provider, *version.Version, *tfschema.ProviderSchema, error) {
+	pAddr, err := tfaddr.ParseRawProviderSourceString(ce.Provider)
+	if err != nil {
+		return tfaddr.Provider{}, nil, nil, err
+	}
+	pv, err := version.NewVersion(ce.Version)
+	if err != nil {
+		return tfaddr.Provider{}, nil, nil, err
+	}
+	if ce.Schema == nil {
+		return tfaddr.Provider{}, nil, nil, fmt.Errorf("missing schema for %s %s", pAddr, pv)
+	}
+
+	pSchema := tfschema.ProviderSchemaFromJson(ce.Schema, pAddr)
+	pSchema.SetProviderVersion(pAddr, pv)
+
+	return pAddr, pv, pSchema, nil
+}
+
+// LoadToStore loads all cached schemas into the given store.
+// Entries which cannot be read are skipped, as they may be
+// a result of an interrupted write or a different format.
+func (sc *SchemaCache) LoadToStore(pss *state.ProviderSchemaStore) error {
+	root := filepath.Join(sc.dir, cacheFormatVersion)
+	_, err := sc.fs.Stat(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" ||
+			strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+
+		entry, err := sc.readCacheEntry(path)
+		if err != nil {
+			return nil
+		}
+		pAddr, pv, pSchema, err := entry.ProviderSchema()
+		if err != nil {
+			return nil
+		}
+
+		return pss.AddCachedSchema(pAddr, pv, pSchema)
+	})
+}
+
+func (sc *SchemaCache) readCacheEntry(path string) (*CacheEntry, error) {
+	b, err := sc.fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &CacheEntry{}
+	err = json.Unmarshal(b, entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.FormatVersion != cacheFormatVersion {
+		return nil, fmt.Errorf("unsupported cache format version: %q", entry.FormatVersion)
+	}
+
+	return entry, nil
+}
+
+type ctxKey string
+
+var ctxSchemaCache = ctxKey("schema cache")
+
+func SchemaCacheFromContext(ctx context.Context) (*SchemaCache, bool) {
+	sc, ok := ctx.Value(ctxSchemaCache).(*SchemaCache)
+	return sc, ok && sc != nil
+}
+
+func WithSchemaCache(ctx context.Context, sc *SchemaCache) context.Context {
+	return context.WithValue(ctx, ctxSchemaCache, sc)
+}
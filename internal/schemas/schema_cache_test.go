@@ -0,0 +1,158 @@
+package schemas
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSchemaCache_putAndGet(t *testing.T) {
+	sc := NewSchemaCache(filesystem.NewFilesystem(), t.TempDir())
+
+	addr := tfaddr.NewDefaultProvider("aws")
+	pv := version.Must(version.NewVersion("3.63.0"))
+	hashes := []string{"h1:first", "zh:second"}
+
+	err := sc.Put(addr, pv, hashes, testProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok, err := sc.Get(addr, pv, []string{"zh:second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected cached entry to be found")
+	}
+	if diff := cmp.Diff(testProviderSchema(), entry.Schema, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected schema: %s", diff)
+	}
+
+	_, ok, err = sc.Get(addr, version.Must(version.NewVersion("3.64.0")), hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected entry of different version not to be found")
+	}
+}
+
+func TestSchemaCache_staleEntryIsRemoved(t *testing.T) {
+	sc := NewSchemaCache(filesystem.NewFilesystem(), t.TempDir())
+
+	addr := tfaddr.NewDefaultProvider("aws")
+	pv := version.Must(version.NewVersion("3.63.0"))
+
+	err := sc.Put(addr, pv, []string{"h1:old"}, testProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok, err := sc.Get(addr, pv, []string{"h1:new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected entry with mismatching hashes not to be returned")
+	}
+
+	_, err = os.Stat(sc.entryPath(addr, pv))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected stale entry to be removed, given: %v", err)
+	}
+}
+
+func TestSchemaCache_LoadToStore(t *testing.T) {
+	dir := t.TempDir()
+	sc := NewSchemaCache(filesystem.NewFilesystem(), dir)
+
+	addr := tfaddr.NewDefaultProvider("aws")
+	pv := version.Must(version.NewVersion("3.63.0"))
+	err := sc.Put(addr, pv, []string{"h1:first"}, testProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// corrupted entries should be ignored
+	corruptedPath := filepath.Join(dir, cacheFormatVersion,
+		"registry.terraform.io", "hashicorp", "random", "3.1.0.json")
+	err = os.MkdirAll(filepath.Dir(corruptedPath), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(corruptedPath, []byte("{"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sc.LoadToStore(ss.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	si, err := ss.ProviderSchemas.ListSchemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := make([]*state.ProviderSchema, 0)
+	for ps := si.Next(); ps != nil; ps = si.Next() {
+		schemas = append(schemas, ps)
+	}
+	if len(schemas) != 1 {
+		t.Fatalf("expected 1 schema, given: %d", len(schemas))
+	}
+	if !schemas[0].Address.Equals(addr) {
+		t.Fatalf("unexpected address: %s", schemas[0].Address)
+	}
+	if !schemas[0].Version.Equal(pv) {
+		t.Fatalf("unexpected version: %s", schemas[0].Version)
+	}
+	if schemas[0].Source != (state.CachedSchemaSource{}) {
+		t.Fatalf("unexpected source: %s", schemas[0].Source)
+	}
+	if _, ok := schemas[0].Schema.Resources["aws_instance"]; !ok {
+		t.Fatal("expected aws_instance resource in cached schema")
+	}
+}
+
+func testProviderSchema() *tfjson.ProviderSchema {
+	return &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"region": {
+						AttributeType: cty.String,
+						Optional:      true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"aws_instance": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"ami": {
+							AttributeType: cty.String,
+							Required:      true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
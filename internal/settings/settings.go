@@ -15,6 +15,11 @@ type ExperimentalFeatures struct {
 	PrefillRequiredFields bool `mapstructure:"prefillRequiredFields"`
 }
 
+const (
+	FormatterNative    = "native"
+	FormatterTerraform = "terraform"
+)
+
 type Options struct {
 	// ModulePaths describes a list of absolute paths to modules to load
 	ModulePaths          []string `mapstructure:"rootModulePaths"`
@@ -22,12 +27,31 @@ type Options struct {
 	CommandPrefix        string   `mapstructure:"commandPrefix"`
 	IgnoreDirectoryNames []string `mapstructure:"ignoreDirectoryNames"`
 
+	// VariablesFileName describes name of the file within each module
+	// where any missing variable declarations are generated
+	VariablesFileName string `mapstructure:"variablesFileName"`
+
+	// ActiveVariablesFile describes name of a (non-autoloaded) variables
+	// file within each module whose values take precedence
+	// over autoloaded values, e.g. in inlay hints
+	ActiveVariablesFile string `mapstructure:"activeVariablesFile"`
+
+	// Formatter describes how documents are formatted, either
+	// natively (the default) or via terraform fmt (FormatterTerraform)
+	Formatter string `mapstructure:"formatter"`
+
 	// ExperimentalFeatures encapsulates experimental features users can opt into.
 	ExperimentalFeatures ExperimentalFeatures `mapstructure:"experimentalFeatures"`
 
 	TerraformExecPath    string `mapstructure:"terraformExecPath"`
 	TerraformExecTimeout string `mapstructure:"terraformExecTimeout"`
 	TerraformLogFilePath string `mapstructure:"terraformLogFilePath"`
+	TerraformVersionsDir string `mapstructure:"terraformVersionsDir"`
+
+	// LaunchProviderPlugins enables obtaining schemas directly
+	// from provider plugins installed in the module when no
+	// Terraform binary is found, which involves executing them
+	LaunchProviderPlugins bool `mapstructure:"launchProviderPlugins"`
 }
 
 func (o *Options) Validate() error {
@@ -49,6 +73,47 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	if o.TerraformVersionsDir != "" {
+		path := o.TerraformVersionsDir
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("Expected absolute path for Terraform versions directory, got %q", path)
+		}
+		stat, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("Unable to find Terraform versions directory: %s", err)
+		}
+		if !stat.IsDir() {
+			return fmt.Errorf("Expected a directory of Terraform versions, got a file: %q", path)
+		}
+	}
+
+	if o.VariablesFileName != "" {
+		name := o.VariablesFileName
+		if strings.Contains(name, string(filepath.Separator)) {
+			return fmt.Errorf("expected file name, got a path: %q", name)
+		}
+		if filepath.Ext(name) != ".tf" {
+			return fmt.Errorf("expected a *.tf file name, got %q", name)
+		}
+	}
+
+	if o.ActiveVariablesFile != "" {
+		name := o.ActiveVariablesFile
+		if strings.Contains(name, string(filepath.Separator)) {
+			return fmt.Errorf("expected file name, got a path: %q", name)
+		}
+		if !strings.HasSuffix(name, ".tfvars") && !strings.HasSuffix(name, ".tfvars.json") {
+			return fmt.Errorf("expected a *.tfvars or *.tfvars.json file name, got %q", name)
+		}
+	}
+
+	switch o.Formatter {
+	case "", FormatterNative, FormatterTerraform:
+	default:
+		return fmt.Errorf("unknown formatter %q, expected %q or %q",
+			o.Formatter, FormatterNative, FormatterTerraform)
+	}
+
 	if len(o.IgnoreDirectoryNames) > 0 {
 		for _, directory := range o.IgnoreDirectoryNames {
 			if directory == datadir.DataDirName {
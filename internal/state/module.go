@@ -80,6 +80,10 @@ type Module struct {
 	TerraformVersionErr   error
 	TerraformVersionState op.OpState
 
+	// TerraformVersionDiagnostics reflects any problems
+	// with selecting Terraform version for the module
+	TerraformVersionDiagnostics ast.ModDiags
+
 	InstalledProviders InstalledProviders
 
 	ProviderSchemaErr   error
@@ -110,6 +114,16 @@ type Module struct {
 
 	ModuleDiagnostics ast.ModDiags
 	VarsDiagnostics   ast.VarsDiags
+
+	SchemaValidationDiagnostics ast.ModDiags
+	SchemaValidationState       op.OpState
+
+	ReferenceValidationDiagnostics ast.ModDiags
+	UnusedDeclarationDiagnostics   ast.ModDiags
+	ReferenceValidationState       op.OpState
+
+	VarsValidationDiagnostics ast.VarsDiags
+	VarsValidationState       op.OpState
 }
 
 func (m *Module) Copy() *Module {
@@ -151,6 +165,10 @@ func (m *Module) Copy() *Module {
 		Meta:      m.Meta.Copy(),
 		MetaErr:   m.MetaErr,
 		MetaState: m.MetaState,
+
+		SchemaValidationState:    m.SchemaValidationState,
+		ReferenceValidationState: m.ReferenceValidationState,
+		VarsValidationState:      m.VarsValidationState,
 	}
 
 	if m.InstalledProviders != nil {
@@ -199,6 +217,60 @@ func (m *Module) Copy() *Module {
 		}
 	}
 
+	if m.SchemaValidationDiagnostics != nil {
+		newMod.SchemaValidationDiagnostics = make(ast.ModDiags, len(m.SchemaValidationDiagnostics))
+		for name, diags := range m.SchemaValidationDiagnostics {
+			newMod.SchemaValidationDiagnostics[name] = make(hcl.Diagnostics, len(diags))
+			for i, diag := range diags {
+				// hcl.Diagnostic is practically immutable once it comes out of validation
+				newMod.SchemaValidationDiagnostics[name][i] = diag
+			}
+		}
+	}
+
+	if m.ReferenceValidationDiagnostics != nil {
+		newMod.ReferenceValidationDiagnostics = make(ast.ModDiags, len(m.ReferenceValidationDiagnostics))
+		for name, diags := range m.ReferenceValidationDiagnostics {
+			newMod.ReferenceValidationDiagnostics[name] = make(hcl.Diagnostics, len(diags))
+			for i, diag := range diags {
+				// hcl.Diagnostic is practically immutable once it comes out of validation
+				newMod.ReferenceValidationDiagnostics[name][i] = diag
+			}
+		}
+	}
+
+	if m.UnusedDeclarationDiagnostics != nil {
+		newMod.UnusedDeclarationDiagnostics = make(ast.ModDiags, len(m.UnusedDeclarationDiagnostics))
+		for name, diags := range m.UnusedDeclarationDiagnostics {
+			newMod.UnusedDeclarationDiagnostics[name] = make(hcl.Diagnostics, len(diags))
+			for i, diag := range diags {
+				// hcl.Diagnostic is practically immutable once it comes out of validation
+				newMod.UnusedDeclarationDiagnostics[name][i] = diag
+			}
+		}
+	}
+
+	if m.VarsValidationDiagnostics != nil {
+		newMod.VarsValidationDiagnostics = make(ast.VarsDiags, len(m.VarsValidationDiagnostics))
+		for name, diags := range m.VarsValidationDiagnostics {
+			newMod.VarsValidationDiagnostics[name] = make(hcl.Diagnostics, len(diags))
+			for i, diag := range diags {
+				// hcl.Diagnostic is practically immutable once it comes out of validation
+				newMod.VarsValidationDiagnostics[name][i] = diag
+			}
+		}
+	}
+
+	if m.TerraformVersionDiagnostics != nil {
+		newMod.TerraformVersionDiagnostics = make(ast.ModDiags, len(m.TerraformVersionDiagnostics))
+		for name, diags := range m.TerraformVersionDiagnostics {
+			newMod.TerraformVersionDiagnostics[name] = make(hcl.Diagnostics, len(diags))
+			for i, diag := range diags {
+				newMod.TerraformVersionDiagnostics[name][i] = diag
+			}
+		}
+	}
+
 	return newMod
 }
 
@@ -728,6 +800,31 @@ func (s *ModuleStore) UpdateModuleDiagnostics(path string, diags ast.ModDiags) e
 	return nil
 }
 
+func (s *ModuleStore) UpdateTerraformVersionDiagnostics(path string, diags ast.ModDiags) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.TerraformVersionDiagnostics = diags
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Defer(func() {
+		go s.ChangeHooks.notifyModuleChange(oldMod, mod)
+	})
+
+	txn.Commit()
+	return nil
+}
+
 func (s *ModuleStore) UpdateVarsDiagnostics(path string, diags ast.VarsDiags) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -753,6 +850,148 @@ func (s *ModuleStore) UpdateVarsDiagnostics(path string, diags ast.VarsDiags) er
 	return nil
 }
 
+func (s *ModuleStore) SetSchemaValidationState(path string, state op.OpState) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	mod, err := moduleCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod.SchemaValidationState = state
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) UpdateSchemaValidationDiagnostics(path string, diags ast.ModDiags) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetSchemaValidationState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.SchemaValidationDiagnostics = diags
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Defer(func() {
+		go s.ChangeHooks.notifyModuleChange(oldMod, mod)
+	})
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) SetReferenceValidationState(path string, state op.OpState) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	mod, err := moduleCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod.ReferenceValidationState = state
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) UpdateReferenceValidationDiagnostics(path string, diags, unusedDiags ast.ModDiags) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetReferenceValidationState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.ReferenceValidationDiagnostics = diags
+	mod.UnusedDeclarationDiagnostics = unusedDiags
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Defer(func() {
+		go s.ChangeHooks.notifyModuleChange(oldMod, mod)
+	})
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) SetVarsValidationState(path string, state op.OpState) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	mod, err := moduleCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod.VarsValidationState = state
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) UpdateVarsValidationDiagnostics(path string, diags ast.VarsDiags) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetVarsValidationState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.VarsValidationDiagnostics = diags
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Defer(func() {
+		go s.ChangeHooks.notifyModuleChange(oldMod, mod)
+	})
+
+	txn.Commit()
+	return nil
+}
+
 func (s *ModuleStore) SetReferenceTargetsState(path string, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -192,6 +192,37 @@ func (s *ProviderSchemaStore) AddPreloadedSchema(addr tfaddr.Provider, pv *versi
 	return nil
 }
 
+// AddCachedSchema adds schema obtained from the on-disk schema cache.
+// Any existing cached schema of the same provider version is replaced,
+// as the cache may be refreshed during the session.
+func (s *ProviderSchemaStore) AddCachedSchema(addr tfaddr.Provider, pv *version.Version, schema *tfschema.ProviderSchema) error {
+	s.logger.Printf("PSS: adding cached schema (%s, %s): %p", addr, pv, schema)
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	src := CachedSchemaSource{}
+	_, err := txn.DeleteAll(s.tableName, "id", addr, src, pv)
+	if err != nil {
+		return err
+	}
+
+	schemaCopy := schema.Copy()
+	schemaCopy.SetProviderVersion(addr, pv)
+
+	err = txn.Insert(s.tableName, &ProviderSchema{
+		Address: addr,
+		Version: pv,
+		Source:  src,
+		Schema:  schemaCopy,
+	})
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*tfschema.ProviderSchema, error) {
 	s.logger.Printf("PSS: getting provider schema (%s, %s, %s)", modPath, addr, vc)
 	txn := s.db.Txn(false)
@@ -305,6 +336,10 @@ func (ss sortableSchemas) rankBySource(src SchemaSource) int {
 	switch s := src.(type) {
 	case PreloadedSchemaSource:
 		return -1
+	case CachedSchemaSource:
+		// cached schemas were obtained from real installations
+		// but not necessarily of the requested module
+		return 0
 	case LocalSchemaSource:
 		if s.ModulePath == ss.requiredModPath {
 			return 2
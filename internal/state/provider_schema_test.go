@@ -43,6 +43,105 @@ func TestStateStore_AddPreloadedSchema_duplicate(t *testing.T) {
 	}
 }
 
+func TestStateStore_AddCachedSchema_replace(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := tfaddr.NewDefaultProvider("aws")
+	pv := testVersion(t, "1.0.0")
+
+	err = s.ProviderSchemas.AddCachedSchema(addr, pv, &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{
+			Description: lang.PlainText("cached: first"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.AddCachedSchema(addr, pv, &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{
+			Description: lang.PlainText("cached: second"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	si, err := s.ProviderSchemas.ListSchemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := make([]*ProviderSchema, 0)
+	for ps := si.Next(); ps != nil; ps = si.Next() {
+		schemas = append(schemas, ps)
+	}
+	if len(schemas) != 1 {
+		t.Fatalf("expected 1 schema, given: %d", len(schemas))
+	}
+	if schemas[0].Source != (CachedSchemaSource{}) {
+		t.Fatalf("unexpected source: %s", schemas[0].Source)
+	}
+	if desc := schemas[0].Schema.Provider.Description.Value; desc != "cached: second" {
+		t.Fatalf("expected schema to be replaced, given: %q", desc)
+	}
+}
+
+func TestStateStore_ProviderSchema_cachedHasPriorityOverPreloaded(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := filepath.Join("special", "module")
+	err = s.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := []*ProviderSchema{
+		{
+			tfaddr.NewDefaultProvider("aws"),
+			testVersion(t, "1.0.0"),
+			PreloadedSchemaSource{},
+			&tfschema.ProviderSchema{
+				Provider: &schema.BodySchema{
+					Description: lang.PlainText("preload: hashicorp/aws 1.0.0"),
+				},
+			},
+		},
+		{
+			tfaddr.NewDefaultProvider("aws"),
+			testVersion(t, "1.0.0"),
+			CachedSchemaSource{},
+			&tfschema.ProviderSchema{
+				Provider: &schema.BodySchema{
+					Description: lang.PlainText("cached: hashicorp/aws 1.0.0"),
+				},
+			},
+		},
+	}
+
+	for _, ps := range schemas {
+		addAnySchema(t, s.ProviderSchemas, s.Modules, ps)
+	}
+
+	ps, err := s.ProviderSchemas.ProviderSchema(modPath,
+		tfaddr.NewDefaultProvider("aws"),
+		testConstraint(t, "1.0.0"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDescription := "cached: hashicorp/aws 1.0.0"
+	if ps.Provider.Description.Value != expectedDescription {
+		t.Fatalf("description doesn't match. expected: %q, got: %q",
+			expectedDescription, ps.Provider.Description.Value)
+	}
+}
+
 // Test a scenario where Terraform 0.13+ produced schema with non-legacy
 // addresses but lookup is still done via legacy address
 func TestStateStore_IncompleteSchema_legacyLookup(t *testing.T) {
@@ -896,6 +995,11 @@ func addAnySchema(t testOrBench, ss *ProviderSchemaStore, ms *ModuleStore, ps *P
 		if err != nil {
 			t.Fatal(err)
 		}
+	case CachedSchemaSource:
+		err := ss.AddCachedSchema(ps.Address, ps.Version, ps.Schema)
+		if err != nil {
+			t.Fatal(err)
+		}
 	case LocalSchemaSource:
 		err := ss.AddLocalSchema(s.ModulePath, ps.Address, ps.Schema)
 		if err != nil {
@@ -31,3 +31,16 @@ func (LocalSchemaSource) isSchemaSrcImpl() schemaSrcSigil {
 func (lss LocalSchemaSource) String() string {
 	return fmt.Sprintf("local(%s)", lss.ModulePath)
 }
+
+// CachedSchemaSource represents schema loaded from the on-disk
+// schema cache, as persisted by a previous session
+type CachedSchemaSource struct {
+}
+
+func (CachedSchemaSource) isSchemaSrcImpl() schemaSrcSigil {
+	return schemaSrcSigil{}
+}
+
+func (CachedSchemaSource) String() string {
+	return "cached"
+}
@@ -0,0 +1,208 @@
+// Package structure provides structural information about HCL files,
+// such as ranges which can be folded or progressively selected
+package structure
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type FoldingKind uint
+
+const (
+	// FoldingRegion represents a block or an expression spanning lines
+	FoldingRegion FoldingKind = iota
+	// FoldingComment represents a group of comments
+	FoldingComment
+)
+
+// FoldingRange represents lines which can be folded,
+// where StartLine remains visible when folded
+type FoldingRange struct {
+	// StartLine and EndLine are 1-based and inclusive
+	StartLine int
+	EndLine   int
+	Kind      FoldingKind
+}
+
+// FoldingRanges returns ranges of blocks, multi-line object and tuple
+// expressions, heredocs and groups of consecutive comments in the file.
+//
+// Closing braces and brackets (and heredoc markers) which begin
+// their own line are left out, so they remain visible when folded.
+func FoldingRanges(f *hcl.File) []FoldingRange {
+	ranges := make([]FoldingRange, 0)
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return ranges
+	}
+
+	src := f.Bytes
+	addRegion := func(openRng, closeRng hcl.Range) {
+		endLine := closeRng.End.Line
+		if isFirstOnLine(src, closeRng.Start) {
+			endLine = closeRng.Start.Line - 1
+		}
+		if endLine > openRng.Start.Line {
+			ranges = append(ranges, FoldingRange{
+				StartLine: openRng.Start.Line,
+				EndLine:   endLine,
+				Kind:      FoldingRegion,
+			})
+		}
+	}
+
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.Block:
+			addRegion(n.OpenBraceRange, n.CloseBraceRange)
+		case *hclsyntax.ObjectConsExpr:
+			addRegion(n.OpenRange, closingRange(n.SrcRange))
+		case *hclsyntax.TupleConsExpr:
+			addRegion(n.OpenRange, closingRange(n.SrcRange))
+		}
+		return nil
+	})
+
+	tokens, _ := hclsyntax.LexConfig(src, f.Body.MissingItemRange().Filename, hcl.InitialPos)
+	ranges = append(ranges, heredocRanges(src, tokens)...)
+	ranges = append(ranges, commentRanges(tokens)...)
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].StartLine != ranges[j].StartLine {
+			return ranges[i].StartLine < ranges[j].StartLine
+		}
+		return ranges[i].EndLine > ranges[j].EndLine
+	})
+
+	return ranges
+}
+
+func heredocRanges(src []byte, tokens hclsyntax.Tokens) []FoldingRange {
+	ranges := make([]FoldingRange, 0)
+
+	var openRng *hcl.Range
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOHeredoc:
+			rng := token.Range
+			openRng = &rng
+		case hclsyntax.TokenCHeredoc:
+			if openRng == nil {
+				continue
+			}
+			// the closing marker token includes any leading whitespace
+			// (for indented heredocs) and the trailing newline
+			endLine := token.Range.Start.Line - 1
+			if endLine > openRng.Start.Line {
+				ranges = append(ranges, FoldingRange{
+					StartLine: openRng.Start.Line,
+					EndLine:   endLine,
+					Kind:      FoldingRegion,
+				})
+			}
+			openRng = nil
+		}
+	}
+
+	return ranges
+}
+
+func commentRanges(tokens hclsyntax.Tokens) []FoldingRange {
+	ranges := make([]FoldingRange, 0)
+
+	var group *FoldingRange
+	flush := func() {
+		if group != nil && group.EndLine > group.StartLine {
+			ranges = append(ranges, *group)
+		}
+		group = nil
+	}
+
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			if token.Type != hclsyntax.TokenNewline {
+				flush()
+			}
+			continue
+		}
+
+		startLine := token.Range.Start.Line
+		endLine := lastLineOfComment(token)
+		isLineComment := !bytes.HasPrefix(token.Bytes, []byte("/*"))
+
+		if !isLineComment || (i > 0 && !isLineStart(tokens, i)) {
+			// block comments and trailing comments are not grouped
+			flush()
+			if endLine > startLine {
+				ranges = append(ranges, FoldingRange{
+					StartLine: startLine,
+					EndLine:   endLine,
+					Kind:      FoldingComment,
+				})
+			}
+			continue
+		}
+
+		if group != nil && group.EndLine == startLine-1 {
+			group.EndLine = endLine
+			continue
+		}
+		flush()
+		group = &FoldingRange{
+			StartLine: startLine,
+			EndLine:   endLine,
+			Kind:      FoldingComment,
+		}
+	}
+	flush()
+
+	return ranges
+}
+
+// lastLineOfComment returns the last line of the comment,
+// excluding the newline which line comments include
+func lastLineOfComment(token hclsyntax.Token) int {
+	if bytes.HasSuffix(token.Bytes, []byte("\n")) {
+		return token.Range.End.Line - 1
+	}
+	return token.Range.End.Line
+}
+
+// isLineStart returns true if the token at the given index
+// is the first token on its line
+func isLineStart(tokens hclsyntax.Tokens, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+	prev := tokens[idx-1]
+	return prev.Type == hclsyntax.TokenNewline ||
+		(prev.Type == hclsyntax.TokenComment && bytes.HasSuffix(prev.Bytes, []byte("\n")))
+}
+
+// closingRange returns range of the closing brace or bracket
+// of an expression
+func closingRange(rng hcl.Range) hcl.Range {
+	start := rng.End
+	start.Byte--
+	start.Column--
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    start,
+		End:      rng.End,
+	}
+}
+
+// isFirstOnLine returns true if there is only whitespace
+// between the beginning of the line and the position
+func isFirstOnLine(src []byte, pos hcl.Pos) bool {
+	if pos.Byte > len(src) {
+		return false
+	}
+	lineStart := bytes.LastIndexByte(src[:pos.Byte], '\n') + 1
+	return len(bytes.TrimSpace(src[lineStart:pos.Byte])) == 0
+}
@@ -0,0 +1,51 @@
+package structure
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestFoldingRanges(t *testing.T) {
+	cfg := `# first line
+# second line
+resource "aws_instance" "web" {
+  tags = {
+    Name = "web"
+  }
+  zones = ["a", "b"]
+  subnets = [
+    "one",
+    "two"]
+  user_data = <<EOT
+#!/bin/bash
+echo hello
+EOT
+
+  /* a block
+     comment */
+  ami = "ami-123" # trailing
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	ranges := FoldingRanges(f)
+
+	expectedRanges := []FoldingRange{
+		{StartLine: 1, EndLine: 2, Kind: FoldingComment},
+		{StartLine: 3, EndLine: 18, Kind: FoldingRegion},
+		{StartLine: 4, EndLine: 5, Kind: FoldingRegion},
+		{StartLine: 8, EndLine: 10, Kind: FoldingRegion},
+		{StartLine: 11, EndLine: 13, Kind: FoldingRegion},
+		{StartLine: 16, EndLine: 17, Kind: FoldingComment},
+	}
+
+	if diff := cmp.Diff(expectedRanges, ranges); diff != "" {
+		t.Fatalf("unexpected folding ranges: %s", diff)
+	}
+}
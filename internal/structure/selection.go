@@ -0,0 +1,73 @@
+package structure
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// SelectionRanges returns ranges enclosing the position, starting with
+// the innermost one (e.g. an identifier) and expanding to its traversal,
+// enclosing expressions, the attribute, blocks and the whole file
+func SelectionRanges(f *hcl.File, pos hcl.Pos) []hcl.Range {
+	ranges := make([]hcl.Range, 0)
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return ranges
+	}
+
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case hclsyntax.Attributes, hclsyntax.Blocks:
+			// collections of nodes have no meaningful range
+			return nil
+		case *hclsyntax.Body:
+			if n != body {
+				// bodies of blocks are represented by blocks
+				return nil
+			}
+		}
+
+		rng := node.Range()
+		if rng.ContainsPos(pos) || rng.End.Byte == pos.Byte {
+			ranges = append(ranges, rng)
+		}
+		return nil
+	})
+
+	tokens, _ := hclsyntax.LexConfig(f.Bytes, body.SrcRange.Filename, hcl.InitialPos)
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenIdent {
+			continue
+		}
+		if token.Range.ContainsPos(pos) || token.Range.End.Byte == pos.Byte {
+			ranges = append(ranges, token.Range)
+			break
+		}
+	}
+
+	// innermost ranges first
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return rangeSize(ranges[i]) < rangeSize(ranges[j])
+	})
+
+	unique := make([]hcl.Range, 0, len(ranges))
+	for _, rng := range ranges {
+		if len(unique) > 0 && sameRange(unique[len(unique)-1], rng) {
+			continue
+		}
+		unique = append(unique, rng)
+	}
+
+	return unique
+}
+
+func rangeSize(rng hcl.Range) int {
+	return rng.End.Byte - rng.Start.Byte
+}
+
+func sameRange(a, b hcl.Range) bool {
+	return a.Start.Byte == b.Start.Byte && a.End.Byte == b.End.Byte
+}
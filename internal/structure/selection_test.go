@@ -0,0 +1,51 @@
+package structure
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestSelectionRanges(t *testing.T) {
+	cfg := `variable "name" {}
+
+resource "aws_instance" "web" {
+  tags = {
+    Name = upper(var.name)
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	// position within "name" of var.name
+	pos := hcl.Pos{Line: 5, Column: 23, Byte: 85}
+
+	ranges := SelectionRanges(f, pos)
+
+	expectedRanges := []hcl.Range{
+		rangeInFile(5, 22, 84, 5, 26, 88), // name
+		rangeInFile(5, 18, 80, 5, 26, 88), // var.name
+		rangeInFile(5, 12, 74, 5, 27, 89), // upper(var.name)
+		rangeInFile(4, 10, 61, 6, 4, 93),  // object
+		rangeInFile(4, 3, 54, 6, 4, 93),   // tags = {...}
+		rangeInFile(3, 1, 20, 7, 2, 95),   // resource block
+		rangeInFile(1, 1, 0, 8, 1, 96),    // file
+	}
+
+	if diff := cmp.Diff(expectedRanges, ranges); diff != "" {
+		t.Fatalf("unexpected selection ranges: %s", diff)
+	}
+}
+
+func rangeInFile(startLine, startCol, startByte, endLine, endCol, endByte int) hcl.Range {
+	return hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: startLine, Column: startCol, Byte: startByte},
+		End:      hcl.Pos{Line: endLine, Column: endCol, Byte: endByte},
+	}
+}
@@ -16,6 +16,14 @@ func (mf ModFilename) IsJSON() bool {
 	return strings.HasSuffix(string(mf), ".json")
 }
 
+// IsOverride returns true if the file is an override file
+// (i.e. override.tf, *_override.tf or their JSON variants)
+// which Terraform merges into the primary configuration files.
+func (mf ModFilename) IsOverride() bool {
+	name := strings.TrimSuffix(strings.TrimSuffix(string(mf), ".json"), ".tf")
+	return name == "override" || strings.HasSuffix(name, "_override")
+}
+
 func IsModuleFilename(name string) bool {
 	return (strings.HasSuffix(name, ".tf") ||
 		strings.HasSuffix(name, ".tf.json")) &&
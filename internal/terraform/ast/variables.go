@@ -67,6 +67,18 @@ func (vd VarsDiags) AutoloadedOnly() VarsDiags {
 	return diags
 }
 
+// AutoloadedOrOpen returns diagnostics of autoloaded files
+// and of any other files for which isOpen returns true
+func (vd VarsDiags) AutoloadedOrOpen(isOpen func(VarsFilename) bool) VarsDiags {
+	diags := make(VarsDiags)
+	for name, f := range vd {
+		if name.IsAutoloaded() || isOpen(name) {
+			diags[name] = f
+		}
+	}
+	return diags
+}
+
 func (vd VarsDiags) ForFile(name VarsFilename) VarsDiags {
 	diags := make(VarsDiags)
 	for fName, f := range vd {
@@ -16,6 +16,12 @@ var pluginLockFilePathElements = [][]string{
 	{DataDirName, "plugins", runtime.GOOS + "_" + runtime.GOARCH, "lock.json"},
 }
 
+// providersDirElements represents the directory where
+// Terraform >= 0.14 unpacks installed provider packages
+var providersDirElements = []string{
+	DataDirName, "providers",
+}
+
 var manifestPathElements = []string{
 	DataDirName, "modules", "modules.json",
 }
@@ -1,9 +1,16 @@
 package datadir
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func PluginLockFilePath(fs filesystem.Filesystem, modPath string) (string, bool) {
@@ -17,3 +24,105 @@ func PluginLockFilePath(fs filesystem.Filesystem, modPath string) (string, bool)
 
 	return "", false
 }
+
+// ProviderLock represents a single provider selection
+// recorded in the dependency lock file (.terraform.lock.hcl)
+type ProviderLock struct {
+	Version *version.Version
+	Hashes  []string
+}
+
+// HasHash returns true if any of the given hashes
+// is recorded for the locked provider
+func (pl ProviderLock) HasHash(hashes []string) bool {
+	for _, h := range hashes {
+		for _, lockedHash := range pl.Hashes {
+			if h == lockedHash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseDependencyLockFile parses the dependency lock file
+// (.terraform.lock.hcl) as introduced in Terraform 0.14.
+//
+// The returned bool indicates whether the file was found.
+func ParseDependencyLockFile(fs filesystem.Filesystem, modPath string) (map[tfaddr.Provider]ProviderLock, bool, error) {
+	lockFilePath := filepath.Join(append([]string{modPath}, pluginLockFilePathElements[0]...)...)
+	b, err := fs.ReadFile(lockFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	locks, err := parseDependencyLockFile(b, lockFilePath)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return locks, true, nil
+}
+
+func parseDependencyLockFile(b []byte, filename string) (map[tfaddr.Provider]ProviderLock, error) {
+	f, diags := hclsyntax.ParseConfig(b, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	locks := make(map[tfaddr.Provider]ProviderLock, 0)
+
+	body := f.Body.(*hclsyntax.Body)
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+
+		pAddr, err := tfaddr.ParseRawProviderSourceString(block.Labels[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider address %q: %w", block.Labels[0], err)
+		}
+
+		lock := ProviderLock{
+			Hashes: make([]string, 0),
+		}
+
+		if attr, ok := block.Body.Attributes["version"]; ok {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			if !val.Type().Equals(cty.String) || val.IsNull() || !val.IsKnown() {
+				return nil, fmt.Errorf("invalid version for %s", pAddr)
+			}
+			lock.Version, err = version.NewVersion(val.AsString())
+			if err != nil {
+				return nil, fmt.Errorf("invalid version for %s: %w", pAddr, err)
+			}
+		}
+
+		if attr, ok := block.Body.Attributes["hashes"]; ok {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			if !val.CanIterateElements() || !val.IsWhollyKnown() {
+				return nil, fmt.Errorf("invalid hashes for %s", pAddr)
+			}
+			for it := val.ElementIterator(); it.Next(); {
+				_, hash := it.Element()
+				if !hash.Type().Equals(cty.String) || hash.IsNull() {
+					return nil, fmt.Errorf("invalid hash for %s", pAddr)
+				}
+				lock.Hashes = append(lock.Hashes, hash.AsString())
+			}
+		}
+
+		locks[pAddr] = lock
+	}
+
+	return locks, nil
+}
@@ -0,0 +1,72 @@
+package datadir
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+func TestParseDependencyLockFile(t *testing.T) {
+	fs := filesystem.NewFilesystem()
+	modPath := t.TempDir()
+
+	_, ok, err := ParseDependencyLockFile(fs, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected missing lock file to be reported as not found")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(modPath, ".terraform.lock.hcl"), []byte(testLockFileContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locks, ok, err := ParseDependencyLockFile(fs, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected lock file to be found")
+	}
+
+	expectedLocks := map[tfaddr.Provider]ProviderLock{
+		tfaddr.NewDefaultProvider("aws"): {
+			Version: version.Must(version.NewVersion("3.63.0")),
+			Hashes: []string{
+				"h1:fxTJxTsTUp4D9RMvvJzCbJwnv0mCeUAUk5gSBNSNqfg=",
+				"zh:42c6c98b294953a4e1434a331251e539f5372bf6779bd9bc2ed9af7b4e6a4d6b",
+			},
+		},
+		tfaddr.NewDefaultProvider("random"): {
+			Version: version.Must(version.NewVersion("3.1.0")),
+			Hashes:  []string{},
+		},
+	}
+	if diff := cmp.Diff(expectedLocks, locks); diff != "" {
+		t.Fatalf("unexpected locks: %s", diff)
+	}
+}
+
+const testLockFileContent = `# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "3.63.0"
+  constraints = "~> 3.0"
+  hashes = [
+    "h1:fxTJxTsTUp4D9RMvvJzCbJwnv0mCeUAUk5gSBNSNqfg=",
+    "zh:42c6c98b294953a4e1434a331251e539f5372bf6779bd9bc2ed9af7b4e6a4d6b",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.1.0"
+}
+`
@@ -0,0 +1,123 @@
+package datadir
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// ProviderPluginPath returns path to the executable of a provider
+// installed in the given version, as unpacked by Terraform >= 0.14
+// under .terraform/providers
+func ProviderPluginPath(modPath string, addr tfaddr.Provider, pv *version.Version) (string, bool) {
+	if pv == nil {
+		return "", false
+	}
+
+	dir := filepath.Join(append(append([]string{modPath}, providersDirElements...),
+		addr.Hostname.String(), addr.Namespace, addr.Type, pv.String(),
+		runtime.GOOS+"_"+runtime.GOARCH)...)
+
+	// ReadDir follows the symlink to the global plugin cache, if any
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+
+	prefix := "terraform-provider-" + addr.Type
+	for _, fi := range infos {
+		if !strings.HasPrefix(fi.Name(), prefix) {
+			continue
+		}
+
+		path := filepath.Join(dir, fi.Name())
+		fi, err := os.Stat(path)
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		if runtime.GOOS != "windows" && fi.Mode()&0111 == 0 {
+			// skip non-executable files, such as docs
+			continue
+		}
+
+		return path, true
+	}
+
+	return "", false
+}
+
+// ProviderPackageHash returns hash of the provider package unpacked
+// in the given directory, using the same scheme ("h1:") as Terraform
+// when recording hashes in the dependency lock file.
+//
+// Hashes of the other scheme ("zh:") are computed from the package
+// archives, which are not retained after installation,
+// so these cannot be used to verify an installed package.
+func ProviderPackageHash(dir string) (string, error) {
+	// Any symlink to the global plugin cache is resolved first,
+	// as paths within the package are relative to its target
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+
+	files := make([]string, 0)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	summary := sha256.New()
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", fmt.Errorf("unsupported filename with newline: %q", file)
+		}
+
+		fileHash, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(summary, "%x  %s\n", fileHash, file)
+	}
+
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+func fileSHA256(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,75 @@
+package datadir
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+func TestProviderPluginPath(t *testing.T) {
+	modPath := t.TempDir()
+	addr := tfaddr.NewDefaultProvider("aws")
+	pv := version.Must(version.NewVersion("3.63.0"))
+
+	_, ok := ProviderPluginPath(modPath, addr, pv)
+	if ok {
+		t.Fatal("expected plugin not to be found")
+	}
+
+	pluginDir := filepath.Join(modPath, ".terraform", "providers",
+		"registry.terraform.io", "hashicorp", "aws", "3.63.0",
+		runtime.GOOS+"_"+runtime.GOARCH)
+	err := os.MkdirAll(pluginDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(pluginDir, "CHANGELOG.md"), []byte{}, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPath := filepath.Join(pluginDir, "terraform-provider-aws_v3.63.0_x5")
+	err = ioutil.WriteFile(expectedPath, []byte{}, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, ok := ProviderPluginPath(modPath, addr, pv)
+	if !ok {
+		t.Fatal("expected plugin to be found")
+	}
+	if path != expectedPath {
+		t.Fatalf("unexpected path.\nexpected: %q\ngiven: %q", expectedPath, path)
+	}
+
+	_, ok = ProviderPluginPath(modPath, addr, version.Must(version.NewVersion("3.64.0")))
+	if ok {
+		t.Fatal("expected plugin of different version not to be found")
+	}
+}
+
+func TestProviderPackageHash(t *testing.T) {
+	pluginDir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(pluginDir, "LICENSE"), []byte("MPL-2.0\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(pluginDir, "terraform-provider-aws_v3.63.0_x5"), []byte("binary"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := ProviderPackageHash(pluginDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHash := "h1:omnyI8Huwr66T8qtgG9Hja85DDzngXVmRpnkcMfWMfo="
+	if hash != expectedHash {
+		t.Fatalf("unexpected hash.\nexpected: %q\ngiven: %q", expectedHash, hash)
+	}
+}
@@ -2,22 +2,139 @@ package exec
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
 type ExecutorOpts struct {
-	ExecPath    string
+	ExecPath string
+	// ExplicitExecPath indicates whether ExecPath was configured
+	// explicitly (via CLI flag or config option), as opposed
+	// to being discovered on $PATH
+	ExplicitExecPath bool
+
 	ExecLogPath string
 	Timeout     time.Duration
+
+	// FolderExecPaths maps (workspace folder) paths to paths
+	// of Terraform binaries, which take precedence over ExecPath
+	// for any modules within these folders
+	FolderExecPaths map[string]string
+
+	// VersionsDir represents a directory of installed Terraform
+	// versions to select from based on version constraints of each module
+	VersionsDir string
+
+	// LaunchProviderPlugins indicates whether provider plugins
+	// installed in the module may be launched to obtain schemas
+	// when no Terraform binary is found
+	LaunchProviderPlugins bool
+}
+
+// ExecPathForModule returns path of the Terraform binary
+// configured for the most specific folder containing the module,
+// or ExecPath if no such folder is configured
+func (eo *ExecutorOpts) ExecPathForModule(modPath string) string {
+	if execPath, ok := eo.folderExecPath(modPath); ok {
+		return execPath
+	}
+	return eo.ExecPath
+}
+
+// ExplicitExecPathForModule returns path of the Terraform binary
+// configured explicitly for the module, i.e. for the most specific
+// folder containing it, or globally, if any
+func (eo *ExecutorOpts) ExplicitExecPathForModule(modPath string) (string, bool) {
+	if execPath, ok := eo.folderExecPath(modPath); ok {
+		return execPath, true
+	}
+	if eo.ExplicitExecPath && eo.ExecPath != "" {
+		return eo.ExecPath, true
+	}
+	return "", false
+}
+
+func (eo *ExecutorOpts) folderExecPath(modPath string) (string, bool) {
+	execPath := ""
+	matchedFolder := ""
+	for folderPath, folderExecPath := range eo.FolderExecPaths {
+		if !isWithinPath(folderPath, modPath) {
+			continue
+		}
+		if len(folderPath) > len(matchedFolder) {
+			matchedFolder = folderPath
+			execPath = folderExecPath
+		}
+	}
+	return execPath, matchedFolder != ""
+}
+
+func isWithinPath(parentPath, path string) bool {
+	rel, err := filepath.Rel(parentPath, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// SharedExecutorOpts holds executor options which may be replaced
+// (e.g. when settings change) while being read from other goroutines.
+//
+// Options are never modified in place, readers always obtain
+// a snapshot which remains unchanged.
+type SharedExecutorOpts struct {
+	mu   sync.RWMutex
+	opts *ExecutorOpts
+}
+
+func NewSharedExecutorOpts(opts *ExecutorOpts) *SharedExecutorOpts {
+	return &SharedExecutorOpts{opts: opts}
+}
+
+// Get returns a snapshot of the current options,
+// which must not be modified
+func (so *SharedExecutorOpts) Get() *ExecutorOpts {
+	so.mu.RLock()
+	defer so.mu.RUnlock()
+	return so.opts
+}
+
+// Update replaces the current options with a copy
+// modified by the given function
+func (so *SharedExecutorOpts) Update(modify func(opts *ExecutorOpts)) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	newOpts := ExecutorOpts{}
+	if so.opts != nil {
+		newOpts = *so.opts
+	}
+	modify(&newOpts)
+	so.opts = &newOpts
 }
 
 var ctxExecOpts = ctxKey("executor opts")
 
+// ExecutorOptsFromContext returns executor options from the context,
+// i.e. a snapshot of current options where these are shared
 func ExecutorOptsFromContext(ctx context.Context) (*ExecutorOpts, bool) {
-	opts, ok := ctx.Value(ctxExecOpts).(*ExecutorOpts)
-	return opts, ok
+	switch opts := ctx.Value(ctxExecOpts).(type) {
+	case *ExecutorOpts:
+		return opts, true
+	case *SharedExecutorOpts:
+		return opts.Get(), true
+	}
+	return nil, false
 }
 
 func WithExecutorOpts(ctx context.Context, opts *ExecutorOpts) context.Context {
 	return context.WithValue(ctx, ctxExecOpts, opts)
 }
+
+// WithSharedExecutorOpts returns context with executor options
+// which may be replaced during the lifetime of the context
+func WithSharedExecutorOpts(ctx context.Context, opts *SharedExecutorOpts) context.Context {
+	return context.WithValue(ctx, ctxExecOpts, opts)
+}
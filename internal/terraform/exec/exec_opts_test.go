@@ -0,0 +1,52 @@
+package exec
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSharedExecutorOpts_folderExecPaths(t *testing.T) {
+	folderPath := filepath.Join("workspace", "folder")
+	modPath := filepath.Join(folderPath, "module")
+
+	shared := NewSharedExecutorOpts(&ExecutorOpts{
+		ExecPath: "/usr/bin/terraform",
+	})
+	ctx := WithSharedExecutorOpts(context.Background(), shared)
+
+	snapshot, ok := ExecutorOptsFromContext(ctx)
+	if !ok {
+		t.Fatal("expected executor options in context")
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		shared.Update(func(opts *ExecutorOpts) {
+			opts.FolderExecPaths = map[string]string{
+				folderPath: "/opt/terraform",
+			}
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		opts, _ := ExecutorOptsFromContext(ctx)
+		opts.ExecPathForModule(modPath)
+	}()
+	wg.Wait()
+
+	if execPath := snapshot.ExecPathForModule(modPath); execPath != "/usr/bin/terraform" {
+		t.Fatalf("expected previous snapshot to remain unchanged, given: %q", execPath)
+	}
+
+	opts, _ := ExecutorOptsFromContext(ctx)
+	if execPath := opts.ExecPathForModule(modPath); execPath != "/opt/terraform" {
+		t.Fatalf("expected folder exec path, given: %q", execPath)
+	}
+	if opts.ExecPath != "/usr/bin/terraform" {
+		t.Fatalf("expected exec path to be retained, given: %q", opts.ExecPath)
+	}
+}
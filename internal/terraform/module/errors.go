@@ -2,6 +2,8 @@ package module
 
 import (
 	"fmt"
+
+	"github.com/hashicorp/go-version"
 )
 
 type ModuleNotFoundErr struct {
@@ -36,3 +38,16 @@ func IsTerraformNotFound(err error) bool {
 	_, ok := err.(NoTerraformExecPathErr)
 	return ok
 }
+
+type NoMatchingTerraformVersionErr struct {
+	Constraints version.Constraints
+	VersionsDir string
+}
+
+func (e *NoMatchingTerraformVersionErr) Error() string {
+	if len(e.Constraints) == 0 {
+		return fmt.Sprintf("no Terraform version installed in %s", e.VersionsDir)
+	}
+	return fmt.Sprintf("no Terraform version matching %q installed in %s",
+		e.Constraints.String(), e.VersionsDir)
+}
@@ -130,7 +130,7 @@ func (ml *moduleLoader) executeModuleOp(ctx context.Context, modOp ModuleOperati
 			ml.logger.Printf("failed to get terraform version: %s", opErr)
 		}
 	case op.OpTypeObtainSchema:
-		opErr = ObtainSchema(ctx, ml.modStore, ml.schemaStore, modOp.ModulePath)
+		opErr = ObtainSchema(ctx, ml.fs, ml.modStore, ml.schemaStore, modOp.ModulePath)
 		if opErr != nil {
 			ml.logger.Printf("failed to obtain schema: %s", opErr)
 		}
@@ -150,10 +150,12 @@ func (ml *moduleLoader) executeModuleOp(ctx context.Context, modOp ModuleOperati
 			ml.logger.Printf("failed to parse module manifest: %s", opErr)
 		}
 	case op.OpTypeLoadModuleMetadata:
+		oldMod, _ := ml.modStore.ModuleByPath(modOp.ModulePath)
 		opErr = LoadModuleMetadata(ml.modStore, modOp.ModulePath)
 		if opErr != nil {
 			ml.logger.Printf("failed to load module metadata: %s", opErr)
 		}
+		ml.reselectTerraformVersion(ctx, oldMod)
 	case op.OpTypeDecodeReferenceTargets:
 		opErr = DecodeReferenceTargets(ctx, ml.modStore, ml.schemaStore, modOp.ModulePath)
 		if opErr != nil {
@@ -169,6 +171,21 @@ func (ml *moduleLoader) executeModuleOp(ctx context.Context, modOp ModuleOperati
 		if opErr != nil {
 			ml.logger.Printf("failed to decode vars references: %s", opErr)
 		}
+	case op.OpTypeValidateModuleSchema:
+		opErr = ValidateModuleSchema(ml.modStore, ml.schemaStore, modOp.ModulePath)
+		if opErr != nil {
+			ml.logger.Printf("failed to validate module schema: %s", opErr)
+		}
+	case op.OpTypeValidateReferences:
+		opErr = ValidateReferences(ml.modStore, modOp.ModulePath)
+		if opErr != nil {
+			ml.logger.Printf("failed to validate references: %s", opErr)
+		}
+	case op.OpTypeValidateVariables:
+		opErr = ValidateVariables(ml.modStore, modOp.ModulePath)
+		if opErr != nil {
+			ml.logger.Printf("failed to validate variables: %s", opErr)
+		}
 	default:
 		ml.logger.Printf("%s: unknown operation (%#v) for module operation",
 			modOp.ModulePath, modOp.Type)
@@ -181,6 +198,31 @@ func (ml *moduleLoader) executeModuleOp(ctx context.Context, modOp ModuleOperati
 	}
 }
 
+// reselectTerraformVersion gets Terraform version again if the module's
+// version constraints changed and the version may be selected based on them
+func (ml *moduleLoader) reselectTerraformVersion(ctx context.Context, oldMod *state.Module) {
+	opts, ok := exec.ExecutorOptsFromContext(ctx)
+	if !ok || oldMod == nil || !versionSelectionEnabled(opts, oldMod.Path) {
+		return
+	}
+	if _, ok := VersionResolverFromContext(ctx); !ok {
+		return
+	}
+
+	mod, err := ml.modStore.ModuleByPath(oldMod.Path)
+	if err != nil {
+		return
+	}
+	if mod.Meta.CoreRequirements.Equals(oldMod.Meta.CoreRequirements) {
+		return
+	}
+
+	err = ml.EnqueueModuleOp(NewModuleOperation(mod.Path, op.OpTypeGetTerraformVersion))
+	if err != nil {
+		ml.logger.Printf("failed to enqueue terraform version: %s", err)
+	}
+}
+
 func (ml *moduleLoader) EnqueueModuleOp(modOp ModuleOperation) error {
 	mod, err := ml.modStore.ModuleByPath(modOp.ModulePath)
 	if err != nil {
@@ -190,9 +232,13 @@ func (ml *moduleLoader) EnqueueModuleOp(modOp ModuleOperation) error {
 	ml.logger.Printf("ML: enqueing %q module operation: %q", modOp.Type, modOp.ModulePath)
 
 	if operationState(mod, modOp.Type) == op.OpStateQueued {
-		// avoid enqueuing duplicate operation
-		modOp.markAsDone()
-		return nil
+		// avoid enqueuing duplicate operation, while ensuring
+		// any deferred function runs after the queued one.
+		// Operations already dispatched are enqueued again.
+		if modOp.Defer == nil || ml.queue.DeferOp(modOp) {
+			modOp.markAsDone()
+			return nil
+		}
 	}
 
 	switch modOp.Type {
@@ -214,6 +260,12 @@ func (ml *moduleLoader) EnqueueModuleOp(modOp ModuleOperation) error {
 		ml.modStore.SetReferenceOriginsState(modOp.ModulePath, op.OpStateQueued)
 	case op.OpTypeDecodeVarsReferences:
 		ml.modStore.SetVarsReferenceOriginsState(modOp.ModulePath, op.OpStateQueued)
+	case op.OpTypeValidateModuleSchema:
+		ml.modStore.SetSchemaValidationState(modOp.ModulePath, op.OpStateQueued)
+	case op.OpTypeValidateReferences:
+		ml.modStore.SetReferenceValidationState(modOp.ModulePath, op.OpStateQueued)
+	case op.OpTypeValidateVariables:
+		ml.modStore.SetVarsValidationState(modOp.ModulePath, op.OpStateQueued)
 	}
 
 	ml.queue.PushOp(modOp)
@@ -242,6 +294,12 @@ func operationState(mod *state.Module, opType op.OpType) op.OpState {
 		return mod.RefOriginsState
 	case op.OpTypeDecodeVarsReferences:
 		return mod.VarsRefOriginsState
+	case op.OpTypeValidateModuleSchema:
+		return mod.SchemaValidationState
+	case op.OpTypeValidateReferences:
+		return mod.ReferenceValidationState
+	case op.OpTypeValidateVariables:
+		return mod.VarsValidationState
 	}
 	return op.OpStateUnknown
 }
@@ -93,6 +93,38 @@ func (mm *moduleManager) EnqueueModuleOp(modPath string, opType op.OpType, defer
 	return nil
 }
 
+// EnqueueModuleOpsInSequence enqueues the given operations for the module
+// such that each is only enqueued once the previous one has finished,
+// e.g. so that validation only runs after the module is decoded.
+//
+// The sequence continues regardless of errors of individual operations,
+// so that any state (such as diagnostics) reflects the latest content.
+func (mm *moduleManager) EnqueueModuleOpsInSequence(modPath string, opTypes ...op.OpType) error {
+	if len(opTypes) == 0 {
+		return nil
+	}
+
+	if mm.syncLoading {
+		// each operation is finished by the time it is enqueued
+		for _, opType := range opTypes {
+			err := mm.EnqueueModuleOp(modPath, opType, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var deferFunc DeferFunc
+	if len(opTypes) > 1 {
+		deferFunc = func(opErr error) {
+			mm.EnqueueModuleOpsInSequence(modPath, opTypes[1:]...)
+		}
+	}
+
+	return mm.EnqueueModuleOp(modPath, opTypes[0], deferFunc)
+}
+
 func (mm *moduleManager) CallersOfModule(modPath string) ([]Module, error) {
 	modules := make([]Module, 0)
 	callers, err := mm.moduleStore.CallersOfModule(modPath)
@@ -9,11 +9,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
 
 func TestModuleManager_ModuleCandidatesByPath(t *testing.T) {
@@ -387,3 +389,65 @@ func testLogger() *log.Logger {
 
 	return log.New(ioutil.Discard, "", 0)
 }
+
+func TestModuleManager_EnqueueModuleOpsInSequence(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "single-root-no-modules")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := filesystem.NewFilesystem()
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancelFunc)
+
+	mm := NewModuleManager(ctx, fs, ss.Modules, ss.ProviderSchemas)
+	mm.SetLogger(testLogger())
+	t.Cleanup(mm.CancelLoading)
+
+	_, err = mm.AddModule(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = mm.EnqueueModuleOpsInSequence(modPath,
+		op.OpTypeParseModuleConfiguration,
+		op.OpTypeLoadModuleMetadata,
+		op.OpTypeDecodeReferenceTargets,
+		op.OpTypeDecodeReferenceOrigins,
+		op.OpTypeValidateReferences)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// operations following the first one are only
+	// enqueued once the previous one has finished
+	for {
+		mod, err := ss.Modules.ModuleByPath(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mod.ReferenceValidationState == op.OpStateLoaded {
+			if mod.RefOriginsState != op.OpStateLoaded || mod.RefTargetsState != op.OpStateLoaded {
+				t.Fatalf("expected references to be decoded before validation, given states: %s, %s",
+					mod.RefTargetsState, mod.RefOriginsState)
+			}
+			break
+		}
+		if mod.ReferenceValidationState != op.OpStateUnknown && mod.RefOriginsState != op.OpStateLoaded {
+			t.Fatalf("expected validation to be enqueued after references are decoded, given state: %s",
+				mod.RefOriginsState)
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for validation")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
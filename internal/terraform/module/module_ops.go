@@ -6,13 +6,18 @@ import (
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/lang"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/decoder"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/schemas"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/hashicorp/terraform-schema/earlydecoder"
 	"github.com/hashicorp/terraform-schema/module"
@@ -58,6 +63,11 @@ func GetTerraformVersion(ctx context.Context, modStore *state.ModuleStore, modPa
 	}
 	defer modStore.SetTerraformVersionState(modPath, op.OpStateLoaded)
 
+	err = modStore.UpdateTerraformVersionDiagnostics(modPath, requiredVersionDiagnostics(ctx, mod))
+	if err != nil {
+		return err
+	}
+
 	tfExec, err := TerraformExecutorForModule(ctx, mod.Path)
 	if err != nil {
 		sErr := modStore.UpdateTerraformVersion(modPath, nil, nil, err)
@@ -101,22 +111,28 @@ func providerVersions(pv map[string]*version.Version) map[tfaddr.Provider]*versi
 	return m
 }
 
-func ObtainSchema(ctx context.Context, modStore *state.ModuleStore, schemaStore *state.ProviderSchemaStore, modPath string) error {
+func ObtainSchema(ctx context.Context, fs filesystem.Filesystem, modStore *state.ModuleStore, schemaStore *state.ProviderSchemaStore, modPath string) error {
 	mod, err := modStore.ModuleByPath(modPath)
 	if err != nil {
 		return err
 	}
 
-	tfExec, err := TerraformExecutorForModule(ctx, mod.Path)
-	if err != nil {
-		sErr := modStore.FinishProviderSchemaLoading(modPath, err)
-		if sErr != nil {
-			return sErr
+	// The lock file is treated as optional here, as it is only used
+	// when the exact provider versions are needed.
+	locks, _, _ := datadir.ParseDependencyLockFile(fs, modPath)
+
+	cache, hasCache := schemas.SchemaCacheFromContext(ctx)
+	if hasCache {
+		ok, err := obtainSchemaFromCache(cache, modStore, schemaStore, modPath, locks)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
 		}
-		return err
 	}
 
-	ps, err := tfExec.ProviderSchemas(ctx)
+	ps, err := providerSchemas(ctx, mod.Path, locks)
 	if err != nil {
 		sErr := modStore.FinishProviderSchemaLoading(modPath, err)
 		if sErr != nil {
@@ -146,11 +162,72 @@ func ObtainSchema(ctx context.Context, modStore *state.ModuleStore, schemaStore
 		if err != nil {
 			return err
 		}
+
+		if lock, ok := locks[pAddr]; ok && lock.Version != nil {
+			installedProviders[pAddr] = lock.Version
+
+			if hasCache {
+				// Caching is best-effort and any failure
+				// just means the schema is obtained again next time.
+				_ = cache.Put(pAddr, lock.Version, lock.Hashes, pJsonSchema)
+			}
+		}
 	}
 
 	return modStore.UpdateInstalledProviders(modPath, installedProviders)
 }
 
+// providerSchemas obtains schemas of providers via Terraform, or if Terraform
+// is not available and it was enabled explicitly, directly from provider
+// plugins installed in the module.
+func providerSchemas(ctx context.Context, modPath string, locks map[tfaddr.Provider]datadir.ProviderLock) (*tfjson.ProviderSchemas, error) {
+	tfExec, err := TerraformExecutorForModule(ctx, modPath)
+	if err != nil {
+		opts, ok := exec.ExecutorOptsFromContext(ctx)
+		if IsTerraformNotFound(err) && ok && opts.LaunchProviderPlugins && len(locks) > 0 {
+			return plugin.ProviderSchemas(ctx, modPath, locks)
+		}
+		return nil, err
+	}
+
+	return tfExec.ProviderSchemas(ctx)
+}
+
+// obtainSchemaFromCache loads schemas of all providers recorded in the
+// dependency lock file from the on-disk cache, if entries of all locked
+// versions are cached and match the recorded hashes.
+func obtainSchemaFromCache(cache *schemas.SchemaCache, modStore *state.ModuleStore,
+	schemaStore *state.ProviderSchemaStore, modPath string, locks map[tfaddr.Provider]datadir.ProviderLock) (bool, error) {
+	if len(locks) == 0 {
+		return false, nil
+	}
+
+	entries := make(map[tfaddr.Provider]*schemas.CacheEntry, len(locks))
+	for pAddr, lock := range locks {
+		entry, ok, err := cache.Get(pAddr, lock.Version, lock.Hashes)
+		if err != nil || !ok {
+			return false, nil
+		}
+		entries[pAddr] = entry
+	}
+
+	installedProviders := make(map[tfaddr.Provider]*version.Version, 0)
+	for pAddr, entry := range entries {
+		_, pv, pSchema, err := entry.ProviderSchema()
+		if err != nil {
+			return false, nil
+		}
+
+		err = schemaStore.AddLocalSchema(modPath, pAddr, pSchema)
+		if err != nil {
+			return false, err
+		}
+		installedProviders[pAddr] = pv
+	}
+
+	return true, modStore.UpdateInstalledProviders(modPath, installedProviders)
+}
+
 func ParseModuleConfiguration(fs filesystem.Filesystem, modStore *state.ModuleStore, modPath string) error {
 	err := modStore.SetModuleParsingState(modPath, op.OpStateLoading)
 	if err != nil {
@@ -239,7 +316,8 @@ func LoadModuleMetadata(modStore *state.ModuleStore, modPath string) error {
 	}
 
 	var mErr error
-	meta, diags := earlydecoder.LoadModule(mod.Path, mod.ParsedModuleFiles.AsMap())
+	files, _ := parser.MergeOverrideFiles(mod.ParsedModuleFiles)
+	meta, diags := earlydecoder.LoadModule(mod.Path, files.AsMap())
 	if len(diags) > 0 {
 		mErr = diags
 	}
@@ -272,8 +350,9 @@ func DecodeReferenceTargets(ctx context.Context, modStore *state.ModuleStore, sc
 	}
 
 	d, err := decoder.NewDecoder(ctx, &decoder.PathReader{
-		ModuleReader: modStore,
-		SchemaReader: schemaReader,
+		ModuleReader:   modStore,
+		SchemaReader:   schemaReader,
+		MergeOverrides: true,
 	}).Path(lang.Path{
 		Path:       modPath,
 		LanguageID: ilsp.Terraform.String(),
@@ -300,8 +379,9 @@ func DecodeReferenceOrigins(ctx context.Context, modStore *state.ModuleStore, sc
 	}
 
 	d := decoder.NewDecoder(ctx, &decoder.PathReader{
-		ModuleReader: modStore,
-		SchemaReader: schemaReader,
+		ModuleReader:   modStore,
+		SchemaReader:   schemaReader,
+		MergeOverrides: true,
 	})
 
 	moduleDecoder, err := d.Path(lang.Path{
@@ -349,3 +429,61 @@ func DecodeVarsReferences(ctx context.Context, modStore *state.ModuleStore, sche
 
 	return rErr
 }
+
+func ValidateModuleSchema(modStore *state.ModuleStore, schemaReader state.SchemaReader, modPath string) error {
+	err := modStore.SetSchemaValidationState(modPath, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	mod, err := modStore.ModuleByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	diags, vErr := decoder.ValidateModule(mod, schemaReader, modStore)
+	if vErr != nil {
+		// avoid publishing stale diagnostics
+		// if we are unable to validate
+		diags = make(ast.ModDiags, 0)
+	}
+
+	sErr := modStore.UpdateSchemaValidationDiagnostics(modPath, diags)
+	if sErr != nil {
+		return sErr
+	}
+
+	return vErr
+}
+
+func ValidateReferences(modStore *state.ModuleStore, modPath string) error {
+	err := modStore.SetReferenceValidationState(modPath, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	mod, err := modStore.ModuleByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	diags, unusedDiags := decoder.ValidateReferences(mod)
+
+	return modStore.UpdateReferenceValidationDiagnostics(modPath, diags, unusedDiags)
+}
+
+func ValidateVariables(modStore *state.ModuleStore, modPath string) error {
+	err := modStore.SetVarsValidationState(modPath, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	mod, err := modStore.ModuleByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	diags := decoder.ValidateVariables(mod)
+
+	return modStore.UpdateVarsValidationDiagnostics(modPath, diags)
+}
@@ -44,6 +44,30 @@ func (q *moduleOpsQueue) PopOp() (ModuleOperation, bool) {
 	return modOp, true
 }
 
+// DeferOp attaches the deferred function of the given operation
+// to a queued operation of the same type for the same module,
+// reporting whether there was such an operation
+func (q *moduleOpsQueue) DeferOp(modOp ModuleOperation) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, queuedOp := range q.q.ops {
+		if queuedOp.ModulePath != modOp.ModulePath || queuedOp.Type != modOp.Type {
+			continue
+		}
+		queuedDefer := queuedOp.Defer
+		q.q.ops[i].Defer = func(opErr error) {
+			if queuedDefer != nil {
+				queuedDefer(opErr)
+			}
+			modOp.Defer(opErr)
+		}
+		return true
+	}
+
+	return false
+}
+
 func (q *moduleOpsQueue) DequeueAllModuleOps(modPath string) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -91,3 +91,48 @@ func openModAtPath(t *testing.T, fs filesystem.Filesystem, dir, modName string)
 
 	return modPath
 }
+
+func TestModuleOpsQueue_deferOp(t *testing.T) {
+	fs := filesystem.NewFilesystem()
+	fs.SetLogger(testLogger())
+
+	mq := newModuleOpsQueue(fs)
+
+	modPath := closedModPath(t, fs, t.TempDir(), "alpha")
+
+	deferred := make([]string, 0)
+	queuedOp := NewModuleOperation(modPath, op.OpTypeDecodeReferenceOrigins)
+	queuedOp.Defer = func(opErr error) {
+		deferred = append(deferred, "queued")
+	}
+	mq.PushOp(queuedOp)
+
+	duplicateOp := NewModuleOperation(modPath, op.OpTypeDecodeReferenceOrigins)
+	duplicateOp.Defer = func(opErr error) {
+		deferred = append(deferred, "duplicate")
+	}
+	if !mq.DeferOp(duplicateOp) {
+		t.Fatal("expected deferred function to be attached to queued operation")
+	}
+
+	otherOp := NewModuleOperation(modPath, op.OpTypeDecodeReferenceTargets)
+	otherOp.Defer = func(opErr error) {}
+	if mq.DeferOp(otherOp) {
+		t.Fatal("expected no queued operation of different type to be found")
+	}
+
+	poppedOp, ok := mq.PopOp()
+	if !ok {
+		t.Fatal("expected PopOp to succeed")
+	}
+	poppedOp.Defer(nil)
+
+	expectedDeferred := []string{"queued", "duplicate"}
+	if len(deferred) != len(expectedDeferred) || deferred[0] != expectedDeferred[0] || deferred[1] != expectedDeferred[1] {
+		t.Fatalf("unexpected deferred functions: %q", deferred)
+	}
+
+	if mq.Len() != 0 {
+		t.Fatalf("expected no other operations to be queued, %d given", mq.Len())
+	}
+}
@@ -0,0 +1,239 @@
+package module
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/schemas"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/stretchr/testify/mock"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestObtainSchema_fromCache(t *testing.T) {
+	modPath := t.TempDir()
+	writeTestLockFile(t, modPath, "3.63.0", "h1:first")
+
+	cache := schemas.NewSchemaCache(filesystem.NewFilesystem(), t.TempDir())
+	addr := tfaddr.NewDefaultProvider("aws")
+	err := cache.Put(addr, version.Must(version.NewVersion("3.63.0")),
+		[]string{"h1:first"}, testAwsProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no executor is provided, so any attempt to invoke Terraform would fail
+	ctx := schemas.WithSchemaCache(context.Background(), cache)
+	err = ObtainSchema(ctx, filesystem.NewFilesystem(), ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := ss.ProviderSchemas.ProviderSchema(modPath, addr, version.MustConstraints(version.NewConstraint("3.63.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["aws_instance"]; !ok {
+		t.Fatal("expected aws_instance resource in schema")
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pv, ok := mod.InstalledProviders[addr]; !ok || pv.String() != "3.63.0" {
+		t.Fatalf("unexpected installed providers: %#v", mod.InstalledProviders)
+	}
+}
+
+func TestObtainSchema_staleCache(t *testing.T) {
+	modPath := t.TempDir()
+	writeTestLockFile(t, modPath, "3.64.0", "h1:second")
+
+	cache := schemas.NewSchemaCache(filesystem.NewFilesystem(), t.TempDir())
+	addr := tfaddr.NewDefaultProvider("aws")
+	err := cache.Put(addr, version.Must(version.NewVersion("3.63.0")),
+		[]string{"h1:first"}, testAwsProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tfCalls := &exec.TerraformMockCalls{
+		PerWorkDir: map[string][]*mock.Call{
+			modPath: {
+				{
+					Method:        "ProviderSchemas",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						mock.AnythingOfType("*context.valueCtx"),
+					},
+					ReturnArguments: []interface{}{
+						&tfjson.ProviderSchemas{
+							FormatVersion: "0.1",
+							Schemas: map[string]*tfjson.ProviderSchema{
+								"registry.terraform.io/hashicorp/aws": testAwsProviderSchema(),
+							},
+						},
+						nil,
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ctx = exec.WithExecutorFactory(ctx, exec.NewMockExecutor(tfCalls))
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{ExecPath: "tf-mock"})
+	ctx = schemas.WithSchemaCache(ctx, cache)
+
+	err = ObtainSchema(ctx, filesystem.NewFilesystem(), ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok, err := cache.Get(addr, version.Must(version.NewVersion("3.64.0")), []string{"h1:second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected newly obtained schema to be cached")
+	}
+}
+
+func writeTestLockFile(t *testing.T, modPath, pVersion, hash string) {
+	content := `provider "registry.terraform.io/hashicorp/aws" {
+  version = "` + pVersion + `"
+  hashes = [
+    "` + hash + `",
+  ]
+}
+`
+	err := ioutil.WriteFile(filepath.Join(modPath, ".terraform.lock.hcl"), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAwsProviderSchema() *tfjson.ProviderSchema {
+	return &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"aws_instance": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"ami": {
+							AttributeType: cty.String,
+							Required:      true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDecodeReferenceTargets_overrides(t *testing.T) {
+	modPath := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(modPath, "main.tf"),
+		[]byte("locals {\n  size = \"small\"\n}\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(modPath, "main_override.tf"),
+		[]byte("locals {\n  size = 42\n}\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ParseModuleConfiguration(filesystem.NewFilesystem(), ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(context.Background(), ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lang.Address{
+		lang.RootStep{Name: "local"},
+		lang.AttrStep{Name: "size"},
+	}
+	for _, target := range mod.RefTargets {
+		if !target.Addr.Equals(addr) {
+			continue
+		}
+		if target.Type != cty.Number {
+			t.Fatalf("expected overridden type of %s, given: %#v", addr, target.Type)
+		}
+		return
+	}
+	t.Fatalf("expected target for %s, given: %#v", addr, mod.RefTargets)
+}
+
+func TestProviderSchemas_launchProviderPlugins(t *testing.T) {
+	modPath := t.TempDir()
+	locks := map[tfaddr.Provider]datadir.ProviderLock{
+		tfaddr.NewDefaultProvider("aws"): {
+			Version: version.Must(version.NewVersion("3.63.0")),
+		},
+	}
+
+	ctx := exec.WithExecutorFactory(context.Background(), exec.NewMockExecutor(nil))
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{})
+
+	_, err := providerSchemas(ctx, modPath, locks)
+	if !IsTerraformNotFound(err) {
+		t.Fatalf("expected plugins not to be launched by default, given error: %#v", err)
+	}
+
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		LaunchProviderPlugins: true,
+	})
+	_, err = providerSchemas(ctx, modPath, locks)
+	if err == nil || IsTerraformNotFound(err) {
+		t.Fatalf("expected error of provider which is not installed, given: %#v", err)
+	}
+}
@@ -17,11 +17,15 @@ func _() {
 	_ = x[OpTypeLoadModuleMetadata-6]
 	_ = x[OpTypeDecodeReferenceTargets-7]
 	_ = x[OpTypeDecodeReferenceOrigins-8]
+	_ = x[OpTypeDecodeVarsReferences-9]
+	_ = x[OpTypeValidateModuleSchema-10]
+	_ = x[OpTypeValidateReferences-11]
+	_ = x[OpTypeValidateVariables-12]
 }
 
-const _OpType_name = "OpTypeUnknownOpTypeGetTerraformVersionOpTypeObtainSchemaOpTypeParseModuleConfigurationOpTypeParseVariablesOpTypeParseModuleManifestOpTypeLoadModuleMetadataOpTypeDecodeReferenceTargetsOpTypeDecodeReferenceOrigins"
+const _OpType_name = "OpTypeUnknownOpTypeGetTerraformVersionOpTypeObtainSchemaOpTypeParseModuleConfigurationOpTypeParseVariablesOpTypeParseModuleManifestOpTypeLoadModuleMetadataOpTypeDecodeReferenceTargetsOpTypeDecodeReferenceOriginsOpTypeDecodeVarsReferencesOpTypeValidateModuleSchemaOpTypeValidateReferencesOpTypeValidateVariables"
 
-var _OpType_index = [...]uint8{0, 13, 38, 56, 86, 106, 131, 155, 183, 211}
+var _OpType_index = [...]uint16{0, 13, 38, 56, 86, 106, 131, 155, 183, 211, 237, 263, 287, 310}
 
 func (i OpType) String() string {
 	if i >= OpType(len(_OpType_index)-1) {
@@ -24,4 +24,7 @@ const (
 	OpTypeDecodeReferenceTargets
 	OpTypeDecodeReferenceOrigins
 	OpTypeDecodeVarsReferences
+	OpTypeValidateModuleSchema
+	OpTypeValidateReferences
+	OpTypeValidateVariables
 )
@@ -13,7 +13,7 @@ func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.Terra
 		return nil, fmt.Errorf("no terraform executor provided")
 	}
 
-	execPath, err := TerraformExecPath(ctx)
+	execPath, err := TerraformExecPath(ctx, modPath)
 	if err != nil {
 		return nil, err
 	}
@@ -34,11 +34,34 @@ func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.Terra
 	return tfExec, nil
 }
 
-func TerraformExecPath(ctx context.Context) (string, error) {
+// TerraformExecPath returns path of the Terraform binary to use
+// for the given module, which may differ between workspace folders.
+//
+// Unless a path is configured explicitly (via CLI flag or config
+// option, globally or for the workspace folder), the binary may be
+// selected from installed versions based on module's constraints.
+func TerraformExecPath(ctx context.Context, modPath string) (string, error) {
 	opts, ok := exec.ExecutorOptsFromContext(ctx)
-	if ok && opts.ExecPath != "" {
-		return opts.ExecPath, nil
-	} else {
+	if !ok {
+		return "", NoTerraformExecPathErr{}
+	}
+
+	if execPath, ok := opts.ExplicitExecPathForModule(modPath); ok {
+		return execPath, nil
+	}
+
+	vr, ok := VersionResolverFromContext(ctx)
+	if ok && opts.VersionsDir != "" {
+		execPath, err := vr.ResolveExecPath(modPath, opts.VersionsDir)
+		if err == nil {
+			return execPath, nil
+		}
+		// fall back to the configured binary
+	}
+
+	execPath := opts.ExecPathForModule(modPath)
+	if execPath == "" {
 		return "", NoTerraformExecPathErr{}
 	}
+	return execPath, nil
 }
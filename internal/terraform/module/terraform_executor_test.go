@@ -0,0 +1,46 @@
+package module
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+func TestTerraformExecPath_folders(t *testing.T) {
+	root := t.TempDir()
+	ctx := exec.WithExecutorOpts(context.Background(), &exec.ExecutorOpts{
+		ExecPath: "/global/terraform",
+		FolderExecPaths: map[string]string{
+			filepath.Join(root, "first"):           "/first/terraform",
+			filepath.Join(root, "first", "nested"): "/nested/terraform",
+		},
+	})
+
+	testCases := []struct {
+		modPath          string
+		expectedExecPath string
+	}{
+		{filepath.Join(root, "first", "mod"), "/first/terraform"},
+		{filepath.Join(root, "first", "nested", "mod"), "/nested/terraform"},
+		{filepath.Join(root, "first-other", "mod"), "/global/terraform"},
+		{filepath.Join(root, "second"), "/global/terraform"},
+	}
+	for _, tc := range testCases {
+		execPath, err := TerraformExecPath(ctx, tc.modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if execPath != tc.expectedExecPath {
+			t.Errorf("%s: expected %q, given %q", tc.modPath, tc.expectedExecPath, execPath)
+		}
+	}
+}
+
+func TestTerraformExecPath_missing(t *testing.T) {
+	_, err := TerraformExecPath(context.Background(), t.TempDir())
+	if _, ok := err.(NoTerraformExecPathErr); !ok {
+		t.Fatalf("expected NoTerraformExecPathErr, given: %#v", err)
+	}
+}
@@ -36,6 +36,7 @@ type ModuleManager interface {
 	AddModule(modPath string) (Module, error)
 	RemoveModule(modPath string) error
 	EnqueueModuleOp(modPath string, opType op.OpType, deferFunc DeferFunc) error
+	EnqueueModuleOpsInSequence(modPath string, opTypes ...op.OpType) error
 	CancelLoading()
 }
 
@@ -0,0 +1,220 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+// VersionFileName represents the name of a file which pins
+// the Terraform version for modules in the same directory
+// and any subdirectories, as recognized by tfenv
+const VersionFileName = ".terraform-version"
+
+// VersionResolver selects a Terraform binary for each module
+// from a directory of installed versions, where each version
+// has its own subdirectory (e.g. <dir>/1.1.7/terraform)
+// as laid out by tfenv or hc-install
+type VersionResolver struct {
+	modStore *state.ModuleStore
+}
+
+func NewVersionResolver(modStore *state.ModuleStore) *VersionResolver {
+	return &VersionResolver{
+		modStore: modStore,
+	}
+}
+
+// ResolveExecPath returns path of the newest Terraform binary within
+// versionsDir matching both required_version of the module
+// and any .terraform-version file in the module or its parents
+func (vr *VersionResolver) ResolveExecPath(modPath, versionsDir string) (string, error) {
+	var constraints version.Constraints
+
+	mod, err := vr.modStore.ModuleByPath(modPath)
+	if err == nil {
+		constraints = append(constraints, mod.Meta.CoreRequirements...)
+	}
+
+	fileConstraints, err := versionFileConstraints(modPath)
+	if err != nil {
+		return "", err
+	}
+	constraints = append(constraints, fileConstraints...)
+
+	installed, err := InstalledVersions(versionsDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, iv := range installed {
+		if constraints.Check(iv.Version) {
+			return iv.ExecPath, nil
+		}
+	}
+
+	return "", &NoMatchingTerraformVersionErr{
+		Constraints: constraints,
+		VersionsDir: versionsDir,
+	}
+}
+
+// requiredVersionDiagnostics reports required_version attributes
+// of the module if version selection is enabled and none
+// of the installed Terraform versions match them
+func requiredVersionDiagnostics(ctx context.Context, mod *state.Module) ast.ModDiags {
+	diags := make(ast.ModDiags, 0)
+
+	opts, ok := exec.ExecutorOptsFromContext(ctx)
+	if !ok || !versionSelectionEnabled(opts, mod.Path) {
+		return diags
+	}
+	vr, ok := VersionResolverFromContext(ctx)
+	if !ok || len(mod.Meta.CoreRequirements) == 0 {
+		return diags
+	}
+
+	_, err := vr.ResolveExecPath(mod.Path, opts.VersionsDir)
+	nmErr, ok := err.(*NoMatchingTerraformVersionErr)
+	if !ok {
+		return diags
+	}
+
+	for name, f := range mod.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			attr, ok := block.Body.Attributes["required_version"]
+			if !ok {
+				continue
+			}
+			diags[name] = append(diags[name], &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "No matching Terraform version installed",
+				Detail: fmt.Sprintf("None of Terraform versions installed in %s match %q, "+
+					"the default Terraform binary is used instead.",
+					nmErr.VersionsDir, nmErr.Constraints.String()),
+				Subject: attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// versionSelectionEnabled reports whether the Terraform binary
+// for the module is to be selected from installed versions,
+// i.e. no binary is configured explicitly for the module
+func versionSelectionEnabled(opts *exec.ExecutorOpts, modPath string) bool {
+	if opts.VersionsDir == "" {
+		return false
+	}
+	_, ok := opts.ExplicitExecPathForModule(modPath)
+	return !ok
+}
+
+// InstalledVersion represents a Terraform binary
+// installed in a versions directory
+type InstalledVersion struct {
+	Version  *version.Version
+	ExecPath string
+}
+
+// InstalledVersions returns Terraform binaries installed within dir,
+// sorted from the newest version
+func InstalledVersions(dir string) ([]InstalledVersion, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make([]InstalledVersion, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.NewVersion(entry.Name())
+		if err != nil {
+			continue
+		}
+		execPath := filepath.Join(dir, entry.Name(), executableName())
+		fi, err := os.Stat(execPath)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		installed = append(installed, InstalledVersion{
+			Version:  v,
+			ExecPath: execPath,
+		})
+	}
+
+	sort.SliceStable(installed, func(i, j int) bool {
+		return installed[i].Version.GreaterThan(installed[j].Version)
+	})
+
+	return installed, nil
+}
+
+// versionFileConstraints returns constraints from the nearest
+// .terraform-version file found in modPath or its parents.
+// Any keyword other than a version or constraint
+// (such as "latest") is treated as no constraint.
+func versionFileConstraints(modPath string) (version.Constraints, error) {
+	dir := modPath
+	for {
+		b, err := ioutil.ReadFile(filepath.Join(dir, VersionFileName))
+		if err == nil {
+			c, err := version.NewConstraint(strings.TrimSpace(string(b)))
+			if err != nil {
+				return version.Constraints{}, nil
+			}
+			return c, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return version.Constraints{}, nil
+		}
+		dir = parentDir
+	}
+}
+
+func executableName() string {
+	if runtime.GOOS == "windows" {
+		return "terraform.exe"
+	}
+	return "terraform"
+}
+
+type ctxKey string
+
+var ctxVersionResolver = ctxKey("version resolver")
+
+func WithVersionResolver(ctx context.Context, vr *VersionResolver) context.Context {
+	return context.WithValue(ctx, ctxVersionResolver, vr)
+}
+
+func VersionResolverFromContext(ctx context.Context) (*VersionResolver, bool) {
+	vr, ok := ctx.Value(ctxVersionResolver).(*VersionResolver)
+	return vr, ok
+}
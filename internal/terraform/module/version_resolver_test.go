@@ -0,0 +1,286 @@
+package module
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestVersionResolver_ResolveExecPath(t *testing.T) {
+	versionsDir := testVersionsDir(t, "0.12.31", "1.0.11", "1.1.7")
+	root := t.TempDir()
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vr := NewVersionResolver(ss.Modules)
+
+	testCases := []struct {
+		name            string
+		requirements    string
+		versionFile     string
+		expectedVersion string
+		expectedNoMatch bool
+	}{
+		{"no constraints", "", "", "1.1.7", false},
+		{"required_version", "~> 1.0.0", "", "1.0.11", false},
+		{"terraform-version", "", "0.12.31", "0.12.31", false},
+		{"terraform-version keyword", "", "latest", "1.1.7", false},
+		{"both", ">= 0.12", "1.0.11", "1.0.11", false},
+		{"no match", "~> 0.14.0", "", "", true},
+		{"conflicting", "~> 1.0", "0.12.31", "", true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// .terraform-version is placed in the parent directory
+			// to verify that it applies to any nested modules
+			parentDir := filepath.Join(root, string(rune('a'+i)))
+			modPath := filepath.Join(parentDir, "module")
+			err := os.MkdirAll(modPath, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.versionFile != "" {
+				err := ioutil.WriteFile(filepath.Join(parentDir, VersionFileName),
+					[]byte(tc.versionFile+"\n"), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = ss.Modules.Add(modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.requirements != "" {
+				err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+					CoreRequirements: testConstraint(t, tc.requirements),
+				}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			execPath, err := vr.ResolveExecPath(modPath, versionsDir)
+			if tc.expectedNoMatch {
+				if _, ok := err.(*NoMatchingTerraformVersionErr); !ok {
+					t.Fatalf("expected NoMatchingTerraformVersionErr, given: %#v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedPath := filepath.Join(versionsDir, tc.expectedVersion, executableName())
+			if execPath != expectedPath {
+				t.Fatalf("expected %q, given %q", expectedPath, execPath)
+			}
+		})
+	}
+}
+
+func TestTerraformExecPath_versionsDir(t *testing.T) {
+	versionsDir := testVersionsDir(t, "1.0.11")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matchingPath, mismatchingPath := t.TempDir(), t.TempDir()
+	for path, constraint := range map[string]string{
+		matchingPath:    "~> 1.0",
+		mismatchingPath: "~> 0.14.0",
+	} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.Modules.UpdateMetadata(path, &tfmod.Meta{
+			CoreRequirements: testConstraint(t, constraint),
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := exec.WithExecutorOpts(context.Background(), &exec.ExecutorOpts{
+		ExecPath:    "/global/terraform",
+		VersionsDir: versionsDir,
+	})
+	ctx = WithVersionResolver(ctx, NewVersionResolver(ss.Modules))
+
+	execPath, err := TerraformExecPath(ctx, matchingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPath := filepath.Join(versionsDir, "1.0.11", executableName())
+	if execPath != expectedPath {
+		t.Fatalf("expected %q, given %q", expectedPath, execPath)
+	}
+
+	execPath, err = TerraformExecPath(ctx, mismatchingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execPath != "/global/terraform" {
+		t.Fatalf("expected fallback to global path, given %q", execPath)
+	}
+}
+
+func TestTerraformExecPath_explicitExecPath(t *testing.T) {
+	versionsDir := testVersionsDir(t, "1.0.11")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	folderPath := t.TempDir()
+	folderModPath := filepath.Join(folderPath, "mod")
+	modPath := t.TempDir()
+	for _, path := range []string{folderModPath, modPath} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.Modules.UpdateMetadata(path, &tfmod.Meta{
+			CoreRequirements: testConstraint(t, "~> 1.0"),
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := exec.WithExecutorOpts(context.Background(), &exec.ExecutorOpts{
+		ExecPath:         "/global/terraform",
+		ExplicitExecPath: true,
+		FolderExecPaths: map[string]string{
+			folderPath: "/folder/terraform",
+		},
+		VersionsDir: versionsDir,
+	})
+	ctx = WithVersionResolver(ctx, NewVersionResolver(ss.Modules))
+
+	execPath, err := TerraformExecPath(ctx, folderModPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execPath != "/folder/terraform" {
+		t.Fatalf("expected folder path, given %q", execPath)
+	}
+
+	execPath, err = TerraformExecPath(ctx, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execPath != "/global/terraform" {
+		t.Fatalf("expected explicit global path, given %q", execPath)
+	}
+}
+
+func TestRequiredVersionDiagnostics(t *testing.T) {
+	versionsDir := testVersionsDir(t, "1.0.11")
+	modPath := t.TempDir()
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := []byte(`terraform {
+  required_version = "~> 0.14.0"
+}
+`)
+	f, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	err = ss.Modules.UpdateParsedModuleFiles(modPath, ast.ModFiles{
+		"main.tf": f,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		CoreRequirements: testConstraint(t, "~> 0.14.0"),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := exec.WithExecutorOpts(context.Background(), &exec.ExecutorOpts{
+		ExecPath: "/global/terraform",
+	})
+	ctx = WithVersionResolver(ctx, NewVersionResolver(ss.Modules))
+	if count := requiredVersionDiagnostics(ctx, mod).Count(); count != 0 {
+		t.Fatalf("expected no diagnostics without versions dir, given %d", count)
+	}
+
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath:    "/global/terraform",
+		VersionsDir: versionsDir,
+	})
+	modDiags := requiredVersionDiagnostics(ctx, mod)
+	if len(modDiags["main.tf"]) != 1 {
+		t.Fatalf("expected 1 diagnostic, given: %#v", modDiags)
+	}
+	expectedRange := hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 2, Column: 22, Byte: 33},
+		End:      hcl.Pos{Line: 2, Column: 33, Byte: 44},
+	}
+	if *modDiags["main.tf"][0].Subject != expectedRange {
+		t.Fatalf("unexpected range: %#v", modDiags["main.tf"][0].Subject)
+	}
+}
+
+func testVersionsDir(t *testing.T, versions ...string) string {
+	dir := t.TempDir()
+	for _, v := range versions {
+		err := os.MkdirAll(filepath.Join(dir, v), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, v, executableName()), []byte{}, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// directories without a binary should be ignored
+	err := os.MkdirAll(filepath.Join(dir, "2.0.0"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func testConstraint(t *testing.T, v string) version.Constraints {
+	c, err := version.NewConstraint(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/go-multierror"
@@ -45,6 +46,10 @@ type Walker struct {
 	queueMu  *sync.Mutex
 	pushChan chan struct{}
 
+	// roots represent all paths ever enqueued (and not removed)
+	// which makes it possible to walk them again via Rewalk
+	roots map[string]bool
+
 	walking    bool
 	walkingMu  *sync.RWMutex
 	cancelFunc context.CancelFunc
@@ -52,6 +57,7 @@ type Walker struct {
 
 	excludeModulePaths   map[string]bool
 	ignoreDirectoryNames map[string]bool
+	optsMu               *sync.RWMutex
 }
 
 // queueCap represents channel buffer size
@@ -68,8 +74,11 @@ func NewWalker(fs filesystem.Filesystem, modMgr ModuleManager) *Walker {
 		queue:                newWalkerQueue(fs),
 		queueMu:              &sync.Mutex{},
 		pushChan:             make(chan struct{}, queueCap),
+		roots:                make(map[string]bool, 0),
 		doneCh:               make(chan struct{}, 0),
-		ignoreDirectoryNames: skipDirNames,
+		excludeModulePaths:   make(map[string]bool, 0),
+		ignoreDirectoryNames: defaultIgnoreDirectoryNames(),
+		optsMu:               &sync.RWMutex{},
 	}
 }
 
@@ -81,19 +90,70 @@ func (w *Walker) SetWatcher(watcher Watcher) {
 	w.watcher = watcher
 }
 
+func defaultIgnoreDirectoryNames() map[string]bool {
+	names := make(map[string]bool, len(skipDirNames))
+	for name := range skipDirNames {
+		names[name] = true
+	}
+	return names
+}
+
+// SetExcludeModulePaths replaces any previously excluded module paths
 func (w *Walker) SetExcludeModulePaths(excludeModulePaths []string) {
+	w.optsMu.Lock()
+	defer w.optsMu.Unlock()
+
 	w.excludeModulePaths = make(map[string]bool)
 	for _, path := range excludeModulePaths {
 		w.excludeModulePaths[path] = true
 	}
 }
 
+// SetIgnoreDirectoryNames replaces any previously ignored directory names,
+// with the exception of the default ones which are always ignored
 func (w *Walker) SetIgnoreDirectoryNames(ignoreDirectoryNames []string) {
+	w.optsMu.Lock()
+	defer w.optsMu.Unlock()
+
+	w.ignoreDirectoryNames = defaultIgnoreDirectoryNames()
 	for _, path := range ignoreDirectoryNames {
 		w.ignoreDirectoryNames[path] = true
 	}
 }
 
+// IsPathExcluded reports whether the given module path is excluded
+// from walking, either explicitly or by being within an ignored
+// directory of any walked path
+func (w *Walker) IsPathExcluded(modPath string) bool {
+	w.queueMu.Lock()
+	roots := make([]string, 0, len(w.roots))
+	for root := range w.roots {
+		roots = append(roots, root)
+	}
+	w.queueMu.Unlock()
+
+	w.optsMu.RLock()
+	defer w.optsMu.RUnlock()
+
+	if w.excludeModulePaths[modPath] {
+		return true
+	}
+
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, modPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		for _, name := range strings.Split(rel, string(filepath.Separator)) {
+			if w.ignoreDirectoryNames[name] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (w *Walker) Stop() {
 	if w.cancelFunc != nil {
 		w.cancelFunc()
@@ -115,10 +175,23 @@ func (w *Walker) EnqueuePath(path string) {
 	w.queueMu.Lock()
 	defer w.queueMu.Unlock()
 	heap.Push(w.queue, path)
+	w.roots[path] = true
 
 	w.triggerConsumption()
 }
 
+// Rewalk enqueues all previously enqueued paths again,
+// e.g. to reflect changed exclusions
+func (w *Walker) Rewalk() {
+	w.queueMu.Lock()
+	defer w.queueMu.Unlock()
+
+	for path := range w.roots {
+		heap.Push(w.queue, path)
+		w.triggerConsumption()
+	}
+}
+
 func (w *Walker) triggerConsumption() {
 	w.pushChan <- struct{}{}
 }
@@ -127,6 +200,7 @@ func (w *Walker) RemovePathFromQueue(path string) {
 	w.queueMu.Lock()
 	defer w.queueMu.Unlock()
 	w.queue.RemoveFromQueue(path)
+	delete(w.roots, path)
 }
 
 func (w *Walker) StartWalking(ctx context.Context) error {
@@ -210,10 +284,19 @@ func (w *Walker) IsWalking() bool {
 }
 
 func (w *Walker) isSkippableDir(dirName string) bool {
+	w.optsMu.RLock()
+	defer w.optsMu.RUnlock()
 	_, ok := w.ignoreDirectoryNames[dirName]
 	return ok
 }
 
+func (w *Walker) isExcludedModulePath(dir string) bool {
+	w.optsMu.RLock()
+	defer w.optsMu.RUnlock()
+	_, ok := w.excludeModulePaths[dir]
+	return ok
+}
+
 func (w *Walker) walk(ctx context.Context, rootPath string) error {
 	// We ignore the passed FS and instead read straight from OS FS
 	// because that would require reimplementing filepath.Walk and
@@ -241,7 +324,7 @@ func (w *Walker) walk(ctx context.Context, rootPath string) error {
 			return filepath.SkipDir
 		}
 
-		if _, ok := w.excludeModulePaths[dir]; ok {
+		if w.isExcludedModulePath(dir) {
 			return filepath.SkipDir
 		}
 
@@ -287,22 +370,20 @@ func (w *Walker) walk(ctx context.Context, rootPath string) error {
 			} else {
 				// If there is no module manifest we still collect references
 				// as this module may also be called by other modules.
-				err = w.modMgr.EnqueueModuleOp(dir, op.OpTypeDecodeReferenceTargets, nil)
-				if err != nil {
-					return err
-				}
-				err = w.modMgr.EnqueueModuleOp(dir, op.OpTypeDecodeReferenceOrigins, nil)
-				if err != nil {
-					return err
-				}
-				err = w.modMgr.EnqueueModuleOp(dir, op.OpTypeDecodeVarsReferences, nil)
+				err = w.modMgr.EnqueueModuleOpsInSequence(dir,
+					op.OpTypeDecodeReferenceTargets,
+					op.OpTypeDecodeReferenceOrigins,
+					op.OpTypeDecodeVarsReferences,
+					op.OpTypeValidateReferences,
+					op.OpTypeValidateVariables)
 				if err != nil {
 					return err
 				}
 			}
 
 			if dataDir.PluginLockFilePath != "" {
-				err = w.modMgr.EnqueueModuleOp(dir, op.OpTypeObtainSchema, nil)
+				err = w.modMgr.EnqueueModuleOp(dir, op.OpTypeObtainSchema,
+					validateModuleFunc(w.modMgr, w.fs, dir))
 				if err != nil {
 					return err
 				}
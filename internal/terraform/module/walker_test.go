@@ -0,0 +1,45 @@
+package module
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+)
+
+func TestWalker_IsPathExcluded(t *testing.T) {
+	root := t.TempDir()
+
+	w := NewWalker(filesystem.NewFilesystem(), nil)
+	w.EnqueuePath(root)
+	w.SetExcludeModulePaths([]string{filepath.Join(root, "excluded")})
+	w.SetIgnoreDirectoryNames([]string{"vendor"})
+
+	testCases := []struct {
+		path     string
+		excluded bool
+	}{
+		{filepath.Join(root, "mod"), false},
+		{filepath.Join(root, "excluded"), true},
+		{filepath.Join(root, "vendor", "mod"), true},
+		{filepath.Join(root, ".git", "mod"), true},
+		{filepath.Join(filepath.Dir(root), "vendor"), false},
+	}
+	for _, tc := range testCases {
+		if excluded := w.IsPathExcluded(tc.path); excluded != tc.excluded {
+			t.Errorf("%s: expected excluded: %t, given: %t", tc.path, tc.excluded, excluded)
+		}
+	}
+
+	// names are replaced rather than appended, defaults stay in place
+	w.SetIgnoreDirectoryNames([]string{})
+	if w.IsPathExcluded(filepath.Join(root, "vendor", "mod")) {
+		t.Fatal("expected previously ignored directory to no longer be excluded")
+	}
+	if !w.IsPathExcluded(filepath.Join(root, ".git", "mod")) {
+		t.Fatal("expected default ignored directory to remain excluded")
+	}
+	if skipDirNames["vendor"] {
+		t.Fatal("expected default ignored directory names to remain unchanged")
+	}
+}
@@ -154,7 +154,8 @@ func (w *watcher) processEvent(event fsnotify.Event) {
 				return
 			}
 			if containsPath(mod.Watchable.PluginLockFiles, eventPath) {
-				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema, nil)
+				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema,
+					validateModuleFunc(w.modMgr, w.fs, mod.Path))
 				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeGetTerraformVersion, nil)
 				return
 			}
@@ -180,7 +181,8 @@ func (w *watcher) processEvent(event fsnotify.Event) {
 							decodeCalledModulesFunc(w.modMgr, w, mod.Path))
 					}
 					if containsPath(mod.Watchable.PluginLockFiles, path) {
-						w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema, nil)
+						w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema,
+							validateModuleFunc(w.modMgr, w.fs, mod.Path))
 						w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeGetTerraformVersion, nil)
 						return nil
 					}
@@ -197,7 +199,8 @@ func (w *watcher) processEvent(event fsnotify.Event) {
 			}
 
 			if containsPath(mod.Watchable.PluginLockFiles, eventPath) {
-				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema, nil)
+				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeObtainSchema,
+					validateModuleFunc(w.modMgr, w.fs, mod.Path))
 				w.modMgr.EnqueueModuleOp(mod.Path, op.OpTypeGetTerraformVersion, nil)
 				return
 			}
@@ -245,21 +248,27 @@ func decodeCalledModulesFunc(modMgr ModuleManager, w Watcher, modPath string) De
 			}
 			modMgr.AddModule(mc.Path)
 
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeParseModuleConfiguration, nil)
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeLoadModuleMetadata, nil)
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeParseVariables, nil)
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeDecodeReferenceTargets, nil)
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeDecodeReferenceOrigins, nil)
-			modMgr.EnqueueModuleOp(mc.Path, op.OpTypeDecodeVarsReferences, nil)
+			modMgr.EnqueueModuleOpsInSequence(mc.Path,
+				op.OpTypeParseModuleConfiguration,
+				op.OpTypeParseVariables,
+				op.OpTypeLoadModuleMetadata,
+				op.OpTypeDecodeReferenceTargets,
+				op.OpTypeDecodeReferenceOrigins,
+				op.OpTypeDecodeVarsReferences,
+				op.OpTypeValidateReferences,
+				op.OpTypeValidateVariables)
 
 			if w != nil {
 				w.AddModule(mc.Path)
 			}
 		}
 
-		modMgr.EnqueueModuleOp(modPath, op.OpTypeDecodeReferenceTargets, nil)
-		modMgr.EnqueueModuleOp(modPath, op.OpTypeDecodeReferenceOrigins, nil)
-		modMgr.EnqueueModuleOp(modPath, op.OpTypeDecodeVarsReferences, nil)
+		modMgr.EnqueueModuleOpsInSequence(modPath,
+			op.OpTypeDecodeReferenceTargets,
+			op.OpTypeDecodeReferenceOrigins,
+			op.OpTypeDecodeVarsReferences,
+			op.OpTypeValidateReferences,
+			op.OpTypeValidateVariables)
 	}
 }
 
@@ -302,3 +311,26 @@ func (w *watcher) Stop() error {
 
 	return err
 }
+
+// validateModuleFunc returns a function which re-validates the module
+// once schema was obtained, as both schema validation and type inference
+// of reference targets depend on provider schemas.
+// Validation is skipped for modules without open documents, as diagnostics
+// are only published for these and are otherwise validated on didOpen.
+func validateModuleFunc(modMgr ModuleManager, fs filesystem.Filesystem, modPath string) DeferFunc {
+	return func(opErr error) {
+		if opErr != nil {
+			return
+		}
+
+		if hasOpenFiles, _ := fs.HasOpenFiles(modPath); !hasOpenFiles {
+			return
+		}
+
+		modMgr.EnqueueModuleOpsInSequence(modPath,
+			op.OpTypeDecodeReferenceTargets,
+			op.OpTypeDecodeReferenceOrigins,
+			op.OpTypeValidateReferences,
+			op.OpTypeValidateModuleSchema)
+	}
+}
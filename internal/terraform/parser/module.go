@@ -26,8 +26,6 @@ func ParseModuleFiles(fs FS, modPath string) (ast.ModFiles, ast.ModDiags, error)
 			continue
 		}
 
-		// TODO: overrides
-
 		fullPath := filepath.Join(modPath, name)
 
 		src, err := fs.ReadFile(fullPath)
@@ -45,5 +43,13 @@ func ParseModuleFiles(fs FS, modPath string) (ast.ModFiles, ast.ModDiags, error)
 		}
 	}
 
+	// Override files are kept as standalone files, so that they can be
+	// navigated like any other file, but we report any blocks
+	// which Terraform would fail to merge.
+	_, overrideDiags := MergeOverrideFiles(files)
+	for filename, oDiags := range overrideDiags {
+		diags[filename] = append(diags[filename], oDiags...)
+	}
+
 	return files, diags, nil
 }
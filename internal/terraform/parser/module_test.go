@@ -42,6 +42,30 @@ func TestParseModuleFiles(t *testing.T) {
 				"main.tf": nil,
 			},
 		},
+		{
+			"valid-mod-files-with-overrides",
+			map[string]struct{}{
+				"generated.tf.json": {},
+				"main.tf":           {},
+				"main_override.tf":  {},
+			},
+			map[string]hcl.Diagnostics{
+				"generated.tf.json": nil,
+				"main.tf":           nil,
+				"main_override.tf": {
+					{
+						Severity: hcl.DiagError,
+						Summary:  "Missing base output block to override",
+						Detail:   "There is no output block matching the override block. An override file can only override a block that was already defined in a primary configuration file.",
+						Subject: &hcl.Range{
+							Filename: "main_override.tf",
+							Start:    hcl.Pos{Line: 11, Column: 1, Byte: 119},
+							End:      hcl.Pos{Line: 11, Column: 17, Byte: 135},
+						},
+					},
+				},
+			},
+		},
 		{
 			"invalid-mod-files",
 			map[string]struct{}{
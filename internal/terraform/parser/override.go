@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MergeOverrideFiles returns module files with the content of override files
+// (override.tf, *_override.tf and their JSON variants) merged into
+// the primary files, as Terraform does when loading a module.
+//
+// Blocks in override files are merged into the matching block
+// of the primary files: attributes replace attributes of the same name
+// and nested blocks replace all nested blocks of the same type, except for
+// lifecycle and required_providers blocks, which are merged attribute-wise.
+// Any terraform blocks without a matching primary block are retained
+// in the override file.
+//
+// Blocks are merged on the level of hcl.Body, regardless of syntax,
+// such that the affected primary files are no longer represented
+// by *hclsyntax.Body. The returned files are therefore not suitable
+// for position-based lookups or walking the syntax tree.
+func MergeOverrideFiles(files ast.ModFiles) (ast.ModFiles, ast.ModDiags) {
+	merged := make(ast.ModFiles, len(files))
+	diags := make(ast.ModDiags, 0)
+
+	overrideNames := make([]ast.ModFilename, 0)
+	primaryNames := make([]ast.ModFilename, 0)
+	for name, f := range files {
+		if name.IsOverride() {
+			overrideNames = append(overrideNames, name)
+			continue
+		}
+		merged[name] = f
+		primaryNames = append(primaryNames, name)
+	}
+	sortFilenames(overrideNames)
+	sortFilenames(primaryNames)
+
+	primaries := newPrimaryIndex(files, primaryNames)
+
+	for _, overrideName := range overrideNames {
+		overrideFile := files[overrideName]
+
+		remainingBlocks := make(hcl.Blocks, 0)
+		content, _, _ := overrideFile.Body.PartialContent(overridableBlocksSchema)
+		for _, block := range content.Blocks {
+			if block.Type == "locals" {
+				attrs, _ := block.Body.JustAttributes()
+				for _, attr := range sortedAttributes(attrs) {
+					if !primaries.overrideLocal(merged, attr) {
+						diags[overrideName] = append(diags[overrideName], missingLocalDiag(attr))
+					}
+				}
+				continue
+			}
+
+			if primaries.overrideBlock(merged, block) {
+				continue
+			}
+
+			if block.Type == "terraform" {
+				remainingBlocks = append(remainingBlocks, block)
+				continue
+			}
+
+			diags[overrideName] = append(diags[overrideName], missingBlockDiag(block))
+		}
+
+		if len(remainingBlocks) > 0 {
+			merged[overrideName] = &hcl.File{
+				Body: &blocksBody{
+					blocks:           remainingBlocks,
+					missingItemRange: overrideFile.Body.MissingItemRange(),
+				},
+				Bytes: overrideFile.Bytes,
+			}
+		}
+	}
+
+	return merged, diags
+}
+
+// OverriddenBlocks returns keys of primary blocks which are
+// overridden by blocks of any override files, as returned by OverrideKey
+func OverriddenBlocks(files ast.ModFiles) map[string]bool {
+	keys := make(map[string]bool, 0)
+	for name, f := range files {
+		if !name.IsOverride() {
+			continue
+		}
+		content, _, _ := f.Body.PartialContent(overridableBlocksSchema)
+		for _, block := range content.Blocks {
+			if key, ok := OverrideKey(block); ok {
+				keys[key] = true
+			}
+		}
+	}
+	return keys
+}
+
+func missingLocalDiag(attr *hcl.Attribute) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Missing base local value definition to override",
+		Detail: fmt.Sprintf("There is no local value named %q. "+
+			"An override file can only override a local value that was already defined in a primary configuration file.", attr.Name),
+		Subject: attr.NameRange.Ptr(),
+	}
+}
+
+func missingBlockDiag(block *hcl.Block) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Missing base %s block to override", block.Type),
+		Detail: fmt.Sprintf("There is no %s block matching the override block. "+
+			"An override file can only override a block that was already defined in a primary configuration file.", block.Type),
+		Subject: block.DefRange.Ptr(),
+	}
+}
+
+// OverrideKey returns a key identifying the block
+// for the purposes of matching override blocks with primary blocks
+func OverrideKey(block *hcl.Block) (string, bool) {
+	switch block.Type {
+	case "terraform":
+		return block.Type, true
+	case "variable", "output", "module":
+		if len(block.Labels) != 1 {
+			return "", false
+		}
+		return fmt.Sprintf("%s.%s", block.Type, block.Labels[0]), true
+	case "resource", "data":
+		if len(block.Labels) != 2 {
+			return "", false
+		}
+		return fmt.Sprintf("%s.%s.%s", block.Type, block.Labels[0], block.Labels[1]), true
+	case "provider":
+		if len(block.Labels) != 1 {
+			return "", false
+		}
+		content, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{
+				{Name: "alias"},
+			},
+		})
+		alias := ""
+		if attr, ok := content.Attributes["alias"]; ok {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !val.Type().Equals(cty.String) || !val.IsKnown() || val.IsNull() {
+				return "", false
+			}
+			alias = val.AsString()
+		}
+		return fmt.Sprintf("%s.%s.%s", block.Type, block.Labels[0], alias), true
+	}
+
+	return "", false
+}
+
+func isAttributeMergedBlock(blockType string) bool {
+	return blockType == "lifecycle" || blockType == "required_providers"
+}
+
+func sortedAttributes(attrs hcl.Attributes) []*hcl.Attribute {
+	sorted := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+	return sorted
+}
+
+func sortFilenames(names []ast.ModFilename) {
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+}
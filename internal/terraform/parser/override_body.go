@@ -0,0 +1,397 @@
+package parser
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// overridableBlocksSchema describes top-level blocks of module files
+// which can be overridden, or which contain overridable items (locals)
+var overridableBlocksSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "locals"},
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "output", LabelNames: []string{"name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "provider", LabelNames: []string{"name"}},
+	},
+}
+
+// overriddenFileBody represents the body of a primary file
+// with blocks and local values overridden on the level of hcl.Body,
+// i.e. regardless of the syntax of either of the files
+type overriddenFileBody struct {
+	hcl.Body
+
+	// blocks represents bodies of override blocks, keyed by override key
+	blocks map[string][]hcl.Body
+	// locals represents local values overriding these of the same name
+	locals hcl.Attributes
+}
+
+func newOverriddenFileBody(body hcl.Body) *overriddenFileBody {
+	return &overriddenFileBody{
+		Body:   body,
+		blocks: make(map[string][]hcl.Body, 0),
+		locals: make(hcl.Attributes, 0),
+	}
+}
+
+func (b *overriddenFileBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := b.Body.Content(schema)
+	return b.overrideContent(content), diags
+}
+
+func (b *overriddenFileBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := b.Body.PartialContent(schema)
+	return b.overrideContent(content), remain, diags
+}
+
+func (b *overriddenFileBody) overrideContent(content *hcl.BodyContent) *hcl.BodyContent {
+	if content == nil {
+		return nil
+	}
+
+	newContent := *content
+	newContent.Blocks = make(hcl.Blocks, len(content.Blocks))
+	for i, block := range content.Blocks {
+		newContent.Blocks[i] = block
+
+		if block.Type == "locals" && len(b.locals) > 0 {
+			newBlock := *block
+			newBlock.Body = &overriddenLocalsBody{
+				Body:   block.Body,
+				locals: b.locals,
+			}
+			newContent.Blocks[i] = &newBlock
+			continue
+		}
+
+		key, ok := OverrideKey(block)
+		if !ok {
+			continue
+		}
+		overrides, ok := b.blocks[key]
+		if !ok {
+			continue
+		}
+
+		newBlock := *block
+		for _, override := range overrides {
+			newBlock.Body = &mergedBody{
+				base:     newBlock.Body,
+				override: override,
+			}
+		}
+		newContent.Blocks[i] = &newBlock
+	}
+
+	return &newContent
+}
+
+// overriddenLocalsBody represents the body of a locals block
+// where any attributes of the same name are replaced with
+// the given (overriding) local values
+type overriddenLocalsBody struct {
+	hcl.Body
+	locals hcl.Attributes
+}
+
+func (b *overriddenLocalsBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := b.Body.Content(schema)
+	if content == nil {
+		return nil, diags
+	}
+	newContent := *content
+	newContent.Attributes = b.overrideAttributes(content.Attributes)
+	return &newContent, diags
+}
+
+func (b *overriddenLocalsBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := b.Body.PartialContent(schema)
+	if content != nil {
+		newContent := *content
+		newContent.Attributes = b.overrideAttributes(content.Attributes)
+		content = &newContent
+	}
+
+	return content, &overriddenLocalsBody{
+		Body:   remain,
+		locals: b.locals,
+	}, diags
+}
+
+func (b *overriddenLocalsBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs, diags := b.Body.JustAttributes()
+	return b.overrideAttributes(attrs), diags
+}
+
+func (b *overriddenLocalsBody) overrideAttributes(attrs hcl.Attributes) hcl.Attributes {
+	merged := make(hcl.Attributes, len(attrs))
+	for name, attr := range attrs {
+		if override, ok := b.locals[name]; ok {
+			merged[name] = override
+			continue
+		}
+		merged[name] = attr
+	}
+	return merged
+}
+
+// mergedBody represents the body of a block merged with an override
+// block, following the same semantics as Terraform, i.e. attributes
+// replace attributes of the same name and nested blocks replace
+// all nested blocks of the same type, except for blocks which
+// are merged attribute-wise (lifecycle and required_providers).
+type mergedBody struct {
+	base, override hcl.Body
+}
+
+func (b *mergedBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	baseContent, cDiags := b.base.Content(schema)
+	diags = append(diags, cDiags...)
+	overrideContent, cDiags := b.override.Content(overrideSchema(schema))
+	diags = append(diags, cDiags...)
+
+	return mergeContent(baseContent, overrideContent), diags
+}
+
+func (b *mergedBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	baseContent, baseRemain, cDiags := b.base.PartialContent(schema)
+	diags = append(diags, cDiags...)
+	overrideContent, overrideRemain, cDiags := b.override.PartialContent(overrideSchema(schema))
+	diags = append(diags, cDiags...)
+
+	remain := &mergedBody{
+		base:     baseRemain,
+		override: overrideRemain,
+	}
+
+	return mergeContent(baseContent, overrideContent), remain, diags
+}
+
+func (b *mergedBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	baseAttrs, aDiags := b.base.JustAttributes()
+	diags = append(diags, aDiags...)
+	overrideAttrs, aDiags := b.override.JustAttributes()
+	diags = append(diags, aDiags...)
+
+	attrs := make(hcl.Attributes, len(baseAttrs)+len(overrideAttrs))
+	for name, attr := range baseAttrs {
+		attrs[name] = attr
+	}
+	for name, attr := range overrideAttrs {
+		attrs[name] = attr
+	}
+
+	return attrs, diags
+}
+
+func (b *mergedBody) MissingItemRange() hcl.Range {
+	return b.base.MissingItemRange()
+}
+
+func mergeContent(base, override *hcl.BodyContent) *hcl.BodyContent {
+	content := &hcl.BodyContent{
+		Attributes:       make(hcl.Attributes, 0),
+		Blocks:           make(hcl.Blocks, 0),
+		MissingItemRange: base.MissingItemRange,
+	}
+
+	for name, attr := range base.Attributes {
+		content.Attributes[name] = attr
+	}
+	for name, attr := range override.Attributes {
+		content.Attributes[name] = attr
+	}
+
+	overriddenTypes := make(map[string]bool, 0)
+	for _, block := range override.Blocks {
+		if isAttributeMergedBlock(block.Type) {
+			continue
+		}
+		overriddenTypes[block.Type] = true
+	}
+
+	for _, block := range base.Blocks {
+		if overriddenTypes[block.Type] {
+			continue
+		}
+		content.Blocks = append(content.Blocks, block)
+	}
+
+	for _, block := range override.Blocks {
+		if !isAttributeMergedBlock(block.Type) {
+			content.Blocks = append(content.Blocks, block)
+			continue
+		}
+
+		found := false
+		for i, b := range content.Blocks {
+			if b.Type == block.Type {
+				mergedBlock := *b
+				mergedBlock.Body = &mergedBody{
+					base:     b.Body,
+					override: block.Body,
+				}
+				content.Blocks[i] = &mergedBlock
+				found = true
+				break
+			}
+		}
+		if !found {
+			content.Blocks = append(content.Blocks, block)
+		}
+	}
+
+	return content
+}
+
+// overrideSchema returns the schema with all attributes optional
+// as override blocks may only contain a subset of attributes
+func overrideSchema(schema *hcl.BodySchema) *hcl.BodySchema {
+	newSchema := &hcl.BodySchema{
+		Attributes: make([]hcl.AttributeSchema, len(schema.Attributes)),
+		Blocks:     schema.Blocks,
+	}
+	for i, attr := range schema.Attributes {
+		newSchema.Attributes[i] = hcl.AttributeSchema{
+			Name:     attr.Name,
+			Required: false,
+		}
+	}
+	return newSchema
+}
+
+// blocksBody represents a body consisting only of the given blocks
+type blocksBody struct {
+	blocks           hcl.Blocks
+	missingItemRange hcl.Range
+}
+
+func (b *blocksBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, _, diags := b.PartialContent(schema)
+	return content, diags
+}
+
+func (b *blocksBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content := &hcl.BodyContent{
+		Attributes:       make(hcl.Attributes, 0),
+		Blocks:           make(hcl.Blocks, 0),
+		MissingItemRange: b.missingItemRange,
+	}
+
+	for _, block := range b.blocks {
+		for _, blockSchema := range schema.Blocks {
+			if blockSchema.Type == block.Type {
+				content.Blocks = append(content.Blocks, block)
+				break
+			}
+		}
+	}
+
+	return content, hcl.EmptyBody(), nil
+}
+
+func (b *blocksBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	return make(hcl.Attributes, 0), nil
+}
+
+func (b *blocksBody) MissingItemRange() hcl.Range {
+	return b.missingItemRange
+}
+
+// primaryIndex represents blocks and local values
+// of primary files for the purposes of merging
+// override blocks on the level of hcl.Body
+type primaryIndex struct {
+	names  []ast.ModFilename
+	blocks map[ast.ModFilename]map[string]bool
+	locals map[ast.ModFilename]map[string]bool
+}
+
+func newPrimaryIndex(files ast.ModFiles, primaryNames []ast.ModFilename) *primaryIndex {
+	idx := &primaryIndex{
+		names:  primaryNames,
+		blocks: make(map[ast.ModFilename]map[string]bool, len(primaryNames)),
+		locals: make(map[ast.ModFilename]map[string]bool, len(primaryNames)),
+	}
+
+	for _, name := range primaryNames {
+		idx.blocks[name] = make(map[string]bool, 0)
+		idx.locals[name] = make(map[string]bool, 0)
+
+		content, _, _ := files[name].Body.PartialContent(overridableBlocksSchema)
+		for _, block := range content.Blocks {
+			if block.Type == "locals" {
+				attrs, _ := block.Body.JustAttributes()
+				for attrName := range attrs {
+					idx.locals[name][attrName] = true
+				}
+				continue
+			}
+			key, ok := OverrideKey(block)
+			if !ok {
+				continue
+			}
+			idx.blocks[name][key] = true
+		}
+	}
+
+	return idx
+}
+
+func (idx *primaryIndex) overrideBlock(files ast.ModFiles, override *hcl.Block) bool {
+	key, ok := OverrideKey(override)
+	if !ok {
+		return false
+	}
+
+	for _, name := range idx.names {
+		if !idx.blocks[name][key] {
+			continue
+		}
+		body := overriddenBody(files, name)
+		body.blocks[key] = append(body.blocks[key], override.Body)
+		return true
+	}
+
+	return false
+}
+
+func (idx *primaryIndex) overrideLocal(files ast.ModFiles, override *hcl.Attribute) bool {
+	for _, name := range idx.names {
+		if !idx.locals[name][override.Name] {
+			continue
+		}
+		body := overriddenBody(files, name)
+		body.locals[override.Name] = override
+		return true
+	}
+
+	return false
+}
+
+// overriddenBody returns the body of the given file wrapped
+// in overriddenFileBody, wrapping it first where necessary
+func overriddenBody(files ast.ModFiles, name ast.ModFilename) *overriddenFileBody {
+	if body, ok := files[name].Body.(*overriddenFileBody); ok {
+		return body
+	}
+
+	body := newOverriddenFileBody(files[name].Body)
+	files[name] = &hcl.File{
+		Body:  body,
+		Bytes: files[name].Bytes,
+	}
+	return body
+}
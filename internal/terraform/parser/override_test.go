@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestMergeOverrideFiles(t *testing.T) {
+	fs := afero.NewIOFS(afero.NewOsFs())
+	modPath := filepath.Join("testdata", "valid-mod-files-with-overrides")
+
+	files, _, err := ParseModuleFiles(fs, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, diags := MergeOverrideFiles(files)
+	if len(diags["main_override.tf"]) != 1 {
+		t.Fatalf("expected 1 diagnostic for override file, given: %#v", diags)
+	}
+
+	expectedNames := map[string]struct{}{
+		"generated.tf.json": {},
+		"main.tf":           {},
+	}
+	if diff := cmp.Diff(expectedNames, mapKeys(merged)); diff != "" {
+		t.Fatalf("unexpected merged files: %s", diff)
+	}
+
+	content, _, _ := merged["main.tf"].Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "variable", LabelNames: []string{"name"}},
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+		},
+	})
+	if len(content.Blocks) != 2 {
+		t.Fatalf("expected 2 blocks, given %d", len(content.Blocks))
+	}
+
+	variable, _, _ := content.Blocks[0].Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "default"}},
+	})
+	val, _ := variable.Attributes["default"].Expr.Value(nil)
+	if !val.RawEquals(cty.StringVal("bar")) {
+		t.Fatalf("expected overridden default value, given: %#v", val)
+	}
+
+	resource, _, _ := content.Blocks[1].Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "ami", Required: true}},
+		Blocks:     []hcl.BlockHeaderSchema{{Type: "lifecycle"}},
+	})
+	if _, ok := resource.Attributes["ami"]; !ok {
+		t.Fatal("expected base attribute to be retained")
+	}
+	if len(resource.Blocks) != 1 {
+		t.Fatalf("expected 1 lifecycle block, given %d", len(resource.Blocks))
+	}
+	lifecycleAttrs := make(map[string]struct{}, 0)
+	attrs, _ := resource.Blocks[0].Body.JustAttributes()
+	for name := range attrs {
+		lifecycleAttrs[name] = struct{}{}
+	}
+	expectedAttrs := map[string]struct{}{
+		"create_before_destroy": {},
+		"prevent_destroy":       {},
+	}
+	if diff := cmp.Diff(expectedAttrs, lifecycleAttrs); diff != "" {
+		t.Fatalf("unexpected lifecycle attributes: %s", diff)
+	}
+
+	// original files must remain intact
+	originalBody := files["main.tf"].Body.(*hclsyntax.Body)
+	originalVal, _ := originalBody.Blocks[0].Body.Attributes["default"].Expr.Value(nil)
+	if !originalVal.RawEquals(cty.StringVal("foo")) {
+		t.Fatalf("expected original file to remain unchanged, given: %#v", originalVal)
+	}
+	if _, ok := files["generated.tf.json"].Body.(*hclsyntax.Body); ok {
+		t.Fatal("expected JSON file to be parsed with JSON syntax")
+	}
+}
+
+func TestMergeOverrideFiles_json(t *testing.T) {
+	fs := afero.NewIOFS(afero.NewOsFs())
+	modPath := filepath.Join("testdata", "valid-mod-files-with-json-overrides")
+
+	files, _, err := ParseModuleFiles(fs, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, diags := MergeOverrideFiles(files)
+	if len(diags["main_override.tf.json"]) != 1 {
+		t.Fatalf("expected 1 diagnostic for JSON override file, given: %#v", diags)
+	}
+	if len(diags["override.tf"]) != 0 {
+		t.Fatalf("expected no diagnostics for override file, given: %#v", diags["override.tf"])
+	}
+
+	expectedNames := map[string]struct{}{
+		"generated.tf.json": {},
+		"main.tf":           {},
+	}
+	if diff := cmp.Diff(expectedNames, mapKeys(merged)); diff != "" {
+		t.Fatalf("unexpected merged files: %s", diff)
+	}
+
+	schema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "variable", LabelNames: []string{"name"}},
+			{Type: "locals"},
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+		},
+	}
+
+	content, _, _ := merged["main.tf"].Body.PartialContent(schema)
+	if len(content.Blocks) != 3 {
+		t.Fatalf("expected 3 blocks, given %d", len(content.Blocks))
+	}
+
+	variable, _, _ := content.Blocks[0].Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "default"}},
+	})
+	val, _ := variable.Attributes["default"].Expr.Value(nil)
+	if !val.RawEquals(cty.StringVal("bar")) {
+		t.Fatalf("expected overridden default value, given: %#v", val)
+	}
+
+	locals, _ := content.Blocks[1].Body.JustAttributes()
+	val, _ = locals["prefix"].Expr.Value(nil)
+	if !val.RawEquals(cty.StringVal("two")) {
+		t.Fatalf("expected overridden local value, given: %#v", val)
+	}
+
+	resource, _, _ := content.Blocks[2].Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "ami", Required: true}},
+		Blocks:     []hcl.BlockHeaderSchema{{Type: "lifecycle"}},
+	})
+	if _, ok := resource.Attributes["ami"]; !ok {
+		t.Fatal("expected base attribute to be retained")
+	}
+	if len(resource.Blocks) != 1 {
+		t.Fatalf("expected 1 lifecycle block, given %d", len(resource.Blocks))
+	}
+	lifecycleAttrs := make(map[string]struct{}, 0)
+	attrs, _ := resource.Blocks[0].Body.JustAttributes()
+	for name := range attrs {
+		lifecycleAttrs[name] = struct{}{}
+	}
+	expectedAttrs := map[string]struct{}{
+		"create_before_destroy": {},
+		"prevent_destroy":       {},
+	}
+	if diff := cmp.Diff(expectedAttrs, lifecycleAttrs); diff != "" {
+		t.Fatalf("unexpected lifecycle attributes: %s", diff)
+	}
+
+	// native syntax override of a block in JSON primary file
+	content, _, _ = merged["generated.tf.json"].Body.PartialContent(schema)
+	if len(content.Blocks) != 1 {
+		t.Fatalf("expected 1 block, given %d", len(content.Blocks))
+	}
+	variable, _, _ = content.Blocks[0].Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "default"}},
+	})
+	val, _ = variable.Attributes["default"].Expr.Value(nil)
+	if !val.RawEquals(cty.StringVal("bar")) {
+		t.Fatalf("expected overridden default value, given: %#v", val)
+	}
+}
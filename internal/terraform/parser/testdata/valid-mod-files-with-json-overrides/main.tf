@@ -0,0 +1,15 @@
+variable "name" {
+  default = "foo"
+}
+
+locals {
+  prefix = "one"
+}
+
+resource "aws_instance" "web" {
+  ami = "ami-123"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,3 @@
+variable "generated" {
+  default = "bar"
+}
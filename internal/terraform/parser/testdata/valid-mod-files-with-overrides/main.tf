@@ -0,0 +1,11 @@
+variable "name" {
+  default = "foo"
+}
+
+resource "aws_instance" "web" {
+  ami = "ami-123"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,13 @@
+variable "name" {
+  default = "bar"
+}
+
+resource "aws_instance" "web" {
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+output "missing" {
+  value = var.name
+}
@@ -0,0 +1,163 @@
+// Package plugin obtains provider schemas directly from installed
+// provider plugins via the gRPC-based plugin protocol,
+// i.e. without requiring Terraform to be installed.
+//
+// Stubs of the protocol (tfplugin5 and tfplugin6) are generated code
+// copied from github.com/hashicorp/terraform-plugin-go v0.9.0,
+// which only provides these as internal packages.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin5"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin6"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"google.golang.org/grpc"
+)
+
+// Handshake is the handshake configuration
+// which all provider plugins expect
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  5,
+	MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+	MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+}
+
+// ProviderPluginName is the name under which providers
+// serve the provider service
+const ProviderPluginName = "provider"
+
+// pluginStartTimeout is the maximum time to wait
+// for a launched plugin to complete the handshake
+const pluginStartTimeout = 10 * time.Second
+
+// protocolVersions represents versions of the plugin protocol
+// which schemas can be obtained through
+var protocolVersions = []int{5, 6}
+
+type providerPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+
+	protocolVersion int
+}
+
+func (p *providerPlugin) GRPCServer(*goplugin.GRPCBroker, *grpc.Server) error {
+	return fmt.Errorf("serving providers is not supported")
+}
+
+func (p *providerPlugin) GRPCClient(ctx context.Context, broker *goplugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	switch p.protocolVersion {
+	case 5:
+		return &provider5{client: tfplugin5.NewProviderClient(conn)}, nil
+	case 6:
+		return &provider6{client: tfplugin6.NewProviderClient(conn)}, nil
+	}
+	return nil, fmt.Errorf("unsupported protocol version: %d", p.protocolVersion)
+}
+
+// providerClient represents a provider speaking
+// any of the supported protocol versions
+type providerClient interface {
+	ProviderSchema(ctx context.Context) (*tfjson.ProviderSchema, error)
+}
+
+func versionedPlugins() map[int]goplugin.PluginSet {
+	plugins := make(map[int]goplugin.PluginSet, len(protocolVersions))
+	for _, protocolVersion := range protocolVersions {
+		plugins[protocolVersion] = goplugin.PluginSet{
+			ProviderPluginName: &providerPlugin{protocolVersion: protocolVersion},
+		}
+	}
+	return plugins
+}
+
+// ProviderSchema launches the provider plugin at the given path
+// and obtains its schema. The plugin is killed once the context
+// is cancelled, or if it fails to start within pluginStartTimeout.
+func ProviderSchema(ctx context.Context, pluginPath string) (*tfjson.ProviderSchema, error) {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		VersionedPlugins: versionedPlugins(),
+		Cmd:              exec.CommandContext(ctx, pluginPath),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		AutoMTLS:         true,
+		StartTimeout:     pluginStartTimeout,
+		Logger:           hclog.NewNullLogger(),
+	})
+	defer client.Kill()
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch %s: %w", pluginPath, err)
+	}
+
+	raw, err := rpcClient.Dispense(ProviderPluginName)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw.(providerClient).ProviderSchema(ctx)
+}
+
+// ProviderSchemas obtains schemas of all providers recorded
+// in the dependency lock file from plugins installed
+// within the module's data directory (.terraform/providers).
+//
+// Only plugins whose package matches any of the hashes recorded
+// in the lock file are launched, as the data directory may contain
+// arbitrary executables, e.g. in untrusted repositories.
+//
+// Schemas are keyed by the full provider address, same as
+// in the output of "terraform providers schema -json".
+func ProviderSchemas(ctx context.Context, modPath string, locks map[tfaddr.Provider]datadir.ProviderLock) (*tfjson.ProviderSchemas, error) {
+	ps := &tfjson.ProviderSchemas{
+		Schemas: make(map[string]*tfjson.ProviderSchema, len(locks)),
+	}
+
+	addrs := make([]tfaddr.Provider, 0, len(locks))
+	for pAddr := range locks {
+		addrs = append(addrs, pAddr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	for _, pAddr := range addrs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lock := locks[pAddr]
+		pluginPath, ok := datadir.ProviderPluginPath(modPath, pAddr, lock.Version)
+		if !ok {
+			return nil, fmt.Errorf("provider %s %s is not installed", pAddr, lock.Version)
+		}
+
+		hash, err := datadir.ProviderPackageHash(filepath.Dir(pluginPath))
+		if err != nil {
+			return nil, fmt.Errorf("unable to verify provider %s %s: %w", pAddr, lock.Version, err)
+		}
+		if !lock.HasHash([]string{hash}) {
+			return nil, fmt.Errorf("provider %s %s does not match any hashes in the dependency lock file",
+				pAddr, lock.Version)
+		}
+
+		schema, err := ProviderSchema(ctx, pluginPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to obtain schema of %s: %w", pAddr, err)
+		}
+		ps.Schemas[pAddr.String()] = schema
+	}
+
+	return ps, nil
+}
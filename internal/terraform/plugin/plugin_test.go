@@ -0,0 +1,235 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestProviderSchemas(t *testing.T) {
+	modPath := t.TempDir()
+	addr := tfaddr.NewDefaultProvider("fake")
+	pv := version.Must(version.NewVersion("1.0.0"))
+
+	pluginDir := buildFakeProvider(t, modPath, addr, pv)
+	hash, err := datadir.ProviderPackageHash(pluginDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locks := map[tfaddr.Provider]datadir.ProviderLock{
+		addr: {
+			Version: pv,
+			Hashes:  []string{"zh:archive", hash},
+		},
+	}
+
+	testCases := []struct {
+		protocolVersion string
+		expectedSchema  *tfjson.ProviderSchema
+	}{
+		{"5", expectedFakeSchema(false)},
+		{"6", expectedFakeSchema(true)},
+	}
+
+	for _, tc := range testCases {
+		t.Run("protocol "+tc.protocolVersion, func(t *testing.T) {
+			t.Setenv("FAKE_PROVIDER_PROTOCOL", tc.protocolVersion)
+
+			ps, err := ProviderSchemas(context.Background(), modPath, locks)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedSchemas := &tfjson.ProviderSchemas{
+				Schemas: map[string]*tfjson.ProviderSchema{
+					"registry.terraform.io/hashicorp/fake": tc.expectedSchema,
+				},
+			}
+			if diff := cmp.Diff(expectedSchemas, ps, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected schemas: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderSchemas_notInstalled(t *testing.T) {
+	locks := map[tfaddr.Provider]datadir.ProviderLock{
+		tfaddr.NewDefaultProvider("fake"): {
+			Version: version.Must(version.NewVersion("1.0.0")),
+		},
+	}
+
+	_, err := ProviderSchemas(context.Background(), t.TempDir(), locks)
+	if err == nil {
+		t.Fatal("expected error for provider which is not installed")
+	}
+}
+
+func TestProviderSchemas_hashMismatch(t *testing.T) {
+	modPath := t.TempDir()
+	addr := tfaddr.NewDefaultProvider("fake")
+	pv := version.Must(version.NewVersion("1.0.0"))
+
+	buildFakeProvider(t, modPath, addr, pv)
+
+	locks := map[tfaddr.Provider]datadir.ProviderLock{
+		addr: {
+			Version: pv,
+			Hashes:  []string{"h1:fxTJxTsTUp4D9RMvvJzCbJwnv0mCeUAUk5gSBNSNqfg="},
+		},
+	}
+
+	_, err := ProviderSchemas(context.Background(), modPath, locks)
+	if err == nil {
+		t.Fatal("expected error for provider not matching the lock file")
+	}
+}
+
+func TestProviderSchemas_cancelled(t *testing.T) {
+	modPath := t.TempDir()
+	addr := tfaddr.NewDefaultProvider("fake")
+	pv := version.Must(version.NewVersion("1.0.0"))
+
+	pluginDir := buildFakeProvider(t, modPath, addr, pv)
+	hash, err := datadir.ProviderPackageHash(pluginDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locks := map[tfaddr.Provider]datadir.ProviderLock{
+		addr: {Version: pv, Hashes: []string{hash}},
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	_, err = ProviderSchemas(ctx, modPath, locks)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, given: %#v", err)
+	}
+}
+
+// buildFakeProvider builds the fake provider from testdata and places it
+// where Terraform would unpack the provider package during init,
+// returning the directory of the package
+func buildFakeProvider(t *testing.T, modPath string, addr tfaddr.Provider, pv *version.Version) string {
+	if testing.Short() {
+		t.Skip("building fake provider is skipped in short mode")
+	}
+
+	pluginDir := filepath.Join(modPath, ".terraform", "providers",
+		addr.Hostname.String(), addr.Namespace, addr.Type, pv.String(),
+		runtime.GOOS+"_"+runtime.GOARCH)
+	err := os.MkdirAll(pluginDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binName := "terraform-provider-" + addr.Type + "_v" + pv.String()
+	if runtime.GOOS == "windows" {
+		binName += ".exe"
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(pluginDir, binName), "./testdata/fake-provider")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to build fake provider: %s\n%s", err, out)
+	}
+
+	return pluginDir
+}
+
+func expectedFakeSchema(nestedAttributes bool) *tfjson.ProviderSchema {
+	resourceAttributes := map[string]*tfjson.SchemaAttribute{
+		"name": {
+			AttributeType:   cty.String,
+			DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+			Required:        true,
+		},
+	}
+	if nestedAttributes {
+		resourceAttributes["config"] = &tfjson.SchemaAttribute{
+			AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"enabled": {
+						AttributeType:   cty.Bool,
+						DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+						Optional:        true,
+					},
+				},
+				NestingMode: tfjson.SchemaNestingModeSingle,
+			},
+			DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+			Optional:        true,
+		}
+	}
+
+	return &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"region": {
+						AttributeType:   cty.String,
+						Description:     "AWS region",
+						DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+						Optional:        true,
+					},
+				},
+				DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+			},
+		},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"fake_thing": {
+				Version: 1,
+				Block: &tfjson.SchemaBlock{
+					Attributes: resourceAttributes,
+					NestedBlocks: map[string]*tfjson.SchemaBlockType{
+						"setting": {
+							NestingMode: tfjson.SchemaNestingModeList,
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"value": {
+										AttributeType:   cty.Number,
+										DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+										Optional:        true,
+									},
+								},
+								DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+							},
+							MinItems: 1,
+						},
+					},
+					Description:     "A **fake** thing",
+					DescriptionKind: tfjson.SchemaDescriptionKindMarkdown,
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"fake_data": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"id": {
+							AttributeType:   cty.String,
+							DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+							Optional:        true,
+						},
+					},
+					DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+					Deprecated:      true,
+				},
+			},
+		},
+	}
+}
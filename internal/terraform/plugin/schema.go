@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Schemas returned by providers of either protocol version are converted
+// into the same structure as produced by "terraform providers schema -json"
+
+func attributeType(name string, rawType []byte) (cty.Type, error) {
+	if len(rawType) == 0 {
+		return cty.NilType, nil
+	}
+
+	var ty cty.Type
+	err := ty.UnmarshalJSON(rawType)
+	if err != nil {
+		return cty.NilType, fmt.Errorf("invalid type of attribute %q: %w", name, err)
+	}
+	return ty, nil
+}
+
+func diagnosticMessage(summary, detail string) string {
+	if detail != "" {
+		return fmt.Sprintf("%s: %s", summary, detail)
+	}
+	return summary
+}
+
+func diagnosticsError(errDiags []string) error {
+	if len(errDiags) == 0 {
+		return nil
+	}
+	return fmt.Errorf("provider returned errors: %s", strings.Join(errDiags, "; "))
+}
+
+func descriptionKind(isMarkdown bool) tfjson.SchemaDescriptionKind {
+	if isMarkdown {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+	return tfjson.SchemaDescriptionKindPlain
+}
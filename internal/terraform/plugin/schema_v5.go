@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"context"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin5"
+)
+
+var blockNestingModes5 = map[tfplugin5.Schema_NestedBlock_NestingMode]tfjson.SchemaNestingMode{
+	tfplugin5.Schema_NestedBlock_SINGLE: tfjson.SchemaNestingModeSingle,
+	tfplugin5.Schema_NestedBlock_LIST:   tfjson.SchemaNestingModeList,
+	tfplugin5.Schema_NestedBlock_SET:    tfjson.SchemaNestingModeSet,
+	tfplugin5.Schema_NestedBlock_MAP:    tfjson.SchemaNestingModeMap,
+	tfplugin5.Schema_NestedBlock_GROUP:  tfjson.SchemaNestingModeGroup,
+}
+
+// provider5 represents a provider speaking protocol version 5
+type provider5 struct {
+	client tfplugin5.ProviderClient
+}
+
+func (p *provider5) ProviderSchema(ctx context.Context) (*tfjson.ProviderSchema, error) {
+	resp, err := p.client.GetSchema(ctx, &tfplugin5.GetProviderSchema_Request{})
+	if err != nil {
+		return nil, err
+	}
+
+	errDiags := make([]string, 0)
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfplugin5.Diagnostic_ERROR {
+			errDiags = append(errDiags, diagnosticMessage(diag.Summary, diag.Detail))
+		}
+	}
+	if err := diagnosticsError(errDiags); err != nil {
+		return nil, err
+	}
+
+	ps := &tfjson.ProviderSchema{}
+
+	if resp.Provider != nil {
+		ps.ConfigSchema, err = convertSchema5(resp.Provider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(resp.ResourceSchemas) > 0 {
+		ps.ResourceSchemas = make(map[string]*tfjson.Schema, len(resp.ResourceSchemas))
+		for name, schema := range resp.ResourceSchemas {
+			ps.ResourceSchemas[name], err = convertSchema5(schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(resp.DataSourceSchemas) > 0 {
+		ps.DataSourceSchemas = make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas))
+		for name, schema := range resp.DataSourceSchemas {
+			ps.DataSourceSchemas[name], err = convertSchema5(schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ps, nil
+}
+
+func convertSchema5(schema *tfplugin5.Schema) (*tfjson.Schema, error) {
+	s := &tfjson.Schema{
+		Version: uint64(schema.Version),
+	}
+
+	if schema.Block != nil {
+		block, err := convertBlock5(schema.Block)
+		if err != nil {
+			return nil, err
+		}
+		s.Block = block
+	}
+
+	return s, nil
+}
+
+func convertBlock5(block *tfplugin5.Schema_Block) (*tfjson.SchemaBlock, error) {
+	b := &tfjson.SchemaBlock{
+		Description:     block.Description,
+		DescriptionKind: descriptionKind(block.DescriptionKind == tfplugin5.StringKind_MARKDOWN),
+		Deprecated:      block.Deprecated,
+	}
+
+	if len(block.Attributes) > 0 {
+		b.Attributes = make(map[string]*tfjson.SchemaAttribute, len(block.Attributes))
+		for _, attr := range block.Attributes {
+			a, err := convertAttribute5(attr)
+			if err != nil {
+				return nil, err
+			}
+			b.Attributes[attr.Name] = a
+		}
+	}
+
+	if len(block.BlockTypes) > 0 {
+		b.NestedBlocks = make(map[string]*tfjson.SchemaBlockType, len(block.BlockTypes))
+		for _, blockType := range block.BlockTypes {
+			bt, err := convertNestedBlock5(blockType)
+			if err != nil {
+				return nil, err
+			}
+			b.NestedBlocks[blockType.TypeName] = bt
+		}
+	}
+
+	return b, nil
+}
+
+func convertAttribute5(attr *tfplugin5.Schema_Attribute) (*tfjson.SchemaAttribute, error) {
+	ty, err := attributeType(attr.Name, attr.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfjson.SchemaAttribute{
+		AttributeType:   ty,
+		Description:     attr.Description,
+		DescriptionKind: descriptionKind(attr.DescriptionKind == tfplugin5.StringKind_MARKDOWN),
+		Deprecated:      attr.Deprecated,
+		Required:        attr.Required,
+		Optional:        attr.Optional,
+		Computed:        attr.Computed,
+		Sensitive:       attr.Sensitive,
+	}, nil
+}
+
+func convertNestedBlock5(blockType *tfplugin5.Schema_NestedBlock) (*tfjson.SchemaBlockType, error) {
+	bt := &tfjson.SchemaBlockType{
+		NestingMode: blockNestingModes5[blockType.Nesting],
+		MinItems:    uint64(blockType.MinItems),
+		MaxItems:    uint64(blockType.MaxItems),
+	}
+
+	if blockType.Block != nil {
+		block, err := convertBlock5(blockType.Block)
+		if err != nil {
+			return nil, err
+		}
+		bt.Block = block
+	}
+
+	return bt, nil
+}
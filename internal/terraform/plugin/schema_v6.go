@@ -0,0 +1,188 @@
+package plugin
+
+import (
+	"context"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin6"
+)
+
+var blockNestingModes6 = map[tfplugin6.Schema_NestedBlock_NestingMode]tfjson.SchemaNestingMode{
+	tfplugin6.Schema_NestedBlock_SINGLE: tfjson.SchemaNestingModeSingle,
+	tfplugin6.Schema_NestedBlock_LIST:   tfjson.SchemaNestingModeList,
+	tfplugin6.Schema_NestedBlock_SET:    tfjson.SchemaNestingModeSet,
+	tfplugin6.Schema_NestedBlock_MAP:    tfjson.SchemaNestingModeMap,
+	tfplugin6.Schema_NestedBlock_GROUP:  tfjson.SchemaNestingModeGroup,
+}
+
+var objectNestingModes6 = map[tfplugin6.Schema_Object_NestingMode]tfjson.SchemaNestingMode{
+	tfplugin6.Schema_Object_SINGLE: tfjson.SchemaNestingModeSingle,
+	tfplugin6.Schema_Object_LIST:   tfjson.SchemaNestingModeList,
+	tfplugin6.Schema_Object_SET:    tfjson.SchemaNestingModeSet,
+	tfplugin6.Schema_Object_MAP:    tfjson.SchemaNestingModeMap,
+}
+
+// provider6 represents a provider speaking protocol version 6
+type provider6 struct {
+	client tfplugin6.ProviderClient
+}
+
+func (p *provider6) ProviderSchema(ctx context.Context) (*tfjson.ProviderSchema, error) {
+	resp, err := p.client.GetProviderSchema(ctx, &tfplugin6.GetProviderSchema_Request{})
+	if err != nil {
+		return nil, err
+	}
+
+	errDiags := make([]string, 0)
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfplugin6.Diagnostic_ERROR {
+			errDiags = append(errDiags, diagnosticMessage(diag.Summary, diag.Detail))
+		}
+	}
+	if err := diagnosticsError(errDiags); err != nil {
+		return nil, err
+	}
+
+	ps := &tfjson.ProviderSchema{}
+
+	if resp.Provider != nil {
+		ps.ConfigSchema, err = convertSchema6(resp.Provider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(resp.ResourceSchemas) > 0 {
+		ps.ResourceSchemas = make(map[string]*tfjson.Schema, len(resp.ResourceSchemas))
+		for name, schema := range resp.ResourceSchemas {
+			ps.ResourceSchemas[name], err = convertSchema6(schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(resp.DataSourceSchemas) > 0 {
+		ps.DataSourceSchemas = make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas))
+		for name, schema := range resp.DataSourceSchemas {
+			ps.DataSourceSchemas[name], err = convertSchema6(schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ps, nil
+}
+
+func convertSchema6(schema *tfplugin6.Schema) (*tfjson.Schema, error) {
+	s := &tfjson.Schema{
+		Version: uint64(schema.Version),
+	}
+
+	if schema.Block != nil {
+		block, err := convertBlock6(schema.Block)
+		if err != nil {
+			return nil, err
+		}
+		s.Block = block
+	}
+
+	return s, nil
+}
+
+func convertBlock6(block *tfplugin6.Schema_Block) (*tfjson.SchemaBlock, error) {
+	b := &tfjson.SchemaBlock{
+		Description:     block.Description,
+		DescriptionKind: descriptionKind(block.DescriptionKind == tfplugin6.StringKind_MARKDOWN),
+		Deprecated:      block.Deprecated,
+	}
+
+	if len(block.Attributes) > 0 {
+		b.Attributes = make(map[string]*tfjson.SchemaAttribute, len(block.Attributes))
+		for _, attr := range block.Attributes {
+			a, err := convertAttribute6(attr)
+			if err != nil {
+				return nil, err
+			}
+			b.Attributes[attr.Name] = a
+		}
+	}
+
+	if len(block.BlockTypes) > 0 {
+		b.NestedBlocks = make(map[string]*tfjson.SchemaBlockType, len(block.BlockTypes))
+		for _, blockType := range block.BlockTypes {
+			bt, err := convertNestedBlock6(blockType)
+			if err != nil {
+				return nil, err
+			}
+			b.NestedBlocks[blockType.TypeName] = bt
+		}
+	}
+
+	return b, nil
+}
+
+func convertAttribute6(attr *tfplugin6.Schema_Attribute) (*tfjson.SchemaAttribute, error) {
+	ty, err := attributeType(attr.Name, attr.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &tfjson.SchemaAttribute{
+		AttributeType:   ty,
+		Description:     attr.Description,
+		DescriptionKind: descriptionKind(attr.DescriptionKind == tfplugin6.StringKind_MARKDOWN),
+		Deprecated:      attr.Deprecated,
+		Required:        attr.Required,
+		Optional:        attr.Optional,
+		Computed:        attr.Computed,
+		Sensitive:       attr.Sensitive,
+	}
+
+	if attr.NestedType != nil {
+		a.AttributeNestedType, err = convertObject6(attr.NestedType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+func convertObject6(obj *tfplugin6.Schema_Object) (*tfjson.SchemaNestedAttributeType, error) {
+	nt := &tfjson.SchemaNestedAttributeType{
+		NestingMode: objectNestingModes6[obj.Nesting],
+	}
+
+	if len(obj.Attributes) > 0 {
+		nt.Attributes = make(map[string]*tfjson.SchemaAttribute, len(obj.Attributes))
+		for _, attr := range obj.Attributes {
+			a, err := convertAttribute6(attr)
+			if err != nil {
+				return nil, err
+			}
+			nt.Attributes[attr.Name] = a
+		}
+	}
+
+	return nt, nil
+}
+
+func convertNestedBlock6(blockType *tfplugin6.Schema_NestedBlock) (*tfjson.SchemaBlockType, error) {
+	bt := &tfjson.SchemaBlockType{
+		NestingMode: blockNestingModes6[blockType.Nesting],
+		MinItems:    uint64(blockType.MinItems),
+		MaxItems:    uint64(blockType.MaxItems),
+	}
+
+	if blockType.Block != nil {
+		block, err := convertBlock6(blockType.Block)
+		if err != nil {
+			return nil, err
+		}
+		bt.Block = block
+	}
+
+	return bt, nil
+}
@@ -0,0 +1,174 @@
+// fake-provider is a minimal provider plugin used in tests,
+// which only implements the RPC returning the provider schema.
+//
+// FAKE_PROVIDER_PROTOCOL may be set to 5 or 6 to limit
+// the supported protocol versions.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin5"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plugin/tfplugin6"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	plugins := map[int]goplugin.PluginSet{
+		5: {"provider": &fakeProvider{protocolVersion: 5}},
+		6: {"provider": &fakeProvider{protocolVersion: 6}},
+	}
+	if v := os.Getenv("FAKE_PROVIDER_PROTOCOL"); v != "" {
+		protocolVersion, err := strconv.Atoi(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid protocol version: %s", err)
+			os.Exit(1)
+		}
+		plugins = map[int]goplugin.PluginSet{
+			protocolVersion: plugins[protocolVersion],
+		}
+	}
+
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: goplugin.HandshakeConfig{
+			ProtocolVersion:  5,
+			MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+			MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+		},
+		VersionedPlugins: plugins,
+		GRPCServer:       goplugin.DefaultGRPCServer,
+	})
+}
+
+type fakeProvider struct {
+	goplugin.NetRPCUnsupportedPlugin
+
+	protocolVersion int
+}
+
+func (p *fakeProvider) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) error {
+	if p.protocolVersion == 6 {
+		tfplugin6.RegisterProviderServer(s, &server6{})
+		return nil
+	}
+	tfplugin5.RegisterProviderServer(s, &server5{})
+	return nil
+}
+
+func (p *fakeProvider) GRPCClient(context.Context, *goplugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+type server5 struct {
+	tfplugin5.UnimplementedProviderServer
+}
+
+func (*server5) GetSchema(context.Context, *tfplugin5.GetProviderSchema_Request) (*tfplugin5.GetProviderSchema_Response, error) {
+	return &tfplugin5.GetProviderSchema_Response{
+		Provider: &tfplugin5.Schema{
+			Block: &tfplugin5.Schema_Block{
+				Attributes: []*tfplugin5.Schema_Attribute{
+					{Name: "region", Type: []byte(`"string"`), Description: "AWS region", Optional: true},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfplugin5.Schema{
+			"fake_thing": {
+				Version: 1,
+				Block: &tfplugin5.Schema_Block{
+					Attributes: []*tfplugin5.Schema_Attribute{
+						{Name: "name", Type: []byte(`"string"`), Required: true},
+					},
+					BlockTypes: []*tfplugin5.Schema_NestedBlock{
+						{
+							TypeName: "setting",
+							Block: &tfplugin5.Schema_Block{
+								Attributes: []*tfplugin5.Schema_Attribute{
+									{Name: "value", Type: []byte(`"number"`), Optional: true},
+								},
+							},
+							Nesting:  tfplugin5.Schema_NestedBlock_LIST,
+							MinItems: 1,
+						},
+					},
+					Description:     "A **fake** thing",
+					DescriptionKind: tfplugin5.StringKind_MARKDOWN,
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfplugin5.Schema{
+			"fake_data": {
+				Block: &tfplugin5.Schema_Block{
+					Attributes: []*tfplugin5.Schema_Attribute{
+						{Name: "id", Type: []byte(`"string"`), Optional: true},
+					},
+					Deprecated: true,
+				},
+			},
+		},
+	}, nil
+}
+
+type server6 struct {
+	tfplugin6.UnimplementedProviderServer
+}
+
+func (*server6) GetProviderSchema(context.Context, *tfplugin6.GetProviderSchema_Request) (*tfplugin6.GetProviderSchema_Response, error) {
+	return &tfplugin6.GetProviderSchema_Response{
+		Provider: &tfplugin6.Schema{
+			Block: &tfplugin6.Schema_Block{
+				Attributes: []*tfplugin6.Schema_Attribute{
+					{Name: "region", Type: []byte(`"string"`), Description: "AWS region", Optional: true},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfplugin6.Schema{
+			"fake_thing": {
+				Version: 1,
+				Block: &tfplugin6.Schema_Block{
+					Attributes: []*tfplugin6.Schema_Attribute{
+						{Name: "name", Type: []byte(`"string"`), Required: true},
+						{
+							Name: "config",
+							NestedType: &tfplugin6.Schema_Object{
+								Attributes: []*tfplugin6.Schema_Attribute{
+									{Name: "enabled", Type: []byte(`"bool"`), Optional: true},
+								},
+								Nesting: tfplugin6.Schema_Object_SINGLE,
+							},
+							Optional: true,
+						},
+					},
+					BlockTypes: []*tfplugin6.Schema_NestedBlock{
+						{
+							TypeName: "setting",
+							Block: &tfplugin6.Schema_Block{
+								Attributes: []*tfplugin6.Schema_Attribute{
+									{Name: "value", Type: []byte(`"number"`), Optional: true},
+								},
+							},
+							Nesting:  tfplugin6.Schema_NestedBlock_LIST,
+							MinItems: 1,
+						},
+					},
+					Description:     "A **fake** thing",
+					DescriptionKind: tfplugin6.StringKind_MARKDOWN,
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfplugin6.Schema{
+			"fake_data": {
+				Block: &tfplugin6.Schema_Block{
+					Attributes: []*tfplugin6.Schema_Attribute{
+						{Name: "id", Type: []byte(`"string"`), Optional: true},
+					},
+					Deprecated: true,
+				},
+			},
+		},
+	}, nil
+}